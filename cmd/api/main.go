@@ -2,22 +2,39 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/dwilanang/psp/config"
 	_ "github.com/dwilanang/psp/docs"
-	"github.com/dwilanang/psp/infrastructure/db/postgres"
-
-	authroute "github.com/dwilanang/psp/internal/auth/route"
-	"github.com/dwilanang/psp/internal/middleware"
-	"github.com/dwilanang/psp/internal/registry"
-	roleroute "github.com/dwilanang/psp/internal/role/route"
-	userroute "github.com/dwilanang/psp/internal/user/route"
-	"github.com/dwilanang/psp/pkg/logger"
-	"github.com/gin-gonic/gin"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+// command is a subcommand of the binary. run receives the arguments following the command name.
+type command struct {
+	name    string
+	summary string
+	run     func(cfg *config.Config, args []string) error
+}
+
+func commands() []command {
+	return []command{
+		{"serve", "start the HTTP server (default)", runServe},
+		{"migrate", "apply, roll back or create database migrations", runMigrate},
+		{"seed", "create the default roles and, with -count, development users", runSeed},
+		{"create-superadmin", "create a SUPERADMIN user, prompting for missing flags", runCreateSuperadmin},
+		{"rotate-keys", "generate a new JWT signing key in JWT_KEYS_DIR", runRotateKeys},
+		{"routes", "print every route with its middleware", runRoutes},
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: api [command] [flags]\n\ncommands:")
+	for _, c := range commands() {
+		fmt.Fprintf(os.Stderr, "  %-18s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(os.Stderr, "\nRun `api <command> -h` for the flags of a command.")
+}
+
 // @title GO SKELETON API
 // @version 1.0
 // @description go skeleton project API
@@ -29,35 +46,28 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 func main() {
-	cfg := config.LoadConfig()
-	// This is the entry point for the PSP application.
-	// You can initialize your application here, set up routes, etc.
-
-	// Initialize a database postgres connection
-	dbPostgres := postgres.Connect(cfg)
-	if dbPostgres == nil {
-		fmt.Println("Failed to initialize the database connection")
+	// The server starts when no command is given, so existing deployments keep working.
+	name, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	if name == "help" {
+		usage()
 		return
 	}
 
-	r := gin.Default()
-	r.Use(logger.RequestLogger())
-
-	registry := registry.NewRegistry(cfg, dbPostgres)
-
-	api := r.Group("/api/v1")
-
-	// Swagger
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-	// Auth
-	authroute.RegisterRoutes(api, registry)
-
-	api.Use(middleware.JWTAuthMiddleware(cfg.JWTSecret))
-
-	// Register all feature routes here, keep it simple
-	roleroute.RegisterRoutes(api, registry)
-	userroute.RegisterRoutes(api, registry)
+	for _, c := range commands() {
+		if c.name != name {
+			continue
+		}
+		if err := c.run(config.LoadConfig(), args); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	fmt.Println("Server is running on port ", cfg.AppPort)
-	r.Run(fmt.Sprintf(":%s", cfg.AppPort))
+	usage()
+	fmt.Fprintf(os.Stderr, "\nunknown command %q\n", name)
+	os.Exit(2)
 }
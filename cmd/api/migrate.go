@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dwilanang/psp/config"
+	"github.com/dwilanang/psp/db/migrations"
+	"github.com/dwilanang/psp/infrastructure/db/migrate"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+)
+
+const migrateUsage = `usage: api migrate [-dir db/migrations] <command>
+
+commands:
+  up             apply every pending migration
+  down           roll back the latest applied migration
+  status         list migrations and when they were applied
+  redo           roll back the latest applied migration and apply it again
+  create <name>  write an empty migration into -dir; rebuild to embed it`
+
+// runMigrate runs a migration command against the embedded migrations.
+func runMigrate(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dir := flags.String("dir", "db/migrations", "directory new migrations are created in")
+	flags.Usage = func() { fmt.Fprintln(flags.Output(), migrateUsage) }
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return errors.New("missing migrate command")
+	}
+
+	command := flags.Arg(0)
+	if command == "create" {
+		if flags.NArg() != 2 {
+			return errors.New("usage: api migrate create <name>")
+		}
+		file, err := migrate.Create(*dir, flags.Arg(1), time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Println("Created", file)
+		return nil
+	}
+
+	// The command decides what to apply, so startup auto-migration is disabled for its connection.
+	dbConfig := *cfg
+	dbConfig.DBAutoMigrate = "false"
+	db := postgres.Connect(&dbConfig)
+	defer postgres.Close()
+
+	migrator, err := migrate.New(db.DB, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migrations\n", applied)
+	case "down":
+		migration, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Rolled back %d_%s\n", migration.Version, migration.Name)
+	case "redo":
+		migration, err := migrator.Redo(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Redid %d_%s\n", migration.Version, migration.Name)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "APPLIED AT\tMIGRATION")
+		for _, status := range statuses {
+			appliedAt := "Pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.DateTime)
+			}
+			fmt.Fprintf(w, "%s\t%d_%s.sql\n", appliedAt, status.Migration.Version, status.Migration.Name)
+		}
+		return w.Flush()
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown migrate command %q", command)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dwilanang/psp/config"
+	"github.com/dwilanang/psp/internal/auth/keys"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// runRotateKeys generates a new signing key in JWT_KEYS_DIR. Old keys stay so tokens they signed still verify;
+// -retire removes their private halves once every replica signs with the new key.
+func runRotateKeys(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("rotate-keys", flag.ContinueOnError)
+	dir := flags.String("dir", cfg.JWTKeysDir, "directory holding the keys")
+	method := flags.String("method", cfg.JWTSigningMethod, "signing method of the new key: RS256, ES256 or EdDSA")
+	kid := flags.String("kid", time.Now().UTC().Format("20060102150405"), "kid of the new key")
+	retire := flags.Bool("retire", false, "remove the private key of every other kid, keeping their public keys")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if strings.EqualFold(*method, jwt.SigningMethodHS256.Alg()) || *method == "" {
+		return errors.New("HS256 signs with JWT_SECRET, so rotate it by changing the secret; key files are used by RS256, ES256 and EdDSA")
+	}
+
+	key, err := keys.Generate(*kid, *method)
+	if err != nil {
+		return err
+	}
+	if err := keys.Write(*dir, key); err != nil {
+		return err
+	}
+	fmt.Printf("Generated %s key %s in %s\n", key.Method.Alg(), key.ID, *dir)
+
+	if *retire {
+		retired, err := keys.Retire(*dir, key.ID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Retired the private keys of %s\n", strings.Join(retired, ", "))
+	}
+
+	// Without JWT_ACTIVE_KEY_ID the greatest kid signs, which may not be the new one.
+	ks, err := keys.LoadDir(*dir, "")
+	if err != nil {
+		return err
+	}
+	if cfg.JWTActiveKeyID != "" || ks.SigningKey().ID != key.ID {
+		fmt.Printf("Set JWT_ACTIVE_KEY_ID=%s to sign with the new key\n", key.ID)
+	}
+	return nil
+}
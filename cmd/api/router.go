@@ -0,0 +1,62 @@
+package main
+
+import (
+	attendanceroute "github.com/dwilanang/psp/internal/attendance/route"
+	auditroute "github.com/dwilanang/psp/internal/audit/route"
+	authroute "github.com/dwilanang/psp/internal/auth/route"
+	healthroute "github.com/dwilanang/psp/internal/health/route"
+	leaveroute "github.com/dwilanang/psp/internal/leave/route"
+	"github.com/dwilanang/psp/internal/middleware"
+	overtimeroute "github.com/dwilanang/psp/internal/overtime/route"
+	payrollroute "github.com/dwilanang/psp/internal/payroll/route"
+	paysliproute "github.com/dwilanang/psp/internal/payslip/route"
+	"github.com/dwilanang/psp/internal/registry"
+	reimbursementroute "github.com/dwilanang/psp/internal/reimbursement/route"
+	roleroute "github.com/dwilanang/psp/internal/role/route"
+	salaryroute "github.com/dwilanang/psp/internal/salary/route"
+	userroute "github.com/dwilanang/psp/internal/user/route"
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/dwilanang/psp/pkg/logger"
+	"github.com/dwilanang/psp/utils/response"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// newRouter builds the Gin engine with every route of the application.
+// extra runs before anything else on every route; the routes command uses it to inspect handler chains.
+func newRouter(registry *registry.Registry, extra ...gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Use(extra...)
+	r.Use(gin.Logger(), gin.Recovery())
+	r.Use(logger.RequestLogger(), middleware.ErrorHandler())
+	r.NoRoute(func(c *gin.Context) {
+		response.Error(c, apperror.NotFound("route not found"), "")
+	})
+
+	api := r.Group("/api/v1")
+
+	// Probes
+	healthroute.RegisterRoutes(r, registry)
+	// Swagger
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// Auth
+	authroute.RegisterWellKnownRoutes(r, registry)
+	authroute.RegisterRoutes(api, registry)
+
+	api.Use(registry.NewAuthMiddleware())
+
+	// Register all feature routes here, keep it simple
+	roleroute.RegisterRoutes(api, registry)
+	userroute.RegisterRoutes(api, registry)
+	salaryroute.RegisterRoutes(api, registry)
+	paysliproute.RegisterRoutes(api, registry)
+	payrollroute.RegisterRoutes(api, registry)
+	attendanceroute.RegisterRoutes(api, registry)
+	overtimeroute.RegisterRoutes(api, registry)
+	reimbursementroute.RegisterRoutes(api, registry)
+	leaveroute.RegisterRoutes(api, registry)
+	auditroute.RegisterRoutes(api, registry)
+
+	return r
+}
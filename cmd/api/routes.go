@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dwilanang/psp/config"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/auth/keys"
+	"github.com/dwilanang/psp/internal/registry"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+)
+
+const modulePath = "github.com/dwilanang/psp"
+
+// funcSuffix matches the suffixes Go adds to the names of closures and method values.
+var funcSuffix = regexp.MustCompile(`(\.func\d+)+$|-fm$`)
+
+// runRoutes prints every route with the middleware and handler it runs, in order.
+// No database connection or signing key is needed: routes are only registered, never served.
+func runRoutes(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("routes", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// sqlx.Open only validates the driver name; nothing connects until a query runs.
+	db, err := sqlx.Open(cfg.DBDriver, "")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	keySet, err := keys.NewHMACKeySet("routes", "routes")
+	if err != nil {
+		return err
+	}
+
+	// The first middleware records the handler chain of the matched route and stops it.
+	gin.SetMode(gin.ReleaseMode)
+	var chain []string
+	r := newRouter(registry.NewRegistry(cfg, postgres.NewDB(db), keySet), func(c *gin.Context) {
+		chain = c.HandlerNames()[1:]
+		c.Abort()
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tHANDLERS")
+	routes := r.Routes()
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	for _, route := range routes {
+		chain = nil
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(route.Method, samplePath(route.Path), http.NoBody))
+
+		names := make([]string, 0, len(chain))
+		for _, name := range chain {
+			names = append(names, shortName(name))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", route.Method, route.Path, strings.Join(names, " → "))
+	}
+	return w.Flush()
+}
+
+// samplePath fills the parameters of a route path so a request matches it.
+func samplePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "0"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// shortName drops the module path and closure suffixes from a handler name,
+// e.g. "github.com/dwilanang/psp/internal/role/handler.(*Handler).GetAll-fm" becomes "role/handler.(*Handler).GetAll".
+// Handlers from other modules keep only their package name.
+func shortName(name string) string {
+	if trimmed, ok := strings.CutPrefix(name, modulePath+"/internal/"); ok {
+		name = trimmed
+	} else if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return funcSuffix.ReplaceAllString(name, "")
+}
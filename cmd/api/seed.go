@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/dwilanang/psp/config"
+	"github.com/dwilanang/psp/db/seed"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+)
+
+// runSeed creates the default roles and, with -count, development users with salary histories.
+func runSeed(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	count := flags.Int("count", 0, "number of development users to create; 0 only creates the default roles")
+	randomSeed := flags.Int64("seed", 1, "random seed, the same seed and count always create the same users")
+	password := flags.String("password", seed.DefaultPassword, "password of every development user")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	db := postgres.Connect(cfg)
+	defer postgres.Close()
+
+	if *count <= 0 {
+		roles, err := seed.Roles(db.DB)
+		if err != nil {
+			return err
+		}
+		for _, role := range seed.DefaultRoles {
+			fmt.Printf("Role %s has id %d\n", role.Name, roles[role.Name])
+		}
+		return nil
+	}
+
+	users, err := seed.Development(db.DB, seed.Options{Count: *count, Seed: *randomSeed, Password: *password})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Seeded %d development users (%s to %s) with password %q\n",
+		len(users), users[0].Username, users[len(users)-1].Username, *password)
+	return nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/dwilanang/psp/config"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/auth/keys"
+	"github.com/dwilanang/psp/internal/registry"
+	"github.com/dwilanang/psp/utils"
+)
+
+// runServe starts the HTTP server on APP_PORT and serves until SIGINT or SIGTERM.
+// The readiness probe fails from the first signal on while requests are still served for
+// HTTP_SHUTDOWN_DRAIN_DELAY seconds, so load balancers notice before the listener closes; in-flight
+// requests then get HTTP_SHUTDOWN_TIMEOUT seconds to finish before the database pools are closed.
+func runServe(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// Initialize a database postgres connection
+	dbPostgres := postgres.Connect(cfg)
+	if dbPostgres == nil {
+		return errors.New("failed to initialize the database connection")
+	}
+	defer func() {
+		if err := postgres.Close(); err != nil {
+			log.Printf("Failed to close database connections: %v", err)
+		}
+	}()
+
+	// Load the keys used to sign and verify JWTs
+	keySet, err := keys.Load(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load JWT signing keys: %w", err)
+	}
+
+	registry := registry.NewRegistry(cfg, dbPostgres, keySet)
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%s", cfg.AppPort),
+		Handler:           newRouter(registry),
+		ReadTimeout:       seconds(cfg.HTTPReadTimeout),
+		ReadHeaderTimeout: seconds(cfg.HTTPReadHeaderTimeout),
+		WriteTimeout:      seconds(cfg.HTTPWriteTimeout),
+		IdleTimeout:       seconds(cfg.HTTPIdleTimeout),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	// After the first signal the default handling is restored, so a second one kills the process without waiting.
+	context.AfterFunc(ctx, stop)
+
+	fmt.Println("Server is running on port ", cfg.AppPort)
+	return serve(ctx, srv, registry.SetShuttingDown, seconds(cfg.HTTPShutdownDrainDelay), seconds(cfg.HTTPShutdownTimeout))
+}
+
+// serve runs srv until ctx is done. It then calls draining, which fails the readiness probe, keeps serving for
+// drainDelay so the load balancer stops routing new requests here, and finally stops accepting connections and
+// waits up to timeout for in-flight requests. draining may be nil.
+func serve(ctx context.Context, srv *http.Server, draining func(), drainDelay time.Duration, timeout time.Duration) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	if draining != nil {
+		draining()
+	}
+	if drainDelay > 0 {
+		log.Printf("Shutting down, draining for %s", drainDelay)
+		select {
+		case err := <-serveErr:
+			return err
+		case <-time.After(drainDelay):
+		}
+	}
+
+	log.Println("Shutting down, waiting for in-flight requests")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	log.Println("Server stopped")
+	return nil
+}
+
+// seconds converts a number of seconds from the configuration; 0 disables a timeout.
+func seconds(value string) time.Duration {
+	return time.Duration(utils.ConvertStringToInt(value)) * time.Second
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dwilanang/psp/internal/health"
+	healthhandler "github.com/dwilanang/psp/internal/health/handler"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func freeAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().String()
+}
+
+func TestServe_DrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	srv := &http.Server{
+		Addr: freeAddr(t),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			time.Sleep(200 * time.Millisecond)
+			io.WriteString(w, "done")
+		}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- serve(ctx, srv, nil, 0, 5*time.Second) }()
+
+	body := make(chan string, 1)
+	go func() {
+		var resp *http.Response
+		var err error
+		// Retry until the listener is up.
+		for i := 0; i < 50; i++ {
+			if resp, err = http.Get("http://" + srv.Addr); err == nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if err != nil {
+			body <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		body <- string(data)
+	}()
+
+	<-started
+	cancel()
+
+	assert.Equal(t, "done", <-body)
+	assert.NoError(t, <-served)
+}
+
+func TestServe_ShutdownDeadline(t *testing.T) {
+	started := make(chan struct{})
+	srv := &http.Server{
+		Addr: freeAddr(t),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			time.Sleep(time.Second)
+		}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- serve(ctx, srv, nil, 0, 50*time.Millisecond) }()
+	go func() {
+		for i := 0; i < 50; i++ {
+			if _, err := http.Get("http://" + srv.Addr); err == nil {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	<-started
+	cancel()
+
+	assert.ErrorIs(t, <-served, context.DeadlineExceeded)
+}
+
+func TestServe_FailsReadinessWhileDraining(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	checker := health.NewChecker(func(context.Context, time.Duration) map[string]error {
+		return map[string]error{"main/master": nil}
+	}, nil, time.Second)
+	router := gin.New()
+	router.GET("/readyz", healthhandler.NewHandler(checker).Readiness)
+	srv := &http.Server{Addr: freeAddr(t), Handler: router}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- serve(ctx, srv, checker.SetShuttingDown, 300*time.Millisecond, time.Second) }()
+
+	readyz := func() (int, health.Report) {
+		var resp *http.Response
+		var err error
+		// Retry until the listener is up.
+		for i := 0; i < 50; i++ {
+			if resp, err = http.Get("http://" + srv.Addr + "/readyz"); err == nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var report health.Report
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+		return resp.StatusCode, report
+	}
+
+	status, report := readyz()
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, health.StatusReady, report.Status)
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		status, report := readyz()
+		return status == http.StatusServiceUnavailable && report.Status == health.StatusShuttingDown
+	}, 200*time.Millisecond, 10*time.Millisecond)
+
+	assert.NoError(t, <-served)
+}
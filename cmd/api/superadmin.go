@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/dwilanang/psp/config"
+	"github.com/dwilanang/psp/db/seed"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	auditmodel "github.com/dwilanang/psp/internal/audit/model"
+	auditrepository "github.com/dwilanang/psp/internal/audit/repository"
+	rolerepository "github.com/dwilanang/psp/internal/role/repository"
+	userdto "github.com/dwilanang/psp/internal/user/dto"
+	userrepository "github.com/dwilanang/psp/internal/user/repository"
+	userservice "github.com/dwilanang/psp/internal/user/service"
+	utilrequest "github.com/dwilanang/psp/utils/request"
+)
+
+// runCreateSuperadmin creates the first SUPERADMIN user, which /users/register cannot do because it requires one.
+// Flags that are not given are prompted for when stdin is a terminal.
+func runCreateSuperadmin(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("create-superadmin", flag.ContinueOnError)
+	username := flags.String("username", "", "username of the new user")
+	fullName := flags.String("full-name", "", "full name of the new user")
+	password := flags.String("password", "", "password of the new user; prompted for when empty, which keeps it out of the shell history")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	stat, _ := os.Stdin.Stat()
+	interactive := stat != nil && stat.Mode()&os.ModeCharDevice != 0
+	in := bufio.NewReader(os.Stdin)
+	for _, field := range []struct {
+		prompt string
+		value  *string
+		secret bool
+	}{
+		{"Username", username, false},
+		{"Full name", fullName, false},
+		{"Password", password, true},
+	} {
+		if *field.value != "" {
+			continue
+		}
+		if !interactive {
+			return errors.New("-username, -full-name and -password are required when stdin is not a terminal")
+		}
+		value, err := prompt(in, field.prompt, field.secret)
+		if err != nil {
+			return err
+		}
+		*field.value = value
+	}
+	if !utilrequest.IsValidUsername(*username) {
+		return errors.New("username may only contain letters, digits, dots, underscores and hyphens, and must start with a letter or digit")
+	}
+	if !utilrequest.IsStrongPassword(*password) {
+		return errors.New("password must be at least 8 characters long and contain an upper case letter, a lower case letter, a digit and a symbol")
+	}
+
+	db := postgres.Connect(cfg)
+	defer postgres.Close()
+
+	roles, err := seed.Roles(db.DB)
+	if err != nil {
+		return err
+	}
+
+	// Register hashes the password with bcrypt like /users/register; no session is revoked.
+	// The user is audited as created by the system, since nobody is signed in.
+	svc := userservice.NewService(db, userrepository.NewRepository(db), rolerepository.NewRepository(db), auditrepository.NewRepository(db), nil, nil)
+	_, err = svc.Register(&userdto.UserRequest{
+		Username: *username,
+		FullName: *fullName,
+		Password: *password,
+		RoleID:   roles[seed.RoleSuperadmin],
+	}, auditmodel.Actor{})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Created SUPERADMIN user %s\n", *username)
+	return nil
+}
+
+// prompt reads a line from in. Secret input is not echoed when stty is available.
+func prompt(in *bufio.Reader, label string, secret bool) (string, error) {
+	fmt.Printf("%s: ", label)
+	if secret {
+		if err := stty("-echo"); err == nil {
+			defer func() {
+				stty("echo")
+				fmt.Println()
+			}()
+		}
+	}
+
+	line, err := in.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func stty(mode string) error {
+	cmd := exec.Command("stty", mode)
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
@@ -8,17 +8,53 @@ import (
 )
 
 type Config struct {
-	AppName       string
-	AppPort       string
-	DBDriver      string
-	DBHost        string
-	DBName        string
-	DBPassword    string
-	DBUser        string
-	DBPort        string
-	JWTSecret     string
-	JWTExpiration string
-	JWTType       string
+	AppName                     string
+	AppPort                     string
+	HTTPReadTimeout             string
+	HTTPReadHeaderTimeout       string
+	HTTPWriteTimeout            string
+	HTTPIdleTimeout             string
+	HTTPShutdownTimeout         string
+	HTTPShutdownDrainDelay      string
+	HealthCheckTimeout          string
+	DBDriver                    string
+	DBHost                      string
+	DBName                      string
+	DBPassword                  string
+	DBUser                      string
+	DBPort                      string
+	DBAutoMigrate               string
+	DBReplicaDSNs               string
+	DBReplicaCheckInterval      string
+	JWTSecret                   string
+	JWTExpiration               string
+	JWTType                     string
+	JWTRefreshExpiration        string
+	JWTRevocationCacheTTL       string
+	JWTSigningMethod            string
+	JWTKeysDir                  string
+	JWTActiveKeyID              string
+	PermissionCacheTTL          string
+	PayslipTemplateDir          string
+	PayslipTemplate             string
+	AttendanceTimezone          string
+	AttendanceWorkStart         string
+	AttendanceLateGrace         string
+	AttendanceLatePenalty       string
+	DeductAbsentDays            string
+	OvertimeWeekdayRate         string
+	OvertimeWeekendRate         string
+	OvertimeHourlyDivisor       string
+	ReimbursementCategories     string
+	ReimbursementMaxReceiptSize string
+	ReimbursementStorage        string
+	ReimbursementStorageDir     string
+	ReimbursementS3Endpoint     string
+	ReimbursementS3Region       string
+	ReimbursementS3Bucket       string
+	ReimbursementS3AccessKey    string
+	ReimbursementS3SecretKey    string
+	ReimbursementS3PathStyle    string
 }
 
 func LoadConfig() *Config {
@@ -28,17 +64,53 @@ func LoadConfig() *Config {
 	}
 
 	return &Config{
-		AppName:       getEnv("APP_NAME", "payslip-service"),
-		AppPort:       getEnv("APP_PORT", "8000"),
-		DBDriver:      getEnv("DB_DRIVER", "postgres"),
-		DBHost:        getEnv("DB_HOST", "localhost"),
-		DBPort:        getEnv("DB_PORT", ""),
-		DBName:        getEnv("DB_NAME", ""),
-		DBUser:        getEnv("DB_USER", ""),
-		DBPassword:    getEnv("DB_PASSWORD", ""),
-		JWTSecret:     getEnv("JWT_SECRET", ""),
-		JWTExpiration: getEnv("JWT_EXPIRATION", "0"),
-		JWTType:       getEnv("JWT_TYPE", "bearer"),
+		AppName:                     getEnv("APP_NAME", "payslip-service"),
+		AppPort:                     getEnv("APP_PORT", "8000"),
+		HTTPReadTimeout:             getEnv("HTTP_READ_TIMEOUT", "30"),
+		HTTPReadHeaderTimeout:       getEnv("HTTP_READ_HEADER_TIMEOUT", "10"),
+		HTTPWriteTimeout:            getEnv("HTTP_WRITE_TIMEOUT", "60"),
+		HTTPIdleTimeout:             getEnv("HTTP_IDLE_TIMEOUT", "120"),
+		HTTPShutdownTimeout:         getEnv("HTTP_SHUTDOWN_TIMEOUT", "30"),
+		HTTPShutdownDrainDelay:      getEnv("HTTP_SHUTDOWN_DRAIN_DELAY", "5"),
+		HealthCheckTimeout:          getEnv("HEALTH_CHECK_TIMEOUT", "2"),
+		DBDriver:                    getEnv("DB_DRIVER", "postgres"),
+		DBHost:                      getEnv("DB_HOST", "localhost"),
+		DBPort:                      getEnv("DB_PORT", ""),
+		DBName:                      getEnv("DB_NAME", ""),
+		DBUser:                      getEnv("DB_USER", ""),
+		DBPassword:                  getEnv("DB_PASSWORD", ""),
+		DBAutoMigrate:               getEnv("DB_AUTO_MIGRATE", "false"),
+		DBReplicaDSNs:               getEnv("DB_REPLICA_DSNS", ""),
+		DBReplicaCheckInterval:      getEnv("DB_REPLICA_CHECK_INTERVAL", "5"),
+		JWTSecret:                   getEnv("JWT_SECRET", ""),
+		JWTExpiration:               getEnv("JWT_EXPIRATION", "0"),
+		JWTType:                     getEnv("JWT_TYPE", "bearer"),
+		JWTRefreshExpiration:        getEnv("JWT_REFRESH_EXPIRATION", "168"),
+		JWTRevocationCacheTTL:       getEnv("JWT_REVOCATION_CACHE_TTL", "30"),
+		JWTSigningMethod:            getEnv("JWT_SIGNING_METHOD", "HS256"),
+		JWTKeysDir:                  getEnv("JWT_KEYS_DIR", "keys"),
+		JWTActiveKeyID:              getEnv("JWT_ACTIVE_KEY_ID", ""),
+		PermissionCacheTTL:          getEnv("PERMISSION_CACHE_TTL", "60"),
+		PayslipTemplateDir:          getEnv("PAYSLIP_TEMPLATE_DIR", "templates/payslip"),
+		PayslipTemplate:             getEnv("PAYSLIP_TEMPLATE", "default"),
+		AttendanceTimezone:          getEnv("ATTENDANCE_TIMEZONE", "Asia/Jakarta"),
+		AttendanceWorkStart:         getEnv("ATTENDANCE_WORK_START", "09:00"),
+		AttendanceLateGrace:         getEnv("ATTENDANCE_LATE_GRACE", "0"),
+		AttendanceLatePenalty:       getEnv("ATTENDANCE_LATE_PENALTY", "0"),
+		DeductAbsentDays:            getEnv("ATTENDANCE_DEDUCT_ABSENT", "false"),
+		OvertimeWeekdayRate:         getEnv("OVERTIME_WEEKDAY_RATE", "1.5"),
+		OvertimeWeekendRate:         getEnv("OVERTIME_WEEKEND_RATE", "2"),
+		OvertimeHourlyDivisor:       getEnv("OVERTIME_HOURLY_DIVISOR", "173"),
+		ReimbursementCategories:     getEnv("REIMBURSEMENT_CATEGORIES", "transport,meal,medical,travel,other"),
+		ReimbursementMaxReceiptSize: getEnv("REIMBURSEMENT_MAX_RECEIPT_SIZE", "5"),
+		ReimbursementStorage:        getEnv("REIMBURSEMENT_STORAGE", "local"),
+		ReimbursementStorageDir:     getEnv("REIMBURSEMENT_STORAGE_DIR", "storage"),
+		ReimbursementS3Endpoint:     getEnv("REIMBURSEMENT_S3_ENDPOINT", ""),
+		ReimbursementS3Region:       getEnv("REIMBURSEMENT_S3_REGION", "us-east-1"),
+		ReimbursementS3Bucket:       getEnv("REIMBURSEMENT_S3_BUCKET", ""),
+		ReimbursementS3AccessKey:    getEnv("REIMBURSEMENT_S3_ACCESS_KEY", ""),
+		ReimbursementS3SecretKey:    getEnv("REIMBURSEMENT_S3_SECRET_KEY", ""),
+		ReimbursementS3PathStyle:    getEnv("REIMBURSEMENT_S3_PATH_STYLE", "false"),
 	}
 }
 
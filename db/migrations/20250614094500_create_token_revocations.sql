@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "revoked_tokens" (
+  "jti" uuid PRIMARY KEY,
+  "user_id" int NOT NULL,
+  "expires_at" timestamp NOT NULL,
+  "revoked_by" int,
+  "revoked_at" timestamp NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX "revoked_tokens_expires_at_idx" ON "revoked_tokens" ("expires_at");
+
+CREATE TABLE "user_session_revocations" (
+  "user_id" int PRIMARY KEY,
+  "revoked_before" timestamp NOT NULL,
+  "revoked_by" int,
+  "updated_at" timestamp NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE "revoked_tokens" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "user_session_revocations" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_session_revocations;
+DROP TABLE IF EXISTS revoked_tokens;
+-- +goose StatementEnd
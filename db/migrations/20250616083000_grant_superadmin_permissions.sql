@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE roles SET privilege = '*', updated_at = NOW() WHERE name = 'SUPERADMIN' AND COALESCE(privilege, '') = '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE roles SET privilege = NULL, updated_at = NOW() WHERE name = 'SUPERADMIN' AND privilege = '*';
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "users" ADD COLUMN "is_active" boolean NOT NULL DEFAULT true;
+ALTER TABLE "users" ADD COLUMN "deleted_by" int;
+ALTER TABLE "users" ADD COLUMN "deleted_at" timestamp;
+
+CREATE INDEX "users_role_id_idx" ON "users" ("role_id") WHERE "deleted_at" IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS users_role_id_idx;
+ALTER TABLE "users" DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "deleted_by";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "is_active";
+-- +goose StatementEnd
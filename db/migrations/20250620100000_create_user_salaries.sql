@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE "user_salaries" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "user_id" int NOT NULL,
+  "amount" numeric(18,2) NOT NULL CHECK ("amount" > 0),
+  "effective_from" date NOT NULL,
+  "effective_to" date,
+  "note" text,
+  "created_by" int,
+  "updated_by" int,
+  "created_at" timestamp,
+  "updated_at" timestamp,
+  CHECK ("effective_to" IS NULL OR "effective_to" >= "effective_from"),
+  -- A user can only have one salary in effect on any given day.
+  EXCLUDE USING gist ("user_id" WITH =, daterange("effective_from", "effective_to", '[]') WITH &&)
+);
+
+ALTER TABLE "user_salaries" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_salaries;
+-- +goose StatementEnd
@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "payslip_components" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "code" varchar(50) UNIQUE NOT NULL,
+  "name" varchar(100) NOT NULL,
+  "type" varchar(20) NOT NULL CHECK ("type" IN ('earning', 'deduction')),
+  "method" varchar(20) NOT NULL CHECK ("method" IN ('fixed', 'percentage')),
+  "value" numeric(18,4) NOT NULL CHECK ("value" >= 0),
+  "sequence" int NOT NULL DEFAULT 0,
+  "is_active" boolean NOT NULL DEFAULT true,
+  "created_by" int,
+  "updated_by" int,
+  "created_at" timestamp,
+  "updated_at" timestamp
+);
+
+CREATE TABLE "payslips" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "user_id" int NOT NULL,
+  "full_name" varchar(255) NOT NULL,
+  "period" char(7) NOT NULL,
+  "period_start" date NOT NULL,
+  "period_end" date NOT NULL,
+  "base_salary" numeric(18,2) NOT NULL,
+  "gross_earnings" numeric(18,2) NOT NULL,
+  "total_deductions" numeric(18,2) NOT NULL,
+  "net_pay" numeric(18,2) NOT NULL,
+  "created_by" int,
+  "created_at" timestamp,
+  UNIQUE ("user_id", "period")
+);
+
+CREATE TABLE "payslip_items" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "payslip_id" int NOT NULL,
+  "code" varchar(50) NOT NULL,
+  "name" varchar(100) NOT NULL,
+  "type" varchar(20) NOT NULL CHECK ("type" IN ('earning', 'deduction')),
+  "amount" numeric(18,2) NOT NULL,
+  "sequence" int NOT NULL DEFAULT 0
+);
+
+ALTER TABLE "payslips" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "payslip_items" ADD FOREIGN KEY ("payslip_id") REFERENCES "payslips" ("id");
+CREATE INDEX ON "payslip_items" ("payslip_id");
+
+-- Issued payslips are immutable: there is one payslip per user and period, and it cannot be edited,
+-- deleted or replaced. A mistake is corrected through a later period, e.g. with a reimbursement.
+CREATE FUNCTION reject_payslip_change() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'payslips are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER payslips_immutable BEFORE UPDATE OR DELETE ON "payslips"
+  FOR EACH ROW EXECUTE FUNCTION reject_payslip_change();
+CREATE TRIGGER payslip_items_immutable BEFORE UPDATE OR DELETE ON "payslip_items"
+  FOR EACH ROW EXECUTE FUNCTION reject_payslip_change();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS payslip_items;
+DROP TABLE IF EXISTS payslips;
+DROP TABLE IF EXISTS payslip_components;
+DROP FUNCTION IF EXISTS reject_payslip_change();
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "payroll_periods" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "period" char(7) UNIQUE NOT NULL,
+  "period_start" date NOT NULL,
+  "period_end" date NOT NULL,
+  "status" varchar(20) NOT NULL DEFAULT 'open' CHECK ("status" IN ('open', 'processing', 'closed')),
+  "processed_users" int NOT NULL DEFAULT 0,
+  "failed_users" int NOT NULL DEFAULT 0,
+  "created_by" int,
+  "created_at" timestamp,
+  "run_by" int,
+  "run_at" timestamp,
+  "closed_by" int,
+  "closed_at" timestamp
+);
+
+CREATE INDEX ON "payroll_periods" ("status", "period_start", "period_end");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS payroll_periods;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "attendances" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "user_id" int NOT NULL,
+  "work_date" date NOT NULL,
+  "status" varchar(20) NOT NULL DEFAULT 'present' CHECK ("status" IN ('present', 'absent')),
+  "check_in" timestamptz,
+  "check_out" timestamptz,
+  "late_minutes" int NOT NULL DEFAULT 0 CHECK ("late_minutes" >= 0),
+  "note" text,
+  "created_by" int,
+  "updated_by" int,
+  "created_at" timestamp,
+  "updated_at" timestamp,
+  UNIQUE ("user_id", "work_date"),
+  CHECK ("check_out" IS NULL OR "check_out" >= "check_in")
+);
+
+ALTER TABLE "attendances" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS attendances;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "overtime_requests" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "user_id" int NOT NULL,
+  "work_date" date NOT NULL,
+  "hours" numeric(4,2) NOT NULL CHECK ("hours" > 0 AND "hours" <= 24),
+  "reason" text NOT NULL,
+  "status" varchar(20) NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'approved', 'rejected')),
+  "review_note" text,
+  "created_by" int,
+  "updated_by" int,
+  "created_at" timestamp,
+  "updated_at" timestamp
+);
+
+-- A user has at most one live request per day; a rejected request may be submitted again.
+CREATE UNIQUE INDEX "overtime_requests_user_day" ON "overtime_requests" ("user_id", "work_date") WHERE "status" <> 'rejected';
+
+-- Every state transition of a request, including its submission.
+CREATE TABLE "overtime_transitions" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "overtime_id" int NOT NULL,
+  "from_status" varchar(20),
+  "to_status" varchar(20) NOT NULL,
+  "note" text,
+  "created_by" int NOT NULL,
+  "created_at" timestamp NOT NULL
+);
+
+ALTER TABLE "overtime_requests" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "overtime_transitions" ADD FOREIGN KEY ("overtime_id") REFERENCES "overtime_requests" ("id");
+ALTER TABLE "overtime_transitions" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS overtime_transitions;
+DROP TABLE IF EXISTS overtime_requests;
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "reimbursements" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "user_id" int NOT NULL,
+  "category" varchar(50) NOT NULL,
+  "amount" numeric(18,2) NOT NULL CHECK ("amount" > 0),
+  "expense_date" date NOT NULL,
+  "description" text,
+  "receipt_key" varchar(255) NOT NULL,
+  "receipt_name" varchar(255) NOT NULL,
+  "receipt_type" varchar(100) NOT NULL,
+  "receipt_size" bigint NOT NULL,
+  "status" varchar(20) NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'approved', 'rejected', 'cancelled')),
+  "review_note" text,
+  -- The pay period whose payslip carries an approved claim, assigned on approval.
+  "pay_period" char(7),
+  "created_by" int,
+  "updated_by" int,
+  "created_at" timestamp,
+  "updated_at" timestamp
+);
+
+-- Every state transition of a claim, including its submission.
+CREATE TABLE "reimbursement_transitions" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "reimbursement_id" int NOT NULL,
+  "from_status" varchar(20),
+  "to_status" varchar(20) NOT NULL,
+  "note" text,
+  "created_by" int NOT NULL,
+  "created_at" timestamp NOT NULL
+);
+
+ALTER TABLE "reimbursements" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "reimbursement_transitions" ADD FOREIGN KEY ("reimbursement_id") REFERENCES "reimbursements" ("id");
+ALTER TABLE "reimbursement_transitions" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+CREATE INDEX ON "reimbursements" ("user_id", "pay_period") WHERE "status" = 'approved';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS reimbursement_transitions;
+DROP TABLE IF EXISTS reimbursements;
+-- +goose StatementEnd
@@ -0,0 +1,83 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE "leave_types" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "code" varchar(50) UNIQUE NOT NULL,
+  "name" varchar(100) NOT NULL,
+  -- Paid leave is limited by the balance; unpaid leave is unlimited and deducted on the payslip.
+  "is_paid" boolean NOT NULL,
+  "yearly_allowance" numeric(5,2) NOT NULL DEFAULT 0 CHECK ("yearly_allowance" >= 0),
+  "accrual" varchar(20) NOT NULL DEFAULT 'yearly' CHECK ("accrual" IN ('yearly', 'monthly')),
+  "max_carry_over" numeric(5,2) NOT NULL DEFAULT 0 CHECK ("max_carry_over" >= 0),
+  "is_active" boolean NOT NULL DEFAULT true,
+  "created_by" int,
+  "updated_by" int,
+  "created_at" timestamp,
+  "updated_at" timestamp
+);
+
+-- The entitlement of a user to a leave type in a year, fixed when the year's balance is first opened
+-- so later changes to the allowance only apply to years opened afterwards.
+CREATE TABLE "leave_balances" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "user_id" int NOT NULL,
+  "leave_type_id" int NOT NULL,
+  "year" int NOT NULL,
+  "entitled" numeric(5,2) NOT NULL,
+  "created_at" timestamp,
+  UNIQUE ("user_id", "leave_type_id", "year")
+);
+
+CREATE TABLE "leave_requests" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "user_id" int NOT NULL,
+  "leave_type_id" int NOT NULL,
+  "start_date" date NOT NULL,
+  "end_date" date NOT NULL,
+  "days" int NOT NULL CHECK ("days" > 0),
+  "reason" text NOT NULL,
+  "status" varchar(20) NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'approved', 'rejected', 'cancelled')),
+  "review_note" text,
+  "created_by" int,
+  "updated_by" int,
+  "created_at" timestamp,
+  "updated_at" timestamp,
+  CHECK ("end_date" >= "start_date" AND EXTRACT(YEAR FROM "start_date") = EXTRACT(YEAR FROM "end_date")),
+  -- Live requests of a user never overlap.
+  EXCLUDE USING gist ("user_id" WITH =, daterange("start_date", "end_date", '[]') WITH &&)
+    WHERE ("status" IN ('pending', 'approved'))
+);
+
+-- Every state transition of a request, including its submission.
+CREATE TABLE "leave_transitions" (
+  "id" int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "leave_request_id" int NOT NULL,
+  "from_status" varchar(20),
+  "to_status" varchar(20) NOT NULL,
+  "note" text,
+  "created_by" int NOT NULL,
+  "created_at" timestamp NOT NULL
+);
+
+ALTER TABLE "leave_balances" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "leave_balances" ADD FOREIGN KEY ("leave_type_id") REFERENCES "leave_types" ("id");
+ALTER TABLE "leave_requests" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "leave_requests" ADD FOREIGN KEY ("leave_type_id") REFERENCES "leave_types" ("id");
+ALTER TABLE "leave_transitions" ADD FOREIGN KEY ("leave_request_id") REFERENCES "leave_requests" ("id");
+ALTER TABLE "leave_transitions" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+INSERT INTO leave_types (code, name, is_paid, yearly_allowance, accrual, max_carry_over, created_at, updated_at) VALUES
+  ('annual', 'Annual Leave', true, 12, 'monthly', 5, NOW(), NOW()),
+  ('sick', 'Sick Leave', true, 14, 'yearly', 0, NOW(), NOW()),
+  ('unpaid', 'Unpaid Leave', false, 0, 'yearly', 0, NOW(), NOW());
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS leave_transitions;
+DROP TABLE IF EXISTS leave_requests;
+DROP TABLE IF EXISTS leave_balances;
+DROP TABLE IF EXISTS leave_types;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every change made to roles, users and salaries, written in the same transaction as the change itself.
+CREATE TABLE "audit_log" (
+  "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  -- NULL when the change was not made by a signed-in user, e.g. by the create-superadmin command.
+  "actor_id" int,
+  "ip_address" varchar(64) NOT NULL DEFAULT '',
+  "request_id" varchar(64) NOT NULL DEFAULT '',
+  "entity" varchar(50) NOT NULL,
+  "entity_id" varchar(64) NOT NULL,
+  "action" varchar(20) NOT NULL CHECK ("action" IN ('create', 'update', 'delete')),
+  -- The fields that changed: before is NULL on create, after is NULL on delete.
+  "before" jsonb,
+  "after" jsonb,
+  "created_at" timestamp NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX "audit_log_entity_idx" ON "audit_log" ("entity", "entity_id");
+CREATE INDEX "audit_log_actor_id_idx" ON "audit_log" ("actor_id");
+CREATE INDEX "audit_log_request_id_idx" ON "audit_log" ("request_id");
+
+-- The log is append-only: rows can be inserted, never changed or removed.
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_log_append_only"
+  BEFORE UPDATE OR DELETE ON "audit_log"
+  FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+CREATE TRIGGER "audit_log_no_truncate"
+  BEFORE TRUNCATE ON "audit_log"
+  FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only();
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- revoked_before is compared with the iat of tokens, an absolute instant. Without a time zone, lib/pq read
+-- it back as UTC and shifted it by the offset of the server's TimeZone. Existing values were written with
+-- NOW() in that TimeZone, which is how the conversion reads them.
+ALTER TABLE "user_session_revocations"
+  ALTER COLUMN "revoked_before" TYPE timestamptz,
+  ALTER COLUMN "updated_at" TYPE timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "user_session_revocations"
+  ALTER COLUMN "revoked_before" TYPE timestamp,
+  ALTER COLUMN "updated_at" TYPE timestamp;
+-- +goose StatementEnd
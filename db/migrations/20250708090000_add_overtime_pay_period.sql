@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The period whose payslip pays an approved request: the month it was worked, or the next one without a
+-- payslip when the request was approved after that month's payslip was issued.
+ALTER TABLE "overtime_requests" ADD COLUMN "pay_period" char(7);
+UPDATE "overtime_requests" SET "pay_period" = to_char("work_date", 'YYYY-MM') WHERE "status" = 'approved';
+CREATE INDEX ON "overtime_requests" ("user_id", "pay_period") WHERE "status" = 'approved';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "overtime_requests" DROP COLUMN IF EXISTS "pay_period";
+-- +goose StatementEnd
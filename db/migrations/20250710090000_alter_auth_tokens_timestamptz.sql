@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Token expiry and revocation times are compared with time.Now() in Go, so like revoked_before they must be
+-- absolute instants. Existing values are converted as if written in the TimeZone of the migrating session.
+ALTER TABLE "refresh_tokens"
+  ALTER COLUMN "expires_at" TYPE timestamptz,
+  ALTER COLUMN "revoked_at" TYPE timestamptz,
+  ALTER COLUMN "created_at" TYPE timestamptz;
+
+ALTER TABLE "revoked_tokens"
+  ALTER COLUMN "expires_at" TYPE timestamptz,
+  ALTER COLUMN "revoked_at" TYPE timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "refresh_tokens"
+  ALTER COLUMN "expires_at" TYPE timestamp,
+  ALTER COLUMN "revoked_at" TYPE timestamp,
+  ALTER COLUMN "created_at" TYPE timestamp;
+
+ALTER TABLE "revoked_tokens"
+  ALTER COLUMN "expires_at" TYPE timestamp,
+  ALTER COLUMN "revoked_at" TYPE timestamp;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+// Package migrations embeds the goose-annotated SQL migrations so the binary can apply them without the goose CLI.
+package migrations
+
+import "embed"
+
+// FS holds every migration file of this directory.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,143 @@
+package seed
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultPassword is the password of development users unless Options sets another.
+const DefaultPassword = "password123"
+
+var (
+	firstNames = []string{"Adi", "Bima", "Citra", "Dewi", "Eka", "Fajar", "Gita", "Hadi", "Indah", "Joko",
+		"Kartika", "Lestari", "Made", "Nanda", "Oki", "Putri", "Rizky", "Sari", "Tono", "Wulan"}
+	lastNames = []string{"Pratama", "Santoso", "Wijaya", "Saputra", "Hidayat", "Kusuma", "Nugroho", "Lestari",
+		"Siregar", "Halim", "Gunawan", "Setiawan"}
+)
+
+// Options configures the development data. The same Count, Seed and Since always produce the same users and salaries.
+type Options struct {
+	Count    int       // Count is the number of users; the first one is an ADMIN and the others are EMPLOYEEs.
+	Seed     int64     // Seed drives every random choice.
+	Since    time.Time // Since is the earliest date a salary takes effect, 1 January 2024 when zero.
+	Password string    // Password of every user, DefaultPassword when empty.
+}
+
+// User is a generated development user with its salary history, oldest first.
+type User struct {
+	UUID     string
+	Username string
+	FullName string
+	Role     string
+	Salaries []Salary
+}
+
+// Salary is a period of a salary history; To is nil for the salary still in effect.
+type Salary struct {
+	Amount decimal.Decimal
+	From   time.Time
+	To     *time.Time
+}
+
+// Generate returns the development users described by opts without touching a database.
+func Generate(opts Options) []User {
+	since := opts.Since
+	if since.IsZero() {
+		since = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	users := make([]User, 0, opts.Count)
+	for i := 1; i <= opts.Count; i++ {
+		id, _ := uuid.NewRandomFromReader(rng)
+		user := User{
+			UUID:     id.String(),
+			Username: fmt.Sprintf("user%03d", i),
+			FullName: firstNames[rng.Intn(len(firstNames))] + " " + lastNames[rng.Intn(len(lastNames))],
+			Role:     RoleEmployee,
+		}
+		if i == 1 {
+			user.Role = RoleAdmin
+		}
+
+		// One to three salaries, each a raise over the previous one, starting on the first of a month.
+		from := since.AddDate(0, rng.Intn(6), 0)
+		amount := decimal.NewFromInt(int64(4_000_000 + rng.Intn(16)*500_000))
+		for n := 1 + rng.Intn(3); n > 0; n-- {
+			salary := Salary{Amount: amount, From: from}
+			if n > 1 {
+				next := from.AddDate(0, 6+rng.Intn(7), 0)
+				to := next.AddDate(0, 0, -1)
+				salary.To = &to
+				from = next
+				amount = amount.Mul(decimal.NewFromInt(int64(105 + rng.Intn(11)))).Div(decimal.NewFromInt(100)).Round(-3)
+			}
+			user.Salaries = append(user.Salaries, salary)
+		}
+		users = append(users, user)
+	}
+	return users
+}
+
+// Development seeds the default roles and the users of Generate with their salary histories, in one transaction.
+// Users whose username exists and salaries overlapping an existing one are left untouched, so running it again
+// with the same options changes nothing. Returns the generated users.
+func Development(db *sqlx.DB, opts Options) ([]User, error) {
+	roles, err := Roles(db)
+	if err != nil {
+		return nil, err
+	}
+
+	password := opts.Password
+	if password == "" {
+		password = DefaultPassword
+	}
+	// Every user shares the password, so it is hashed once.
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	users := Generate(opts)
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	for _, user := range users {
+		query := `
+			INSERT INTO users (uuid, username, password_hash, full_name, role_id, created_at)
+			VALUES ($1, $2, $3, $4, $5, NOW())
+			ON CONFLICT (username) DO NOTHING
+		`
+		if _, err := tx.Exec(query, user.UUID, user.Username, string(hashed), user.FullName, roles[user.Role]); err != nil {
+			return nil, err
+		}
+
+		var userID int64
+		if err := tx.Get(&userID, `SELECT id FROM users WHERE username = $1`, user.Username); err != nil {
+			return nil, err
+		}
+
+		// The exclusion constraint on salary periods skips salaries already seeded.
+		for _, salary := range user.Salaries {
+			query := `
+				INSERT INTO user_salaries (user_id, amount, effective_from, effective_to, note, created_at)
+				VALUES ($1, $2, $3, $4, 'seed', NOW())
+				ON CONFLICT DO NOTHING
+			`
+			if _, err := tx.Exec(query, userID, salary.Amount, salary.From, salary.To); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return users, tx.Commit()
+}
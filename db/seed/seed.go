@@ -0,0 +1,51 @@
+// Package seed fills a database with the data the application needs to start and with development data.
+// Every function is idempotent, so seeding an already seeded database changes nothing.
+package seed
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+// Role names created by Roles.
+const (
+	RoleSuperadmin = "SUPERADMIN"
+	RoleAdmin      = "ADMIN"
+	RoleEmployee   = "EMPLOYEE"
+)
+
+// Role is a role with the privilege it is created with.
+type Role struct {
+	Name      string
+	Privilege string
+}
+
+// DefaultRoles are the roles every installation starts with. Employees only use the self-service routes,
+// which need no permission.
+var DefaultRoles = []Role{
+	{Name: RoleSuperadmin, Privilege: "*"},
+	{Name: RoleAdmin, Privilege: "users:read, users:create, users:update, salaries:*, payslips:*, payroll:*, " +
+		"attendance:*, overtime:*, reimbursements:*, leave:*"},
+	{Name: RoleEmployee, Privilege: ""},
+}
+
+// Roles creates the default roles that do not exist yet; existing roles keep their privileges.
+// Returns the ID of every default role by name.
+func Roles(db *sqlx.DB) (map[string]int64, error) {
+	ids := map[string]int64{}
+	for _, role := range DefaultRoles {
+		query := `
+			INSERT INTO roles (name, privilege, created_at, updated_at) VALUES ($1, NULLIF($2, ''), NOW(), NOW())
+			ON CONFLICT (name) DO NOTHING
+		`
+		if _, err := db.Exec(query, role.Name, role.Privilege); err != nil {
+			return nil, err
+		}
+
+		var id int64
+		if err := db.Get(&id, `SELECT id FROM roles WHERE name = $1`, role.Name); err != nil {
+			return nil, err
+		}
+		ids[role.Name] = id
+	}
+	return ids, nil
+}
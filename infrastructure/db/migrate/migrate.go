@@ -0,0 +1,296 @@
+// Package migrate applies goose-annotated SQL migrations from an fs.FS, usually the files embedded by db/migrations.
+// Applied versions are recorded in goose's own goose_db_version table, so databases migrated with the goose CLI
+// keep working, and every command holds a Postgres advisory lock so concurrent replicas never migrate at once.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// versionTable is the table goose records applied versions in.
+const versionTable = "goose_db_version"
+
+// lockKey identifies the advisory lock taken while migrating.
+const lockKey int64 = 7_306_853_829_416_274_510
+
+// VersionLayout formats the version of migrations created by Create.
+const VersionLayout = "20060102150405"
+
+var (
+	ErrNoApplied      = errors.New("no migration has been applied")
+	ErrUnknownApplied = errors.New("the database has a migration version that is not in the migration files")
+)
+
+var nonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Status is a migration with the time it was applied, or nil while it is pending.
+type Status struct {
+	Migration *Migration
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []*Migration
+}
+
+// New loads the migrations of fsys for the database db.
+func New(db *sqlx.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up applies every pending migration in version order, including ones older than the latest applied.
+// Returns the number of migrations applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+	err := m.locked(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.readApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration, true); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down rolls back the latest applied migration.
+// Returns the migration rolled back, or ErrNoApplied if there is none.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var migration *Migration
+	err := m.locked(ctx, func(conn *sqlx.Conn) error {
+		var err error
+		migration, err = m.down(ctx, conn)
+		return err
+	})
+	return migration, err
+}
+
+// Redo rolls back the latest applied migration and applies it again.
+// Returns the migration redone, or ErrNoApplied if there is none.
+func (m *Migrator) Redo(ctx context.Context) (*Migration, error) {
+	var migration *Migration
+	err := m.locked(ctx, func(conn *sqlx.Conn) error {
+		var err error
+		migration, err = m.down(ctx, conn)
+		if err != nil {
+			return err
+		}
+		return m.apply(ctx, conn, migration, true)
+	})
+	return migration, err
+}
+
+// Status lists every migration with the time it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.readApplied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if at, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending returns the number of migrations not applied yet.
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// Create writes an empty goose-annotated migration named after the current time into dir.
+// The binary embeds migrations when it is built, so it must be rebuilt to apply the new file.
+// Returns the path of the new file.
+func Create(dir string, name string, now time.Time) (string, error) {
+	name = strings.Trim(nonWord.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", fmt.Errorf("migration name must contain letters or digits")
+	}
+
+	file := filepath.Join(dir, fmt.Sprintf("%s_%s.sql", now.UTC().Format(VersionLayout), name))
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	template := "-- +goose Up\n-- +goose StatementBegin\n\n-- +goose StatementEnd\n\n-- +goose Down\n-- +goose StatementBegin\n\n-- +goose StatementEnd\n"
+	if _, err := f.WriteString(template); err != nil {
+		return "", err
+	}
+	return file, nil
+}
+
+// locked runs fn on a dedicated connection holding the migration advisory lock, waiting for other holders to finish.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+
+	query := `
+		CREATE TABLE IF NOT EXISTS ` + versionTable + ` (
+			id serial PRIMARY KEY,
+			version_id bigint NOT NULL,
+			is_applied boolean NOT NULL,
+			tstamp timestamp NULL DEFAULT NOW()
+		)
+	`
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return err
+	}
+	// goose expects its table to start with version 0.
+	query = `INSERT INTO ` + versionTable + ` (version_id, is_applied) SELECT 0, true WHERE NOT EXISTS (SELECT 1 FROM ` + versionTable + `)`
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func (m *Migrator) down(ctx context.Context, conn *sqlx.Conn) (*Migration, error) {
+	applied, err := m.readApplied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest int64
+	for version := range applied {
+		if version > latest {
+			latest = version
+		}
+	}
+	if latest == 0 {
+		return nil, ErrNoApplied
+	}
+	for _, migration := range m.migrations {
+		if migration.Version == latest {
+			return migration, m.apply(ctx, conn, migration, false)
+		}
+	}
+	return nil, fmt.Errorf("%w: %d", ErrUnknownApplied, latest)
+}
+
+// apply runs the Up or Down statements of a migration and records the result, in one transaction unless NoTx is set.
+func (m *Migrator) apply(ctx context.Context, conn *sqlx.Conn, migration *Migration, up bool) error {
+	statements, record, direction := migration.Up, `INSERT INTO `+versionTable+` (version_id, is_applied) VALUES ($1, true)`, "up"
+	if !up {
+		statements, record, direction = migration.Down, `DELETE FROM `+versionTable+` WHERE version_id = $1`, "down"
+	}
+
+	run := func(exec func(ctx context.Context, query string, args ...any) error) error {
+		for _, statement := range statements {
+			if err := exec(ctx, statement); err != nil {
+				return fmt.Errorf("migration %d_%s %s: %w", migration.Version, migration.Name, direction, err)
+			}
+		}
+		return exec(ctx, record, migration.Version)
+	}
+
+	if migration.NoTx {
+		err := run(func(ctx context.Context, query string, args ...any) error {
+			_, err := conn.ExecContext(ctx, query, args...)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		tx, err := conn.BeginTxx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		err = run(func(ctx context.Context, query string, args ...any) error {
+			_, err := tx.ExecContext(ctx, query, args...)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("migrate %s: %d_%s", direction, migration.Version, migration.Name)
+	return nil
+}
+
+// readApplied returns the applied versions with the time they were applied. goose records rollbacks either by
+// deleting the version or, in older releases, by appending a row that is not applied, so the last row of each version wins.
+func (m *Migrator) readApplied(ctx context.Context, q sqlx.QueryerContext) (map[int64]time.Time, error) {
+	applied := map[int64]time.Time{}
+
+	var exists bool
+	if err := sqlx.GetContext(ctx, q, &exists, `SELECT to_regclass($1) IS NOT NULL`, versionTable); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return applied, nil
+	}
+
+	rows := []struct {
+		VersionID int64     `db:"version_id"`
+		IsApplied bool      `db:"is_applied"`
+		Tstamp    time.Time `db:"tstamp"`
+	}{}
+	query := `SELECT version_id, is_applied, COALESCE(tstamp, NOW()) AS tstamp FROM ` + versionTable + ` WHERE version_id > 0 ORDER BY id`
+	if err := sqlx.SelectContext(ctx, q, &rows, query); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if row.IsApplied {
+			applied[row.VersionID] = row.Tstamp
+		} else {
+			delete(applied, row.VersionID)
+		}
+	}
+	return applied, nil
+}
@@ -0,0 +1,148 @@
+package migrate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dwilanang/psp/db/migrations"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const createUsers = `-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE users (id serial PRIMARY KEY);
+CREATE INDEX users_id_idx ON users (id);
+-- +goose StatementEnd
+INSERT INTO users DEFAULT VALUES;
+
+-- +goose Down
+DROP TABLE users;
+`
+
+func setupMockDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return sqlx.NewDb(db, "postgres"), mock, func() { db.Close() }
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"20250102000000_create_users.sql": {Data: []byte(createUsers)},
+		"20250101000000_concurrently.sql": {Data: []byte("-- +goose NO TRANSACTION\n-- +goose Up\nSELECT 1;\n")},
+		"README.md":                       {Data: []byte("not a migration")},
+	}
+
+	loaded, err := Load(fsys)
+
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	assert.Equal(t, int64(20250101000000), loaded[0].Version)
+	assert.True(t, loaded[0].NoTx)
+	assert.Equal(t, "create_users", loaded[1].Name)
+	// The statement block stays whole; the line after it is a statement of its own.
+	assert.Equal(t, []string{
+		"CREATE TABLE users (id serial PRIMARY KEY);\nCREATE INDEX users_id_idx ON users (id);",
+		"INSERT INTO users DEFAULT VALUES;",
+	}, loaded[1].Up)
+	assert.Equal(t, []string{"DROP TABLE users;"}, loaded[1].Down)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	_, err := Load(fstest.MapFS{"create_users.sql": {Data: []byte(createUsers)}})
+	assert.ErrorContains(t, err, "<version>_<name>.sql")
+
+	_, err = Load(fstest.MapFS{"1_a.sql": {Data: []byte("-- +goose Down\n")}})
+	assert.ErrorContains(t, err, "missing -- +goose Up")
+
+	_, err = Load(fstest.MapFS{"1_a.sql": {Data: []byte("-- +goose Up\n-- +goose StatementBegin\nSELECT 1;\n")}})
+	assert.ErrorContains(t, err, "StatementEnd")
+}
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	loaded, err := Load(migrations.FS)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, loaded)
+	for _, migration := range loaded {
+		assert.NotEmpty(t, migration.Up, migration.Name)
+		assert.NotEmpty(t, migration.Down, migration.Name)
+	}
+}
+
+func TestUp_AppliesPendingUnderLock(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	migrator, err := New(db, fstest.MapFS{
+		"1_first.sql":  {Data: []byte("-- +goose Up\nSELECT 1;\n-- +goose Down\nSELECT 2;\n")},
+		"2_second.sql": {Data: []byte("-- +goose Up\nSELECT 3;\n-- +goose Down\nSELECT 4;\n")},
+	})
+	require.NoError(t, err)
+
+	mock.ExpectExec("SELECT pg_advisory_lock").WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS goose_db_version").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO goose_db_version").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT to_regclass").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery("SELECT version_id, is_applied").
+		WillReturnRows(sqlmock.NewRows([]string{"version_id", "is_applied", "tstamp"}).AddRow(1, true, time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT 3;").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO goose_db_version").WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatus_FollowsLatestRow(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	migrator, err := New(db, fstest.MapFS{
+		"1_first.sql":  {Data: []byte("-- +goose Up\nSELECT 1;\n")},
+		"2_second.sql": {Data: []byte("-- +goose Up\nSELECT 2;\n")},
+	})
+	require.NoError(t, err)
+
+	// Older goose releases record a rollback as a row that is not applied.
+	now := time.Now()
+	mock.ExpectQuery("SELECT to_regclass").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery("SELECT version_id, is_applied").
+		WillReturnRows(sqlmock.NewRows([]string{"version_id", "is_applied", "tstamp"}).
+			AddRow(1, true, now).AddRow(2, true, now).AddRow(2, false, now))
+
+	pending, err := migrator.Pending(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pending)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+
+	file, err := Create(dir, "Add Leave-Notes", time.Date(2025, 7, 4, 9, 30, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "20250704093000_add_leave_notes.sql"), file)
+	source, err := os.ReadFile(file)
+	require.NoError(t, err)
+	_, err = parse(source)
+	assert.NoError(t, err)
+
+	_, err = Create(dir, "Add Leave-Notes", time.Date(2025, 7, 4, 9, 30, 0, 0, time.UTC))
+	assert.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Migration is a versioned SQL migration parsed from a goose-annotated file named "<version>_<name>.sql".
+type Migration struct {
+	Version int64
+	Name    string
+	Up      []string
+	Down    []string
+	// NoTx is set by "-- +goose NO TRANSACTION" for statements that cannot run in a transaction,
+	// such as CREATE INDEX CONCURRENTLY.
+	NoTx bool
+}
+
+// Load parses every migration of fsys in version order.
+// Returns an error if a file name has no numeric version, a version is used twice, or a file has no Up section.
+func Load(fsys fs.FS) ([]*Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]*Migration, 0, len(files))
+	versions := map[int64]string{}
+	for _, file := range files {
+		prefix, name, found := strings.Cut(strings.TrimSuffix(path.Base(file), ".sql"), "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if !found || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: file name must be <version>_<name>.sql", file)
+		}
+		if previous, ok := versions[version]; ok {
+			return nil, fmt.Errorf("migration %s: version %d is also used by %s", file, version, previous)
+		}
+		versions[version] = file
+
+		source, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		migration, err := parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", file, err)
+		}
+		migration.Version = version
+		migration.Name = name
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// parse splits a goose-annotated file into its Up and Down statements. Outside a
+// StatementBegin/StatementEnd block every line ending with a semicolon ends a statement;
+// inside one the whole block is a single statement.
+func parse(source []byte) (*Migration, error) {
+	migration := &Migration{}
+
+	var (
+		section *[]string
+		block   bool
+		buf     strings.Builder
+		hasUp   bool
+	)
+	flush := func() {
+		if statement := strings.TrimSpace(buf.String()); statement != "" && section != nil {
+			*section = append(*section, statement)
+		}
+		buf.Reset()
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(source))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if annotation, ok := strings.CutPrefix(trimmed, "-- +goose "); ok {
+			switch strings.ToUpper(strings.TrimSpace(annotation)) {
+			case "UP":
+				flush()
+				section, hasUp = &migration.Up, true
+			case "DOWN":
+				flush()
+				section = &migration.Down
+			case "STATEMENTBEGIN":
+				flush()
+				block = true
+			case "STATEMENTEND":
+				flush()
+				block = false
+			case "NO TRANSACTION":
+				migration.NoTx = true
+			default:
+				return nil, fmt.Errorf("unknown annotation %q", trimmed)
+			}
+			continue
+		}
+		if section == nil {
+			continue
+		}
+		if !block && (trimmed == "" || strings.HasPrefix(trimmed, "--")) && buf.Len() == 0 {
+			continue
+		}
+
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+		if !block && strings.HasSuffix(trimmed, ";") {
+			flush()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if block {
+		return nil, fmt.Errorf("missing -- +goose StatementEnd")
+	}
+	flush()
+
+	if !hasUp {
+		return nil, fmt.Errorf("missing -- +goose Up")
+	}
+	return migration, nil
+}
@@ -2,29 +2,37 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/dwilanang/psp/config"
+	"github.com/dwilanang/psp/db/migrations"
+	"github.com/dwilanang/psp/infrastructure/db/migrate"
+	"github.com/dwilanang/psp/utils"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq" // Postgres driver
 )
 
 var (
 	masterInstances = map[string]*sqlx.DB{}
-	slaveInstances  = map[string]*sqlx.DB{}
+	slaveInstances  = map[string][]*sqlx.DB{}
+	handles         = map[string]*DB{}
 	mu              sync.RWMutex
 )
 
 type Config struct {
-	Driver       string
-	MasterDSN    string
-	SlaveDSN     string
-	MaxOpenConns int
-	MaxIdleConns int
-	Timeout      int
+	Driver        string
+	MasterDSN     string
+	SlaveDSNs     []string
+	MaxOpenConns  int
+	MaxIdleConns  int
+	Timeout       int
+	CheckInterval int // seconds between replica health checks
 }
 
 func initDB(name string, cfg Config) error {
@@ -51,50 +59,78 @@ func initDB(name string, cfg Config) error {
 	}
 	masterInstances[name] = masterDB
 
-	// Init slave (optional)
-	if cfg.SlaveDSN != "" {
-		slaveDB, err := sqlx.Connect(cfg.Driver, cfg.SlaveDSN)
+	// Init slaves (optional). They connect lazily so an unreachable replica does not stop the startup;
+	// the handle sends its reads to the master until the replica passes a health check.
+	var slaves []*sqlx.DB
+	for _, dsn := range cfg.SlaveDSNs {
+		slaveDB, err := sqlx.Open(cfg.Driver, dsn)
 		if err != nil {
 			return err
 		}
 		slaveDB.SetConnMaxLifetime(time.Hour)
 		slaveDB.SetMaxOpenConns(cfg.MaxOpenConns)
 		slaveDB.SetMaxIdleConns(cfg.MaxIdleConns)
-		if cfg.Timeout > 0 {
-			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Timeout)*time.Second)
-			defer cancel()
-			if err := slaveDB.PingContext(ctx); err != nil {
-				return err
-			}
-		}
-		slaveInstances[name] = slaveDB
+		slaves = append(slaves, slaveDB)
 	}
+	slaveInstances[name] = slaves
+
+	handle := NewDB(masterDB, slaves...)
+	handle.CheckReplicas(context.Background())
+	handle.watch(time.Duration(cfg.CheckInterval) * time.Second)
+	handles[name] = handle
 
 	return nil
 }
 
-func getMasterDB(name string) *sqlx.DB {
+func getDB(name string) *DB {
 	mu.RLock()
 	defer mu.RUnlock()
-	db, ok := masterInstances[name]
+	db, ok := handles[name]
 	if !ok {
-		log.Fatalf("Master DB instance %s not found, call InitDB first", name)
+		log.Fatalf("DB instance %s not found, call InitDB first", name)
 	}
 	return db
 }
 
-func getSlaveDB(name string) *sqlx.DB {
+// Ping pings every master and slave instance concurrently, each within timeout.
+// Returns the result of every instance keyed "<name>/master" or "<name>/slave/<n>"; a nil error means it is healthy.
+func Ping(ctx context.Context, timeout time.Duration) map[string]error {
 	mu.RLock()
-	defer mu.RUnlock()
-	db, ok := slaveInstances[name]
-	if !ok {
-		log.Fatalf("Slave DB instance %s not found, call InitDB first with SlaveDSN", name)
+	instances := map[string]*sqlx.DB{}
+	for name, db := range masterInstances {
+		instances[name+"/master"] = db
 	}
-	return db
+	for name, slaves := range slaveInstances {
+		for i, db := range slaves {
+			instances[fmt.Sprintf("%s/slave/%d", name, i+1)] = db
+		}
+	}
+	mu.RUnlock()
+
+	var (
+		wg      sync.WaitGroup
+		resultM sync.Mutex
+		results = make(map[string]error, len(instances))
+	)
+	for key, db := range instances {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pingCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			err := db.PingContext(pingCtx)
+
+			resultM.Lock()
+			results[key] = err
+			resultM.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results
 }
 
 func healthCheckDB(name string) error {
-	db := getMasterDB(name)
+	db := getDB(name)
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 	return db.PingContext(ctx)
@@ -102,12 +138,13 @@ func healthCheckDB(name string) error {
 
 func setupDB(cfg *config.Config) {
 	dbConfig := Config{
-		Driver:       cfg.DBDriver,
-		MasterDSN:    fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName),
-		SlaveDSN:     "",
-		MaxOpenConns: 10,
-		MaxIdleConns: 5,
-		Timeout:      30,
+		Driver:        cfg.DBDriver,
+		MasterDSN:     fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName),
+		SlaveDSNs:     splitDSNs(cfg.DBReplicaDSNs),
+		MaxOpenConns:  10,
+		MaxIdleConns:  5,
+		Timeout:       30,
+		CheckInterval: int(utils.ConvertStringToInt(cfg.DBReplicaCheckInterval)),
 	}
 	err := initDB("main", dbConfig)
 	if err != nil {
@@ -115,19 +152,64 @@ func setupDB(cfg *config.Config) {
 	}
 }
 
-func Connect(cfg *config.Config) *sqlx.DB {
+// Connect connects the master and the replicas of DB_REPLICA_DSNS, and returns the handle routing between them.
+func Connect(cfg *config.Config) *DB {
 	// Initialize the database connection
 	setupDB(cfg)
 
 	// Check the health of the Postgres database
 	checkPostgresHealth()
 
-	// Get the master database connection
-	dbConn := getMasterDB("main")
+	// Get the database handle
+	dbConn := getDB("main")
+
+	// Apply pending migrations when DB_AUTO_MIGRATE is enabled
+	if autoMigrate, _ := strconv.ParseBool(cfg.DBAutoMigrate); autoMigrate {
+		migrateDB(dbConn.DB)
+	}
 
 	return dbConn
 }
 
+// migrateDB applies the embedded migrations. The migrator's advisory lock makes replicas
+// starting together wait for the first one instead of applying the same migrations twice.
+func migrateDB(db *sqlx.DB) {
+	migrator, err := migrate.New(db, migrations.FS)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to apply migrations: %v", err)
+	}
+	log.Printf("Applied %d pending migrations", applied)
+}
+
+// Close stops the replica health checks and closes every master and slave instance.
+// Connect must be called again before using the database.
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for name, handle := range handles {
+		handle.unwatch()
+		delete(handles, name)
+	}
+
+	var errs []error
+	for name, db := range masterInstances {
+		errs = append(errs, db.Close())
+		delete(masterInstances, name)
+	}
+	for name, slaves := range slaveInstances {
+		for _, db := range slaves {
+			errs = append(errs, db.Close())
+		}
+		delete(slaveInstances, name)
+	}
+	return errors.Join(errs...)
+}
+
 func checkPostgresHealth() {
 	err := healthCheckDB("main")
 	if err != nil {
@@ -136,3 +218,14 @@ func checkPostgresHealth() {
 		log.Println("Postgres is healthy")
 	}
 }
+
+// splitDSNs splits a comma-separated list of DSNs, ignoring blank entries.
+func splitDSNs(value string) []string {
+	var dsns []string
+	for _, dsn := range strings.Split(value, ",") {
+		if dsn = strings.TrimSpace(dsn); dsn != "" {
+			dsns = append(dsns, dsn)
+		}
+	}
+	return dsns
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// replicaPingTimeout bounds each replica health check.
+const replicaPingTimeout = 2 * time.Second
+
+// DB is the handle repositories receive. Queries on the embedded master behave as a plain *sqlx.DB,
+// so writes, transactions and reads that must see the latest data need nothing special.
+// Reads that tolerate replication lag, such as listings, go through Replica instead.
+type DB struct {
+	*sqlx.DB
+
+	replicas []*replica
+	next     atomic.Uint64
+
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+}
+
+type replica struct {
+	name    string
+	db      *sqlx.DB
+	healthy atomic.Bool
+}
+
+// NewDB creates a handle writing to master and reading from replicas.
+// Every replica is considered healthy until CheckReplicas or a watch reports otherwise.
+func NewDB(master *sqlx.DB, replicas ...*sqlx.DB) *DB {
+	db := &DB{DB: master}
+	for i, r := range replicas {
+		rep := &replica{name: fmt.Sprintf("replica %d", i+1), db: r}
+		rep.healthy.Store(true)
+		db.replicas = append(db.replicas, rep)
+	}
+	return db
+}
+
+// Replica returns a healthy replica, rotating between them, or the master when none is healthy.
+func (db *DB) Replica() *sqlx.DB {
+	n := uint64(len(db.replicas))
+	start := db.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if r := db.replicas[(start+i)%n]; r.healthy.Load() {
+			return r.db
+		}
+	}
+	return db.DB
+}
+
+// CheckReplicas pings every replica and records whether it may serve reads.
+func (db *DB) CheckReplicas(ctx context.Context) {
+	for _, r := range db.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+		err := r.db.PingContext(pingCtx)
+		cancel()
+
+		healthy := err == nil
+		if was := r.healthy.Swap(healthy); was != healthy {
+			if healthy {
+				log.Printf("Postgres %s is healthy again, serving reads", r.name)
+			} else {
+				log.Printf("Postgres %s is unhealthy, reading from the master instead: %v", r.name, err)
+			}
+		}
+	}
+}
+
+// watch checks the replicas every interval until Close is called.
+func (db *DB) watch(interval time.Duration) {
+	if len(db.replicas) == 0 || interval <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	db.stop = cancel
+	db.wg.Add(1)
+	go func() {
+		defer db.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				db.CheckReplicas(ctx)
+			}
+		}
+	}()
+}
+
+// unwatch stops the replica health checks started by watch.
+func (db *DB) unwatch() {
+	if db.stop != nil {
+		db.stop()
+		db.wg.Wait()
+	}
+}
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return sqlx.NewDb(db, "postgres"), mock
+}
+
+func TestReplica_RotatesBetweenReplicas(t *testing.T) {
+	master, _ := setupMockDB(t)
+	first, _ := setupMockDB(t)
+	second, _ := setupMockDB(t)
+
+	db := NewDB(master, first, second)
+
+	picked := map[*sqlx.DB]int{}
+	for i := 0; i < 4; i++ {
+		picked[db.Replica()]++
+	}
+	assert.Equal(t, map[*sqlx.DB]int{first: 2, second: 2}, picked)
+}
+
+func TestReplica_WithoutReplicasReadsFromMaster(t *testing.T) {
+	master, _ := setupMockDB(t)
+
+	assert.Same(t, master, NewDB(master).Replica())
+}
+
+func TestCheckReplicas_FallsBackToMaster(t *testing.T) {
+	master, _ := setupMockDB(t)
+	replica, mock := setupMockDB(t)
+	db := NewDB(master, replica)
+
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	db.CheckReplicas(context.Background())
+	assert.Same(t, master, db.Replica())
+
+	mock.ExpectPing()
+	db.CheckReplicas(context.Background())
+	assert.Same(t, replica, db.Replica())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSplitDSNs(t *testing.T) {
+	assert.Nil(t, splitDSNs(""))
+	assert.Equal(t, []string{"host=replica1 port=5432", "host=replica2"}, splitDSNs(" host=replica1 port=5432 ,, host=replica2"))
+}
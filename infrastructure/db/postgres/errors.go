@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/lib/pq"
+)
+
+// PostgreSQL error codes translated by WrapError.
+const (
+	codeForeignKeyViolation = "23503"
+	codeUniqueViolation     = "23505"
+	codeCheckViolation      = "23514"
+	codeExclusionViolation  = "23P01"
+)
+
+// WrapError translates the errors of a query on entity, e.g. "role", into typed errors:
+// no rows becomes a NotFound, unique and exclusion violations and rows still referenced a Conflict,
+// and check violations a Validation error. Other errors are returned unchanged.
+// The original error stays in the chain, so errors.Is(err, sql.ErrNoRows) keeps working.
+func WrapError(err error, entity string) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperror.Wrap(apperror.KindNotFound, entity+" not found", err)
+	}
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch pqErr.Code {
+	case codeUniqueViolation:
+		return apperror.Wrap(apperror.KindConflict, entity+" already exists", err)
+	case codeExclusionViolation:
+		return apperror.Wrap(apperror.KindConflict, entity+" overlaps an existing one", err)
+	case codeForeignKeyViolation:
+		// PostgreSQL reports the side of the constraint that failed: deleting a referenced row, or referencing a missing one.
+		if strings.HasPrefix(pqErr.Message, "update or delete") {
+			return apperror.Wrap(apperror.KindConflict, entity+" is still in use", err)
+		}
+		return apperror.Wrap(apperror.KindConflict, entity+" references a missing record", err)
+	case codeCheckViolation:
+		return apperror.Wrap(apperror.KindValidation, entity+" has an invalid value", err)
+	default:
+		return err
+	}
+}
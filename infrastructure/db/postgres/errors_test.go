@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapError(t *testing.T) {
+	assert.NoError(t, WrapError(nil, "role"))
+
+	err := WrapError(sql.ErrNoRows, "role")
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Equal(t, "role not found", apperror.Message(err, ""))
+
+	err = WrapError(&pq.Error{Code: "23505", Constraint: "roles_name_key"}, "role")
+	assert.Equal(t, apperror.KindConflict, apperror.KindOf(err))
+	assert.Equal(t, "role already exists", apperror.Message(err, ""))
+
+	err = WrapError(&pq.Error{Code: "23503", Message: `update or delete on table "roles" violates foreign key constraint "users_role_id_fkey" on table "users"`}, "role")
+	assert.Equal(t, apperror.KindConflict, apperror.KindOf(err))
+	assert.Equal(t, "role is still in use", apperror.Message(err, ""))
+
+	err = WrapError(&pq.Error{Code: "23514"}, "salary")
+	assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+
+	cause := errors.New("connection reset")
+	assert.Same(t, cause, WrapError(cause, "role"))
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Executor runs queries. It is satisfied by both *sqlx.DB and *sqlx.Tx, so a repository runs the same
+// statements whether or not it is bound to a transaction.
+type Executor interface {
+	sqlx.Ext
+	sqlx.ExtContext
+	Get(dest any, query string, args ...any) error
+	Select(dest any, query string, args ...any) error
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+}
+
+// Transactor runs a unit of work spanning several repositories in one transaction.
+// Services bind their repositories to the tx passed to fn with the repositories' WithTx.
+type Transactor interface {
+	Within(fn func(tx Executor) error) error
+}
+
+// Within runs fn in a transaction on the master. See the package-level Within.
+func (db *DB) Within(fn func(tx Executor) error) error {
+	return Within(db.DB, fn)
+}
+
+// Reader returns where reads that tolerate replication lag should go for a repository executing on q:
+// q itself when it is a transaction, so the reads see its uncommitted writes, and a replica otherwise.
+func (db *DB) Reader(q Executor) Executor {
+	if _, ok := q.(*sqlx.Tx); ok {
+		return q
+	}
+	return db.Replica()
+}
+
+// Within runs fn in a transaction begun on q. It commits when fn returns nil, and rolls back when fn
+// returns an error or panics; the panic is then propagated.
+// When q is already a transaction fn joins it, so nested units of work neither commit nor roll back
+// on their own and the outermost call decides for all of them.
+func Within(q Executor, fn func(tx Executor) error) (err error) {
+	switch q := q.(type) {
+	case *sqlx.Tx:
+		return fn(q)
+	case *sqlx.DB:
+		tx, err := q.Beginx()
+		if err != nil {
+			return fmt.Errorf("begin transaction: %w", err)
+		}
+		defer func() {
+			if p := recover(); p != nil {
+				_ = tx.Rollback()
+				panic(p)
+			}
+		}()
+
+		if err := fn(tx); err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				return errors.Join(err, fmt.Errorf("rollback: %w", rollbackErr))
+			}
+			return err
+		}
+		return tx.Commit()
+	default:
+		return fmt.Errorf("cannot begin a transaction on %T", q)
+	}
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithin_Commits(t *testing.T) {
+	master, mock := setupMockDB(t)
+	db := NewDB(master)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO users").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO user_salaries").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := db.Within(func(tx Executor) error {
+		if _, err := tx.Exec("INSERT INTO users"); err != nil {
+			return err
+		}
+		// A nested unit of work joins the transaction instead of committing on its own.
+		return Within(tx, func(nested Executor) error {
+			assert.Same(t, tx, nested)
+			_, err := nested.Exec("INSERT INTO user_salaries")
+			return err
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithin_RollsBackOnError(t *testing.T) {
+	master, mock := setupMockDB(t)
+	db := NewDB(master)
+	failure := errors.New("salary overlaps")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO users").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectRollback()
+
+	err := db.Within(func(tx Executor) error {
+		if _, err := tx.Exec("INSERT INTO users"); err != nil {
+			return err
+		}
+		return Within(tx, func(Executor) error { return failure })
+	})
+
+	assert.ErrorIs(t, err, failure)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithin_RollsBackOnPanic(t *testing.T) {
+	master, mock := setupMockDB(t)
+	db := NewDB(master)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = db.Within(func(Executor) error { panic("boom") })
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReader(t *testing.T) {
+	master, mock := setupMockDB(t)
+	replica, _ := setupMockDB(t)
+	db := NewDB(master, replica)
+
+	assert.Same(t, replica, db.Reader(master))
+
+	mock.ExpectBegin()
+	tx, err := master.Beginx()
+	assert.NoError(t, err)
+	assert.Same(t, tx, db.Reader(tx))
+}
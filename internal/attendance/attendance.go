@@ -0,0 +1,11 @@
+package attendance
+
+import (
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/attendance/service"
+)
+
+type Dependencies struct {
+	DBPostgres *postgres.DB
+	Service    service.Service
+}
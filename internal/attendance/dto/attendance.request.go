@@ -0,0 +1,15 @@
+package dto
+
+type AttendanceCorrectionRequest struct {
+	UserID   int64  `json:"user_id" binding:"required"`
+	WorkDate string `json:"work_date" binding:"required" example:"2025-01-15"`
+	Status   string `json:"status" binding:"required,oneof=present absent" example:"present"`
+	CheckIn  string `json:"check_in" example:"08:55"`
+	CheckOut string `json:"check_out" example:"17:30"`
+	Note     string `json:"note"`
+	By       int64  `json:"by" swaggerignore:"true"`
+}
+
+type AttendancePeriodRequest struct {
+	Period string `form:"period" binding:"required" example:"2025-01"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+type AttendanceResponse struct {
+	Data AttendanceData `json:"data"`
+}
+
+type AttendanceListResponse struct {
+	Data []AttendanceData `json:"data"`
+}
+
+type AttendanceData struct {
+	ID          int64   `json:"id"`
+	UserID      int64   `json:"user_id"`
+	FullName    string  `json:"full_name"`
+	WorkDate    string  `json:"work_date" example:"2025-01-15"`
+	Status      string  `json:"status" example:"present"`
+	CheckIn     *string `json:"check_in" example:"2025-01-15T08:55:00+07:00"`
+	CheckOut    *string `json:"check_out" example:"2025-01-15T17:30:00+07:00"`
+	LateMinutes int     `json:"late_minutes"`
+	Note        string  `json:"note"`
+}
+
+type SummaryResponse struct {
+	Data SummaryData `json:"data"`
+}
+
+type SummaryData struct {
+	UserID      int64  `json:"user_id"`
+	Period      string `json:"period" example:"2025-01"`
+	WorkingDays int    `json:"working_days"`
+	PresentDays int    `json:"present_days"`
+	AbsentDays  int    `json:"absent_days"`
+	LeaveDays   int    `json:"leave_days"`
+	LateDays    int    `json:"late_days"`
+	LateMinutes int    `json:"late_minutes"`
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"github.com/dwilanang/psp/internal/attendance"
+	"github.com/dwilanang/psp/internal/attendance/dto"
+	"github.com/dwilanang/psp/internal/auth/util"
+	"github.com/dwilanang/psp/utils"
+	utilrequest "github.com/dwilanang/psp/utils/request"
+	"github.com/dwilanang/psp/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	Deps attendance.Dependencies
+}
+
+func NewHandler(deps attendance.Dependencies) *Handler {
+	return &Handler{
+		Deps: deps,
+	}
+}
+
+// CheckIn godoc
+// @Security BearerAuth
+// @Summary      Check in
+// @Description  Record the arrival of the authenticated user today
+// @Tags         attendance
+// @Accept       json
+// @Produce      json
+// @Success      201  {object}  response.ApiResponse{data=dto.AttendanceData}
+// @Failure      409  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /attendance/check-in [post]
+func (h *Handler) CheckIn(c *gin.Context) {
+	id, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+
+	resp, err := h.Deps.Service.CheckIn(id)
+	if err != nil {
+		response.Error(c, err, "could not check in")
+		return
+	}
+	response.Created(c, resp.Data)
+}
+
+// CheckOut godoc
+// @Security BearerAuth
+// @Summary      Check out
+// @Description  Record the departure of the authenticated user today
+// @Tags         attendance
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.ApiResponse{data=dto.AttendanceData}
+// @Failure      409  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /attendance/check-out [post]
+func (h *Handler) CheckOut(c *gin.Context) {
+	id, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+
+	resp, err := h.Deps.Service.CheckOut(id)
+	if err != nil {
+		response.Error(c, err, "could not check out")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// Mine godoc
+// @Security BearerAuth
+// @Summary      Get my attendance
+// @Description  Get the attendance records of the authenticated user for a period
+// @Tags         attendance
+// @Accept       json
+// @Produce      json
+// @Param        period  query     string  true  "Period (YYYY-MM)"
+// @Success      200  {object}  response.ApiResponse{data=[]dto.AttendanceData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /attendance/me [get]
+func (h *Handler) Mine(c *gin.Context) {
+	id, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	h.list(c, id)
+}
+
+// MySummary godoc
+// @Security BearerAuth
+// @Summary      Get my attendance summary
+// @Description  Get present days, absences and late minutes of the authenticated user for a period
+// @Tags         attendance
+// @Accept       json
+// @Produce      json
+// @Param        period  query     string  true  "Period (YYYY-MM)"
+// @Success      200  {object}  response.ApiResponse{data=dto.SummaryData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /attendance/me/summary [get]
+func (h *Handler) MySummary(c *gin.Context) {
+	id, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	h.summary(c, id)
+}
+
+// GetByUser godoc
+// @Security BearerAuth
+// @Summary      Get user attendance
+// @Description  Get the attendance records of a user for a period
+// @Tags         attendance
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int     true  "User ID"
+// @Param        period  query     string  true  "Period (YYYY-MM)"
+// @Success      200  {object}  response.ApiResponse{data=[]dto.AttendanceData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /attendance/user/{id} [get]
+func (h *Handler) GetByUser(c *gin.Context) {
+	h.list(c, utils.ConvertStringToInt(c.Param("id")))
+}
+
+// SummaryByUser godoc
+// @Security BearerAuth
+// @Summary      Get user attendance summary
+// @Description  Get present days, absences and late minutes of a user for a period
+// @Tags         attendance
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int     true  "User ID"
+// @Param        period  query     string  true  "Period (YYYY-MM)"
+// @Success      200  {object}  response.ApiResponse{data=dto.SummaryData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /attendance/user/{id}/summary [get]
+func (h *Handler) SummaryByUser(c *gin.Context) {
+	h.summary(c, utils.ConvertStringToInt(c.Param("id")))
+}
+
+// Correct godoc
+// @Security BearerAuth
+// @Summary      Correct attendance
+// @Description  Create or replace the attendance record of a user on a work day
+// @Tags         attendance
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.AttendanceCorrectionRequest  true  "Attendance correction payload"
+// @Success      200   {object}  response.ApiResponse{data=dto.AttendanceData}
+// @Failure      400   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /attendance/correct [put]
+func (h *Handler) Correct(c *gin.Context) {
+	var ar dto.AttendanceCorrectionRequest
+	if err := c.ShouldBindJSON(&ar); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	ar.By = by
+
+	resp, err := h.Deps.Service.Correct(&ar)
+	if err != nil {
+		response.Error(c, err, "could not correct attendance")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+func (h *Handler) list(c *gin.Context, userID int64) {
+	var pr dto.AttendancePeriodRequest
+	if err := c.ShouldBindQuery(&pr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	resp, err := h.Deps.Service.List(userID, pr.Period)
+	if err != nil {
+		response.Error(c, err, "could not fetch attendance")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+func (h *Handler) summary(c *gin.Context, userID int64) {
+	var pr dto.AttendancePeriodRequest
+	if err := c.ShouldBindQuery(&pr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	resp, err := h.Deps.Service.Summary(userID, pr.Period)
+	if err != nil {
+		response.Error(c, err, "could not summarize attendance")
+		return
+	}
+	response.OK(c, resp.Data)
+}
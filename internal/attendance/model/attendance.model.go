@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// Attendance statuses. A record is present once the user checks in; admins may mark a day absent.
+const (
+	StatusPresent = "present"
+	StatusAbsent  = "absent"
+)
+
+// Attendance is the record of one user on one work day.
+type Attendance struct {
+	ID          int64      `db:"id"`
+	UserID      int64      `db:"user_id"`
+	FullName    string     `db:"full_name"`
+	WorkDate    time.Time  `db:"work_date"`
+	Status      string     `db:"status"`
+	CheckIn     *time.Time `db:"check_in"`
+	CheckOut    *time.Time `db:"check_out"`
+	LateMinutes int        `db:"late_minutes"`
+	Note        string     `db:"note"`
+	CreatedBy   int64      `db:"created_by"`
+	UpdatedBy   int64      `db:"updated_by"`
+}
+
+// Summary aggregates the attendance of a user over a date range.
+// Working days are Monday to Friday up to the end of the range or today, whichever is earlier;
+// a working day without a present record counts as an absence unless the user is on approved leave.
+type Summary struct {
+	UserID      int64
+	From        time.Time
+	To          time.Time
+	WorkingDays int
+	PresentDays int
+	AbsentDays  int
+	LeaveDays   int
+	LateDays    int
+	LateMinutes int
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/internal/attendance/model"
+)
+
+//go:generate mockgen -source=attendance.repository.go -package=mocks -destination=mocks/mock_attendance_repository.go
+
+// Repository defines an interface for data operations related to daily attendance records.
+type Repository interface {
+	// FetchByUser retrieves the attendance records of a user between two dates, inclusive, oldest first.
+	// Param: userID - the ID of the user; from, to - the date range.
+	// Returns a slice of Attendance pointers and an error if the operation fails.
+	FetchByUser(userID int64, from time.Time, to time.Time) ([]*model.Attendance, error)
+
+	// FindByDate retrieves the attendance record of a user on a work day.
+	// Param: userID - the ID of the user; date - the work day.
+	// Returns a pointer to the Attendance and an error if the operation fails or there is no record.
+	FindByDate(userID int64, date time.Time) (*model.Attendance, error)
+
+	// CheckIn inserts the present record of a user for a work day.
+	// Param: attendance - a pointer to the Attendance entity to be created.
+	// Returns sql.ErrNoRows if the user already has a record for the day, or an error if the operation fails.
+	CheckIn(attendance *model.Attendance) error
+
+	// CheckOut records the check-out time of a user who checked in and has not checked out yet.
+	// Param: id - the ID of the attendance record; at - the check-out time.
+	// Returns false if the record was already checked out, and an error if the operation fails.
+	CheckOut(id int64, at time.Time) (bool, error)
+
+	// Upsert creates or replaces the record of a user for a work day, as an admin correction.
+	// Param: attendance - a pointer to the Attendance entity with corrected data.
+	// Returns an error if the operation fails.
+	Upsert(attendance *model.Attendance) error
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/attendance/model"
+)
+
+const selectAttendance = `
+		SELECT 
+			a.id, a.user_id,
+			COALESCE(u.full_name, '') AS full_name,
+			a.work_date, a.status, a.check_in, a.check_out, a.late_minutes,
+			COALESCE(a.note, '') AS note,
+			COALESCE(a.created_by, 0) AS created_by,
+			COALESCE(a.updated_by, a.created_by, 0) AS updated_by
+		FROM attendances a
+		INNER JOIN users u ON(u.id=a.user_id)
+`
+
+type repository struct {
+	db *postgres.DB
+}
+
+func NewRepository(db *postgres.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) FetchByUser(userID int64, from time.Time, to time.Time) ([]*model.Attendance, error) {
+	attendances := []*model.Attendance{}
+	query := selectAttendance + `
+		WHERE a.user_id = $1 AND a.work_date BETWEEN $2 AND $3
+		ORDER BY a.work_date
+	`
+	err := r.db.Select(&attendances, query, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return attendances, nil
+}
+
+func (r *repository) FindByDate(userID int64, date time.Time) (*model.Attendance, error) {
+	var attendance model.Attendance
+	query := selectAttendance + `
+		WHERE a.user_id = $1 AND a.work_date = $2
+	`
+	err := r.db.Get(&attendance, query, userID, date)
+	if err != nil {
+		return nil, err
+	}
+	return &attendance, nil
+}
+
+func (r *repository) CheckIn(attendance *model.Attendance) error {
+	query := `
+		INSERT INTO attendances (user_id, work_date, status, check_in, late_minutes, created_by, created_at, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $1, NOW(), $1, NOW())
+		ON CONFLICT (user_id, work_date) DO NOTHING
+		RETURNING id
+	`
+	return r.db.QueryRowx(
+		query,
+		attendance.UserID,
+		attendance.WorkDate,
+		attendance.Status,
+		attendance.CheckIn,
+		attendance.LateMinutes,
+	).Scan(&attendance.ID)
+}
+
+func (r *repository) CheckOut(id int64, at time.Time) (bool, error) {
+	query := `
+		UPDATE attendances SET check_out = $2, updated_at = NOW()
+		WHERE id = $1 AND check_in IS NOT NULL AND check_out IS NULL
+	`
+	result, err := r.db.Exec(query, id, at)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *repository) Upsert(attendance *model.Attendance) error {
+	query := `
+		INSERT INTO attendances (user_id, work_date, status, check_in, check_out, late_minutes, note, created_by, created_at, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), $8, NOW())
+		ON CONFLICT (user_id, work_date) DO UPDATE SET
+			status = EXCLUDED.status,
+			check_in = EXCLUDED.check_in,
+			check_out = EXCLUDED.check_out,
+			late_minutes = EXCLUDED.late_minutes,
+			note = EXCLUDED.note,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING id
+	`
+	return r.db.QueryRowx(
+		query,
+		attendance.UserID,
+		attendance.WorkDate,
+		attendance.Status,
+		attendance.CheckIn,
+		attendance.CheckOut,
+		attendance.LateMinutes,
+		attendance.Note,
+		attendance.UpdatedBy,
+	).Scan(&attendance.ID)
+}
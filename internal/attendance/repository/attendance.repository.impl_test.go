@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/attendance/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*postgres.DB, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return postgres.NewDB(sqlx.NewDb(db, "postgres")), mock, func() { db.Close() }
+}
+
+func TestCheckIn_AlreadyCheckedIn(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	now := time.Now()
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("ON CONFLICT \\(user_id, work_date\\) DO NOTHING").
+		WithArgs(int64(7), day, model.StatusPresent, &now, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	err := repo.CheckIn(&model.Attendance{UserID: 7, WorkDate: day, Status: model.StatusPresent, CheckIn: &now, LateMinutes: 5})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCheckOut(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	now := time.Now()
+	mock.ExpectExec("UPDATE attendances SET check_out").
+		WithArgs(int64(3), now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE attendances SET check_out").
+		WithArgs(int64(3), now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ok, err := repo.CheckOut(3, now)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = repo.CheckOut(3, now)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestFetchByUser_Success(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	checkIn := from.Add(9 * time.Hour)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "full_name", "work_date", "status", "check_in", "check_out", "late_minutes", "note", "created_by", "updated_by"}).
+		AddRow(1, 7, "John Doe", from, "present", checkIn, nil, 0, "", 7, 7)
+
+	mock.ExpectQuery("FROM attendances a").
+		WithArgs(int64(7), from, to).
+		WillReturnRows(rows)
+
+	attendances, err := repo.FetchByUser(7, from, to)
+
+	assert.NoError(t, err)
+	assert.Len(t, attendances, 1)
+	assert.Nil(t, attendances[0].CheckOut)
+}
@@ -0,0 +1,24 @@
+package route
+
+import (
+	"github.com/dwilanang/psp/internal/permission"
+	"github.com/dwilanang/psp/internal/registry"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, registry *registry.Registry) {
+	h := registry.NewAttendanceHandler()
+
+	attendanceGroup := rg.Group("/attendance")
+	{
+		// Every authenticated user records and reads their own attendance.
+		attendanceGroup.POST("/check-in", h.CheckIn)
+		attendanceGroup.POST("/check-out", h.CheckOut)
+		attendanceGroup.GET("/me", h.Mine)
+		attendanceGroup.GET("/me/summary", h.MySummary)
+
+		attendanceGroup.GET("/user/:id", registry.RequirePermission(permission.AttendanceRead), h.GetByUser)
+		attendanceGroup.GET("/user/:id/summary", registry.RequirePermission(permission.AttendanceRead), h.SummaryByUser)
+		attendanceGroup.PUT("/correct", registry.RequirePermission(permission.AttendanceCorrect), h.Correct)
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dwilanang/psp/internal/attendance/model"
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+	"github.com/shopspring/decimal"
+)
+
+// Summarizer aggregates the attendance of a user between two dates.
+// It is satisfied by the attendance service.
+type Summarizer interface {
+	Summarize(userID int64, from time.Time, to time.Time) (*model.Summary, error)
+}
+
+// PayslipContributor turns attendance summaries into payslip deductions:
+// a penalty per late minute, and optionally the pro-rated base salary of every absent working day.
+type PayslipContributor struct {
+	summaries      Summarizer
+	latePenalty    decimal.Decimal
+	deductAbsences bool
+}
+
+func NewPayslipContributor(summaries Summarizer, latePenalty decimal.Decimal, deductAbsences bool) *PayslipContributor {
+	return &PayslipContributor{summaries: summaries, latePenalty: latePenalty, deductAbsences: deductAbsences}
+}
+
+// Contribute implements the payslip Contributor interface.
+func (p *PayslipContributor) Contribute(calc *payslipmodel.Calculation) ([]*payslipmodel.PayslipItem, error) {
+	if !p.latePenalty.IsPositive() && !p.deductAbsences {
+		return nil, nil
+	}
+
+	summary, err := p.summaries.Summarize(calc.UserID, calc.Period.Start, calc.Period.End)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*payslipmodel.PayslipItem
+	if p.latePenalty.IsPositive() && summary.LateMinutes > 0 {
+		items = append(items, &payslipmodel.PayslipItem{
+			Code:   "LATE",
+			Name:   fmt.Sprintf("Late Arrival (%d minutes)", summary.LateMinutes),
+			Type:   payslipmodel.TypeDeduction,
+			Amount: p.latePenalty.Mul(decimal.NewFromInt(int64(summary.LateMinutes))).Round(2),
+		})
+	}
+	if workingDays := WorkingDays(calc.Period.Start, calc.Period.End); p.deductAbsences && summary.AbsentDays > 0 && workingDays > 0 {
+		daily := calc.BaseSalary.Div(decimal.NewFromInt(int64(workingDays)))
+		items = append(items, &payslipmodel.PayslipItem{
+			Code:   "ABSENCE",
+			Name:   fmt.Sprintf("Absence (%d days)", summary.AbsentDays),
+			Type:   payslipmodel.TypeDeduction,
+			Amount: daily.Mul(decimal.NewFromInt(int64(summary.AbsentDays))).Round(2),
+		})
+	}
+	return items, nil
+}
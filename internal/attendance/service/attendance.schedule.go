@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+	"time"
+)
+
+// Schedule is the working schedule attendance is evaluated against.
+// Work days are Monday to Friday; arriving after WorkStart plus Grace counts as late from WorkStart.
+type Schedule struct {
+	Location  *time.Location
+	WorkStart time.Duration // offset from midnight
+	Grace     time.Duration
+}
+
+// NewSchedule builds a schedule from an IANA timezone, a work start formatted as HH:MM and a grace period in minutes.
+func NewSchedule(timezone string, workStart string, graceMinutes int64) (Schedule, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("attendance timezone: %w", err)
+	}
+	start, err := parseClock(workStart)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("attendance work start: %w", err)
+	}
+	return Schedule{
+		Location:  location,
+		WorkStart: start,
+		Grace:     time.Duration(graceMinutes) * time.Minute,
+	}, nil
+}
+
+// day returns the work day of an instant in the schedule timezone, as a UTC midnight like the dates read from the database.
+func (s Schedule) day(t time.Time) time.Time {
+	local := t.In(s.Location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// at returns the instant of a clock offset on a work day, in the schedule timezone.
+func (s Schedule) at(day time.Time, offset time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, s.Location).Add(offset)
+}
+
+// lateMinutes returns how late a check-in is on a work day; weekends are never late.
+func (s Schedule) lateMinutes(day time.Time, checkIn time.Time) int {
+	if !isWorkingDay(day) {
+		return 0
+	}
+	start := s.at(day, s.WorkStart)
+	if !checkIn.After(start.Add(s.Grace)) {
+		return 0
+	}
+	return int(checkIn.Sub(start) / time.Minute)
+}
+
+func isWorkingDay(day time.Time) bool {
+	return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+}
+
+// WorkingDays counts the Monday to Friday days from `from` through `to`, inclusive.
+func WorkingDays(from time.Time, to time.Time) int {
+	days := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if isWorkingDay(day) {
+			days++
+		}
+	}
+	return days
+}
+
+func parseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
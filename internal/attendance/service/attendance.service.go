@@ -0,0 +1,44 @@
+package service
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/internal/attendance/dto"
+	"github.com/dwilanang/psp/internal/attendance/model"
+)
+
+//go:generate mockgen -source=attendance.service.go -package=mocks -destination=mocks/mock_attendance_service.go
+
+// Service defines the interface for business logic related to daily attendance.
+// Work days and lateness are evaluated in the timezone of the configured Schedule.
+type Service interface {
+	// CheckIn records the arrival of a user today, computing lateness against the schedule.
+	// Param: userID - the ID of the authenticated user.
+	// Returns the attendance record and ErrAlreadyCheckedIn if the user already has a record today.
+	CheckIn(userID int64) (dto.AttendanceResponse, error)
+
+	// CheckOut records the departure of a user who checked in today.
+	// Param: userID - the ID of the authenticated user.
+	// Returns the attendance record, ErrNotCheckedIn or ErrAlreadyCheckedOut.
+	CheckOut(userID int64) (dto.AttendanceResponse, error)
+
+	// Correct creates or replaces the record of a user on a work day, as an admin correction.
+	// Param: request - a pointer to AttendanceCorrectionRequest DTO.
+	// Returns the corrected record and ErrPeriodLocked if the day belongs to a closed payroll period.
+	Correct(request *dto.AttendanceCorrectionRequest) (dto.AttendanceResponse, error)
+
+	// List retrieves the attendance records of a user for a pay period.
+	// Param: userID - the ID of the user; period - the period formatted as YYYY-MM.
+	// Returns an AttendanceListResponse DTO and an error if the operation fails.
+	List(userID int64, period string) (dto.AttendanceListResponse, error)
+
+	// Summary aggregates the attendance of a user for a pay period.
+	// Param: userID - the ID of the user; period - the period formatted as YYYY-MM.
+	// Returns a SummaryResponse DTO and an error if the operation fails.
+	Summary(userID int64, period string) (dto.SummaryResponse, error)
+
+	// Summarize aggregates the attendance of a user between two dates, for use by other modules such as payroll.
+	// Param: userID - the ID of the user; from, to - the inclusive date range.
+	// Returns the Summary model and an error if the operation fails.
+	Summarize(userID int64, from time.Time, to time.Time) (*model.Summary, error)
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dwilanang/psp/internal/attendance/dto"
+	"github.com/dwilanang/psp/internal/attendance/model"
+	"github.com/dwilanang/psp/internal/attendance/repository"
+	"github.com/dwilanang/psp/internal/payroll/lock"
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+	"github.com/dwilanang/psp/pkg/apperror"
+)
+
+const dateLayout = "2006-01-02"
+
+var (
+	ErrAlreadyCheckedIn  = apperror.Conflict("already checked in today")
+	ErrNotCheckedIn      = apperror.Conflict("not checked in today")
+	ErrAlreadyCheckedOut = apperror.Conflict("already checked out today")
+	ErrInvalidDate       = apperror.Validation("work_date must be formatted as YYYY-MM-DD")
+	ErrInvalidTime       = apperror.Validation("check_in and check_out must be formatted as HH:MM, check_out not before check_in")
+	ErrPeriodLocked      = apperror.Conflict("attendance change falls in a closed payroll period")
+)
+
+// LeaveCalendar lists the working days a user is on approved leave between two dates.
+// It is satisfied by the leave service.
+type LeaveCalendar interface {
+	LeaveDays(userID int64, from time.Time, to time.Time) ([]time.Time, error)
+}
+
+type service struct {
+	repo     repository.Repository
+	schedule Schedule
+	periods  lock.PeriodLocker
+	leaves   LeaveCalendar
+	now      func() time.Time
+}
+
+// NewService creates the attendance service. periods may be nil when payroll periods are not enforced,
+// and leaves may be nil when approved leave is not excused from attendance.
+func NewService(r repository.Repository, schedule Schedule, periods lock.PeriodLocker, leaves LeaveCalendar) *service {
+	return &service{repo: r, schedule: schedule, periods: periods, leaves: leaves, now: time.Now}
+}
+
+// CheckIn implements the Service interface.
+func (s *service) CheckIn(userID int64) (dto.AttendanceResponse, error) {
+	now := s.now()
+	day := s.schedule.day(now)
+	if err := s.checkUnlocked(day); err != nil {
+		return dto.AttendanceResponse{}, err
+	}
+
+	attendance := &model.Attendance{
+		UserID:      userID,
+		WorkDate:    day,
+		Status:      model.StatusPresent,
+		CheckIn:     &now,
+		LateMinutes: s.schedule.lateMinutes(day, now),
+	}
+	err := s.repo.CheckIn(attendance)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dto.AttendanceResponse{}, ErrAlreadyCheckedIn
+		}
+		return dto.AttendanceResponse{}, err
+	}
+	return dto.AttendanceResponse{Data: s.toData(attendance)}, nil
+}
+
+// CheckOut implements the Service interface.
+func (s *service) CheckOut(userID int64) (dto.AttendanceResponse, error) {
+	now := s.now()
+	day := s.schedule.day(now)
+	if err := s.checkUnlocked(day); err != nil {
+		return dto.AttendanceResponse{}, err
+	}
+
+	attendance, err := s.repo.FindByDate(userID, day)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dto.AttendanceResponse{}, ErrNotCheckedIn
+		}
+		return dto.AttendanceResponse{}, err
+	}
+	if attendance.CheckIn == nil {
+		return dto.AttendanceResponse{}, ErrNotCheckedIn
+	}
+
+	ok, err := s.repo.CheckOut(attendance.ID, now)
+	if err != nil {
+		return dto.AttendanceResponse{}, err
+	}
+	if !ok {
+		return dto.AttendanceResponse{}, ErrAlreadyCheckedOut
+	}
+
+	attendance.CheckOut = &now
+	return dto.AttendanceResponse{Data: s.toData(attendance)}, nil
+}
+
+// Correct implements the Service interface.
+func (s *service) Correct(request *dto.AttendanceCorrectionRequest) (dto.AttendanceResponse, error) {
+	day, err := time.Parse(dateLayout, request.WorkDate)
+	if err != nil {
+		return dto.AttendanceResponse{}, ErrInvalidDate
+	}
+
+	attendance := &model.Attendance{
+		UserID:    request.UserID,
+		WorkDate:  day,
+		Status:    request.Status,
+		Note:      request.Note,
+		CreatedBy: request.By,
+		UpdatedBy: request.By,
+	}
+	if request.Status == model.StatusPresent {
+		if attendance.CheckIn, err = s.clock(day, request.CheckIn); err != nil || attendance.CheckIn == nil {
+			return dto.AttendanceResponse{}, ErrInvalidTime
+		}
+		if attendance.CheckOut, err = s.clock(day, request.CheckOut); err != nil {
+			return dto.AttendanceResponse{}, ErrInvalidTime
+		}
+		if attendance.CheckOut != nil && attendance.CheckOut.Before(*attendance.CheckIn) {
+			return dto.AttendanceResponse{}, ErrInvalidTime
+		}
+		attendance.LateMinutes = s.schedule.lateMinutes(day, *attendance.CheckIn)
+	}
+
+	if err := s.checkUnlocked(day); err != nil {
+		return dto.AttendanceResponse{}, err
+	}
+
+	err = s.repo.Upsert(attendance)
+	if err != nil {
+		return dto.AttendanceResponse{}, err
+	}
+	return dto.AttendanceResponse{Data: s.toData(attendance)}, nil
+}
+
+// List implements the Service interface.
+func (s *service) List(userID int64, period string) (dto.AttendanceListResponse, error) {
+	month, err := payslipmodel.ParsePeriod(period)
+	if err != nil {
+		return dto.AttendanceListResponse{}, err
+	}
+
+	attendances, err := s.repo.FetchByUser(userID, month.Start, month.End)
+	if err != nil {
+		return dto.AttendanceListResponse{}, err
+	}
+
+	data := make([]dto.AttendanceData, 0, len(attendances))
+	for _, attendance := range attendances {
+		data = append(data, s.toData(attendance))
+	}
+	return dto.AttendanceListResponse{Data: data}, nil
+}
+
+// Summary implements the Service interface.
+func (s *service) Summary(userID int64, period string) (dto.SummaryResponse, error) {
+	month, err := payslipmodel.ParsePeriod(period)
+	if err != nil {
+		return dto.SummaryResponse{}, err
+	}
+
+	summary, err := s.Summarize(userID, month.Start, month.End)
+	if err != nil {
+		return dto.SummaryResponse{}, err
+	}
+	return dto.SummaryResponse{Data: dto.SummaryData{
+		UserID:      userID,
+		Period:      month.Code,
+		WorkingDays: summary.WorkingDays,
+		PresentDays: summary.PresentDays,
+		AbsentDays:  summary.AbsentDays,
+		LeaveDays:   summary.LeaveDays,
+		LateDays:    summary.LateDays,
+		LateMinutes: summary.LateMinutes,
+	}}, nil
+}
+
+// Summarize implements the Service interface.
+func (s *service) Summarize(userID int64, from time.Time, to time.Time) (*model.Summary, error) {
+	attendances, err := s.repo.FetchByUser(userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &model.Summary{UserID: userID, From: from, To: to}
+	present := map[string]bool{}
+	for _, attendance := range attendances {
+		if attendance.Status != model.StatusPresent {
+			continue
+		}
+		present[attendance.WorkDate.Format(dateLayout)] = true
+		summary.PresentDays++
+		if attendance.LateMinutes > 0 {
+			summary.LateDays++
+			summary.LateMinutes += attendance.LateMinutes
+		}
+	}
+
+	onLeave := map[string]bool{}
+	if s.leaves != nil {
+		days, err := s.leaves.LeaveDays(userID, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, day := range days {
+			onLeave[day.Format(dateLayout)] = true
+		}
+	}
+
+	// Days that have not happened yet are neither worked nor missed.
+	last := to
+	if today := s.schedule.day(s.now()); today.Before(last) {
+		last = today
+	}
+	for day := from; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !isWorkingDay(day) {
+			continue
+		}
+		summary.WorkingDays++
+		switch date := day.Format(dateLayout); {
+		case present[date]:
+		case onLeave[date]:
+			summary.LeaveDays++
+		default:
+			summary.AbsentDays++
+		}
+	}
+	return summary, nil
+}
+
+func (s *service) checkUnlocked(day time.Time) error {
+	if s.periods == nil {
+		return nil
+	}
+
+	locked, err := s.periods.IsLocked(day, &day)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return ErrPeriodLocked
+	}
+	return nil
+}
+
+// clock parses an optional HH:MM on a work day in the schedule timezone.
+func (s *service) clock(day time.Time, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	offset, err := parseClock(value)
+	if err != nil {
+		return nil, err
+	}
+	at := s.schedule.at(day, offset)
+	return &at, nil
+}
+
+func (s *service) toData(attendance *model.Attendance) dto.AttendanceData {
+	data := dto.AttendanceData{
+		ID:          attendance.ID,
+		UserID:      attendance.UserID,
+		FullName:    attendance.FullName,
+		WorkDate:    attendance.WorkDate.Format(dateLayout),
+		Status:      attendance.Status,
+		LateMinutes: attendance.LateMinutes,
+		Note:        attendance.Note,
+	}
+	if attendance.CheckIn != nil {
+		checkIn := attendance.CheckIn.In(s.schedule.Location).Format(time.RFC3339)
+		data.CheckIn = &checkIn
+	}
+	if attendance.CheckOut != nil {
+		checkOut := attendance.CheckOut.In(s.schedule.Location).Format(time.RFC3339)
+		data.CheckOut = &checkOut
+	}
+	return data
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mockrepo "github.com/dwilanang/psp/internal/attendance/repository/mocks"
+
+	"github.com/dwilanang/psp/internal/attendance/dto"
+	"github.com/dwilanang/psp/internal/attendance/model"
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+)
+
+func testSchedule(t *testing.T) Schedule {
+	schedule, err := NewSchedule("Asia/Jakarta", "09:00", 5)
+	require.NoError(t, err)
+	return schedule
+}
+
+func day(value string) time.Time {
+	t, _ := time.Parse(dateLayout, value)
+	return t
+}
+
+type fakePeriodLocker struct {
+	locked bool
+}
+
+func (f *fakePeriodLocker) IsLocked(from time.Time, to *time.Time) (bool, error) {
+	return f.locked, nil
+}
+
+func TestService_CheckIn_Late(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, testSchedule(t), nil, nil)
+	// 09:12 in Jakarta on Wednesday 15 January 2025.
+	svc.now = func() time.Time { return time.Date(2025, 1, 15, 2, 12, 0, 0, time.UTC) }
+
+	mockRepo.
+		EXPECT().
+		CheckIn(gomock.AssignableToTypeOf(&model.Attendance{})).
+		DoAndReturn(func(attendance *model.Attendance) error {
+			assert.Equal(t, day("2025-01-15"), attendance.WorkDate)
+			assert.Equal(t, 12, attendance.LateMinutes)
+			attendance.ID = 1
+			return nil
+		})
+
+	resp, err := svc.CheckIn(7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01-15T09:12:00+07:00", *resp.Data.CheckIn)
+}
+
+func TestService_CheckIn_WithinGrace(t *testing.T) {
+	schedule := testSchedule(t)
+	assert.Equal(t, 0, schedule.lateMinutes(day("2025-01-15"), time.Date(2025, 1, 15, 2, 5, 0, 0, time.UTC)))
+	// Weekends are never late.
+	assert.Equal(t, 0, schedule.lateMinutes(day("2025-01-18"), time.Date(2025, 1, 18, 5, 0, 0, 0, time.UTC)))
+}
+
+func TestService_CheckIn_Twice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, testSchedule(t), nil, nil)
+
+	mockRepo.EXPECT().CheckIn(gomock.Any()).Return(sql.ErrNoRows)
+
+	_, err := svc.CheckIn(7)
+	assert.ErrorIs(t, err, ErrAlreadyCheckedIn)
+}
+
+func TestService_CheckOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, testSchedule(t), nil, nil)
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	checkIn := now.Add(-8 * time.Hour)
+	mockRepo.EXPECT().FindByDate(int64(7), day("2025-01-15")).Return(&model.Attendance{ID: 3, UserID: 7, WorkDate: day("2025-01-15"), CheckIn: &checkIn}, nil).Times(2)
+	mockRepo.EXPECT().CheckOut(int64(3), now).Return(true, nil)
+	mockRepo.EXPECT().CheckOut(int64(3), now).Return(false, nil)
+	mockRepo.EXPECT().FindByDate(int64(8), day("2025-01-15")).Return(nil, sql.ErrNoRows)
+
+	resp, err := svc.CheckOut(7)
+	assert.NoError(t, err)
+	assert.NotNil(t, resp.Data.CheckOut)
+
+	_, err = svc.CheckOut(7)
+	assert.ErrorIs(t, err, ErrAlreadyCheckedOut)
+
+	_, err = svc.CheckOut(8)
+	assert.ErrorIs(t, err, ErrNotCheckedIn)
+}
+
+func TestService_Correct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, testSchedule(t), &fakePeriodLocker{}, nil)
+
+	mockRepo.
+		EXPECT().
+		Upsert(gomock.AssignableToTypeOf(&model.Attendance{})).
+		DoAndReturn(func(attendance *model.Attendance) error {
+			assert.Equal(t, 30, attendance.LateMinutes)
+			assert.Equal(t, int64(1), attendance.UpdatedBy)
+			return nil
+		})
+
+	_, err := svc.Correct(&dto.AttendanceCorrectionRequest{UserID: 7, WorkDate: "2025-01-15", Status: "present", CheckIn: "09:30", CheckOut: "17:00", By: 1})
+	assert.NoError(t, err)
+
+	_, err = svc.Correct(&dto.AttendanceCorrectionRequest{UserID: 7, WorkDate: "2025-01-15", Status: "present", CheckIn: "17:00", CheckOut: "09:00"})
+	assert.ErrorIs(t, err, ErrInvalidTime)
+}
+
+func TestService_Correct_PeriodLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := NewService(mockrepo.NewMockRepository(ctrl), testSchedule(t), &fakePeriodLocker{locked: true}, nil)
+
+	_, err := svc.Correct(&dto.AttendanceCorrectionRequest{UserID: 7, WorkDate: "2025-01-15", Status: "absent"})
+	assert.ErrorIs(t, err, ErrPeriodLocked)
+}
+
+func TestService_Summarize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, testSchedule(t), nil, nil)
+	// Friday 10 January 2025: only the first 8 working days have happened.
+	svc.now = func() time.Time { return time.Date(2025, 1, 10, 5, 0, 0, 0, time.UTC) }
+
+	mockRepo.EXPECT().FetchByUser(int64(7), day("2025-01-01"), day("2025-01-31")).Return([]*model.Attendance{
+		{WorkDate: day("2025-01-02"), Status: model.StatusPresent},
+		{WorkDate: day("2025-01-03"), Status: model.StatusPresent, LateMinutes: 10},
+		{WorkDate: day("2025-01-06"), Status: model.StatusPresent, LateMinutes: 20},
+		{WorkDate: day("2025-01-07"), Status: model.StatusAbsent},
+	}, nil)
+
+	summary, err := svc.Summarize(7, day("2025-01-01"), day("2025-01-31"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 8, summary.WorkingDays)
+	assert.Equal(t, 3, summary.PresentDays)
+	assert.Equal(t, 5, summary.AbsentDays)
+	assert.Equal(t, 2, summary.LateDays)
+	assert.Equal(t, 30, summary.LateMinutes)
+}
+
+type fakeLeaveCalendar struct {
+	days []time.Time
+}
+
+func (f *fakeLeaveCalendar) LeaveDays(userID int64, from time.Time, to time.Time) ([]time.Time, error) {
+	return f.days, nil
+}
+
+func TestService_Summarize_ExcusesLeave(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	leaves := &fakeLeaveCalendar{days: []time.Time{day("2025-01-08"), day("2025-01-09"), day("2025-01-10")}}
+	svc := NewService(mockRepo, testSchedule(t), nil, leaves)
+	svc.now = func() time.Time { return time.Date(2025, 1, 10, 5, 0, 0, 0, time.UTC) }
+
+	// The user came in on the first day of their leave, which counts as worked.
+	mockRepo.EXPECT().FetchByUser(int64(7), day("2025-01-01"), day("2025-01-31")).Return([]*model.Attendance{
+		{WorkDate: day("2025-01-08"), Status: model.StatusPresent},
+	}, nil)
+
+	summary, err := svc.Summarize(7, day("2025-01-01"), day("2025-01-31"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 8, summary.WorkingDays)
+	assert.Equal(t, 1, summary.PresentDays)
+	assert.Equal(t, 2, summary.LeaveDays)
+	assert.Equal(t, 5, summary.AbsentDays)
+}
+
+type fakeSummarizer struct {
+	summary *model.Summary
+}
+
+func (f *fakeSummarizer) Summarize(userID int64, from time.Time, to time.Time) (*model.Summary, error) {
+	return f.summary, nil
+}
+
+func TestPayslipContributor(t *testing.T) {
+	period, _ := payslipmodel.ParsePeriod("2025-01")
+	calc := &payslipmodel.Calculation{UserID: 7, Period: period, BaseSalary: decimal.NewFromInt(4600000)}
+	summaries := &fakeSummarizer{summary: &model.Summary{AbsentDays: 2, LateMinutes: 30}}
+
+	items, err := NewPayslipContributor(summaries, decimal.Zero, false).Contribute(calc)
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	items, err = NewPayslipContributor(summaries, decimal.NewFromInt(1000), true).Contribute(calc)
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, "30000.00", items[0].Amount.StringFixed(2))
+	// January 2025 has 23 working days: 4,600,000 / 23 * 2.
+	assert.Equal(t, "400000.00", items[1].Amount.StringFixed(2))
+}
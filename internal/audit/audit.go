@@ -0,0 +1,11 @@
+package audit
+
+import (
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/audit/service"
+)
+
+type Dependencies struct {
+	DBPostgres *postgres.DB
+	Service    service.Service
+}
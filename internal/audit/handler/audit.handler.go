@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"github.com/dwilanang/psp/internal/audit"
+	"github.com/dwilanang/psp/utils/query"
+	"github.com/dwilanang/psp/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	Deps audit.Dependencies
+}
+
+func NewHandler(deps audit.Dependencies) *Handler {
+	return &Handler{
+		Deps: deps,
+	}
+}
+
+// GetAll godoc
+// @Security BearerAuth
+// @Summary Get audit log entries
+// @Description Get a page of the changes made to roles, users and salaries, newest first. Pages are numbered with page, or pass meta.next_cursor as after to page by cursor.
+// @Tags audit
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Page size (default 20, max 100)"
+// @Param sort query string false "Comma separated fields to sort on, prefixed with - for descending: id, created_at" example(-created_at)
+// @Param filter[entity] query string false "Entity: role, user or salary"
+// @Param filter[entity_id] query string false "ID of the entity; the UUID for users"
+// @Param filter[action] query string false "Action: create, update or delete"
+// @Param filter[actor_id] query int false "ID of the user who made the change; 0 for the system"
+// @Param filter[ip_address] query string false "IP address the change was made from"
+// @Param filter[request_id] query string false "ID of the request the change was made in"
+// @Param after query string false "Cursor of the next page, from meta.next_cursor"
+// @Success 200 {object} response.ApiResponse{data=[]model.Entry,meta=response.Meta}
+// @Failure 400 {object} response.Problem
+// @Failure 401 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Router /audit-logs/all [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	params, err := query.Parse(c)
+	if err != nil {
+		response.Error(c, err, "invalid list parameters")
+		return
+	}
+
+	result, err := h.Deps.Service.List(params)
+	if err != nil {
+		response.Error(c, err, "could not fetch audit log")
+		return
+	}
+	response.Page(c, result.Rows, query.Meta(c, params, result))
+}
@@ -0,0 +1,116 @@
+package model
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Audited entities.
+const (
+	EntityRole   = "role"
+	EntityUser   = "user"
+	EntitySalary = "salary"
+)
+
+// Actions recorded for an entity.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Actor is who made a change, and the request it was made in.
+// Its zero value stands for the system, e.g. a change made by a command rather than an API request.
+type Actor struct {
+	UserID    int64
+	IPAddress string
+	RequestID string
+}
+
+// Snapshot holds the audited fields of an entity, keyed by their JSON name.
+// Values must be JSON-encodable and comparable with reflect.DeepEqual, e.g. strings, numbers and booleans.
+type Snapshot map[string]any
+
+// Value stores the snapshot as JSON, or NULL when it is nil.
+func (s Snapshot) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan reads a snapshot stored by Value.
+func (s *Snapshot) Scan(src any) error {
+	var b []byte
+	switch src := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		b = src
+	case string:
+		b = []byte(src)
+	default:
+		return fmt.Errorf("cannot scan %T into a snapshot", src)
+	}
+
+	// Numbers are kept as written, so large IDs and amounts keep their precision.
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(s)
+}
+
+// Entry is one row of the audit log.
+type Entry struct {
+	ID        int64     `db:"id" json:"id"`
+	ActorID   int64     `db:"actor_id" json:"actor_id"`
+	ActorName string    `db:"actor_name" json:"actor_name"`
+	IPAddress string    `db:"ip_address" json:"ip_address"`
+	RequestID string    `db:"request_id" json:"request_id"`
+	Entity    string    `db:"entity" json:"entity" example:"role"`
+	EntityID  string    `db:"entity_id" json:"entity_id" example:"3"`
+	Action    string    `db:"action" json:"action" example:"update"`
+	Before    Snapshot  `db:"before" json:"before" swaggertype:"object"`
+	After     Snapshot  `db:"after" json:"after" swaggertype:"object"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// NewEntry returns the entry recording that actor made a change to an entity, given its state before and
+// after the change. before is nil on create and after is nil on delete; on update both only keep the
+// fields that changed.
+func NewEntry(actor Actor, entity string, entityID any, action string, before, after Snapshot) *Entry {
+	if before != nil && after != nil {
+		before, after = diff(before, after)
+	}
+
+	return &Entry{
+		ActorID:   actor.UserID,
+		IPAddress: actor.IPAddress,
+		RequestID: actor.RequestID,
+		Entity:    entity,
+		EntityID:  fmt.Sprint(entityID),
+		Action:    action,
+		Before:    before,
+		After:     after,
+	}
+}
+
+// diff returns the fields of before and after whose values differ.
+func diff(before, after Snapshot) (Snapshot, Snapshot) {
+	changedBefore, changedAfter := Snapshot{}, Snapshot{}
+	for field, value := range before {
+		if other, ok := after[field]; !ok || !reflect.DeepEqual(value, other) {
+			changedBefore[field] = value
+		}
+	}
+	for field, value := range after {
+		if other, ok := before[field]; !ok || !reflect.DeepEqual(value, other) {
+			changedAfter[field] = value
+		}
+	}
+	return changedBefore, changedAfter
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/audit/model"
+	"github.com/dwilanang/psp/utils/query"
+)
+
+//go:generate mockgen -source=audit.repository.go -package=mocks -destination=mocks/mock_audit_repository.go
+
+// Repository defines an interface for data operations related to the audit log.
+// The log is append-only: entries are created and listed, never changed or removed.
+type Repository interface {
+	// WithTx returns a repository running its statements in tx, so entries are written with the change they record.
+	// Param: tx - the transaction to bind to.
+	// Returns a Repository bound to tx; the receiver is left unchanged.
+	WithTx(tx postgres.Executor) Repository
+
+	// Create appends an entry to the audit log.
+	// Param: entry - a pointer to the Entry to be created; its ID and CreatedAt are set on success.
+	// Returns an error if the operation fails.
+	Create(entry *model.Entry) error
+
+	// Fetch retrieves a page of audit log entries, sorted and filtered as params ask.
+	// Param: params - the page, sort and filters, checked against the fields entries may be listed by.
+	// Returns the page with the total number of matching entries, a validation error if params sort or
+	// filter on another field, or an error if the operation fails.
+	Fetch(params query.Params) (query.Result[*model.Entry], error)
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/audit/model"
+	"github.com/dwilanang/psp/utils/query"
+)
+
+type repository struct {
+	db *postgres.DB
+	q  postgres.Executor // q is the master, or the transaction the repository is bound to by WithTx.
+}
+
+func NewRepository(db *postgres.DB) *repository {
+	return &repository{db: db, q: db.DB}
+}
+
+func (r *repository) WithTx(tx postgres.Executor) Repository {
+	return &repository{db: r.db, q: tx}
+}
+
+// entryQuery whitelists what audit log listings may be sorted and filtered on.
+var entryQuery = query.Schema[*model.Entry]{
+	Key:         "id",
+	DefaultSort: []query.Sort{{Field: "id", Desc: true}},
+	Fields: map[string]query.Field[*model.Entry]{
+		"id": {
+			Column: "al.id", Sortable: true,
+			Value: func(entry *model.Entry) any { return entry.ID },
+		},
+		"created_at": {
+			Column: "date_trunc('second', al.created_at)", Sortable: true,
+			Value: func(entry *model.Entry) any { return entry.CreatedAt },
+		},
+		"entity":     {Column: "al.entity", Filter: query.Equal},
+		"entity_id":  {Column: "al.entity_id", Filter: query.Equal},
+		"action":     {Column: "al.action", Filter: query.Equal},
+		"actor_id":   {Column: "COALESCE(al.actor_id, 0)", Filter: query.Equal},
+		"ip_address": {Column: "al.ip_address", Filter: query.Equal},
+		"request_id": {Column: "al.request_id", Filter: query.Equal},
+	},
+}
+
+func (r *repository) Create(entry *model.Entry) error {
+	query := `
+		INSERT INTO audit_log (actor_id, ip_address, request_id, entity, entity_id, action, before, after, created_at)
+		VALUES (NULLIF($1, 0), $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING id, created_at
+	`
+	err := r.q.QueryRowx(
+		query,
+		entry.ActorID,
+		entry.IPAddress,
+		entry.RequestID,
+		entry.Entity,
+		entry.EntityID,
+		entry.Action,
+		entry.Before,
+		entry.After,
+	).Scan(&entry.ID, &entry.CreatedAt)
+
+	return postgres.WrapError(err, "audit log entry")
+}
+
+func (r *repository) Fetch(params query.Params) (query.Result[*model.Entry], error) {
+	q, err := entryQuery.SQL(params)
+	if err != nil {
+		return query.Result[*model.Entry]{}, err
+	}
+
+	// Count and page on the same replica so the total matches the page.
+	replica := r.db.Reader(r.q)
+
+	var total int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM audit_log al
+		WHERE ` + q.Filter
+	if err := replica.Get(&total, countQuery, q.FilterArgs...); err != nil {
+		return query.Result[*model.Entry]{}, postgres.WrapError(err, "audit log entry")
+	}
+
+	entries := []*model.Entry{}
+	listQuery := `
+		SELECT
+			al.id,
+			COALESCE(al.actor_id, 0) AS actor_id,
+			COALESCE(u.full_name, '') AS actor_name,
+			al.ip_address, al.request_id,
+			al.entity, al.entity_id, al.action,
+			al.before, al.after,
+			date_trunc('second', al.created_at) AS created_at
+		FROM audit_log al
+		LEFT JOIN users u ON(u.id=al.actor_id)
+		WHERE ` + q.Where + `
+		` + q.OrderBy + `
+		` + q.Limit
+	err = replica.Select(&entries, listQuery, q.Args...)
+	if err != nil {
+		return query.Result[*model.Entry]{}, postgres.WrapError(err, "audit log entry")
+	}
+	return entryQuery.Result(params, entries, total)
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/audit/model"
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/dwilanang/psp/utils/query"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupDBMock(t *testing.T) (*postgres.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	return postgres.NewDB(sqlx.NewDb(db, "postgres")), mock
+}
+
+func TestRepository_Create(t *testing.T) {
+	db, mock := setupDBMock(t)
+	defer db.Close()
+
+	entry := model.NewEntry(
+		model.Actor{UserID: 1, IPAddress: "10.0.0.1", RequestID: "req-1"},
+		model.EntityRole, int64(3), model.ActionCreate,
+		nil, model.Snapshot{"name": "Manager"},
+	)
+
+	createdAt := time.Now()
+	mock.ExpectQuery("INSERT INTO audit_log").
+		WithArgs(int64(1), "10.0.0.1", "req-1", "role", "3", "create", nil, []byte(`{"name":"Manager"}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, createdAt))
+
+	err := NewRepository(db).Create(entry)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), entry.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_Fetch(t *testing.T) {
+	db, mock := setupDBMock(t)
+	defer db.Close()
+
+	countQuery := regexp.QuoteMeta(`
+		SELECT COUNT(*)
+		FROM audit_log al
+		WHERE al.entity = $1 AND al.entity_id = $2`)
+	listQuery := regexp.QuoteMeta(`
+		FROM audit_log al
+		LEFT JOIN users u ON(u.id=al.actor_id)
+		WHERE al.entity = $1 AND al.entity_id = $2
+		ORDER BY al.id DESC
+		LIMIT $3 OFFSET $4`)
+
+	createdAt := time.Date(2025, 7, 4, 9, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{
+		"id", "actor_id", "actor_name", "ip_address", "request_id",
+		"entity", "entity_id", "action", "before", "after", "created_at",
+	}).
+		AddRow(2, 1, "Super Admin", "10.0.0.1", "req-2", "role", "3", "update", []byte(`{"name":"Manager"}`), []byte(`{"name":"Lead"}`), createdAt).
+		AddRow(1, 0, "", "", "", "role", "3", "create", nil, []byte(`{"name":"Manager","id":9007199254740993}`), createdAt)
+
+	mock.ExpectQuery(countQuery).WithArgs("role", "3").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(listQuery).WithArgs("role", "3", 21, 0).WillReturnRows(rows)
+
+	result, err := NewRepository(db).Fetch(query.Params{
+		Page:    1,
+		PerPage: 20,
+		Filters: []query.Filter{{Field: "entity", Value: "role"}, {Field: "entity_id", Value: "3"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Rows, 2)
+	assert.Equal(t, int64(2), result.Total)
+	assert.Equal(t, model.Snapshot{"name": "Lead"}, result.Rows[0].After)
+	assert.Nil(t, result.Rows[1].Before)
+
+	// Numbers keep their precision through the log.
+	b, err := json.Marshal(result.Rows[1].After)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Manager","id":9007199254740993}`, string(b))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_Fetch_RejectsUnknownFilter(t *testing.T) {
+	db, mock := setupDBMock(t)
+	defer db.Close()
+
+	_, err := NewRepository(db).Fetch(query.Params{Page: 1, PerPage: 20, Filters: []query.Filter{{Field: "before", Value: "x"}}})
+
+	assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,16 @@
+package route
+
+import (
+	"github.com/dwilanang/psp/internal/permission"
+	"github.com/dwilanang/psp/internal/registry"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, registry *registry.Registry) {
+	h := registry.NewAuditHandler()
+
+	auditGroup := rg.Group("/audit-logs")
+	{
+		auditGroup.GET("/all", registry.RequirePermission(permission.AuditRead), h.GetAll)
+	}
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"github.com/dwilanang/psp/internal/audit/model"
+	"github.com/dwilanang/psp/utils/query"
+)
+
+//go:generate mockgen -source=audit.service.go -package=mocks -destination=mocks/mock_audit_service.go
+
+// Service defines the interface for business logic related to the audit log.
+// Entries are written by the services whose changes they record, in the transaction of the change.
+type Service interface {
+	// List retrieves a page of audit log entries, newest first unless params sort otherwise.
+	// Param: params - the page, sort and filters parsed from the request.
+	// Returns the page with the total number of matching entries, and an error if the operation fails.
+	List(params query.Params) (query.Result[*model.Entry], error)
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"github.com/dwilanang/psp/internal/audit/model"
+	"github.com/dwilanang/psp/internal/audit/repository"
+	"github.com/dwilanang/psp/utils/query"
+)
+
+type service struct {
+	repo repository.Repository
+}
+
+func NewService(r repository.Repository) *service {
+	return &service{repo: r}
+}
+
+// List implements the Service interface.
+func (s *service) List(params query.Params) (query.Result[*model.Entry], error) {
+	result, err := s.repo.Fetch(params)
+	if err != nil {
+		return query.Result[*model.Entry]{}, err
+	}
+
+	return result, nil
+}
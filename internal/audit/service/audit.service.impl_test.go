@@ -0,0 +1,43 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dwilanang/psp/internal/audit/model"
+	mockrepo "github.com/dwilanang/psp/internal/audit/repository/mocks"
+	"github.com/dwilanang/psp/utils/query"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo)
+
+	params := query.Params{Page: 1, PerPage: 20, Filters: []query.Filter{{Field: "entity", Value: "user"}}}
+	expected := []*model.Entry{{ID: 2, Entity: model.EntityUser, Action: model.ActionUpdate}}
+	mockRepo.EXPECT().Fetch(params).Return(query.Result[*model.Entry]{Rows: expected, Total: 1}, nil)
+
+	result, err := svc.List(params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result.Rows)
+	assert.Equal(t, int64(1), result.Total)
+}
+
+func TestService_List_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo)
+
+	mockRepo.EXPECT().Fetch(gomock.Any()).Return(query.Result[*model.Entry]{}, errors.New("fetch error"))
+
+	_, err := svc.List(query.Params{Page: 1, PerPage: 20})
+	assert.Error(t, err)
+}
@@ -1,7 +1,12 @@
 package dto
 
+import "time"
+
 type AuthResponse struct {
-	Type   string `json:"type"`
-	Token  string `json:"token"`
-	Expire string `json:"expire"`
+	Type             string    `json:"type"`
+	Token            string    `json:"token"`
+	Expire           string    `json:"expire"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
 }
@@ -5,7 +5,11 @@ import (
 
 	"github.com/dwilanang/psp/internal/auth/dto"
 	"github.com/dwilanang/psp/internal/auth/service"
+	"github.com/dwilanang/psp/internal/auth/util"
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/dwilanang/psp/utils"
 	utilrequest "github.com/dwilanang/psp/utils/request"
+	"github.com/dwilanang/psp/utils/response"
 	"github.com/gin-gonic/gin"
 )
 
@@ -26,22 +30,131 @@ func NewHandler(svc service.Service) *Handler {
 // @Accept       json
 // @Produce      json
 // @Param        body  body      dto.AuthRequest  true  "Login credentials"
-// @Success      200   {object}  dto.AuthResponse
-// @Failure      400   {object}  map[string]string
-// @Failure      401   {object}  map[string]string
+// @Success      200   {object}  response.ApiResponse{data=dto.AuthResponse}
+// @Failure      400   {object}  response.Problem
+// @Failure      401   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
 // @Router       /auth/login [post]
 func (h *Handler) Login(c *gin.Context) {
 	var ar dto.AuthRequest
 	if err := c.ShouldBindJSON(&ar); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": utilrequest.ValidateRequest(err)})
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
 		return
 	}
 
 	resp, err := h.Service.Login(&ar)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		response.Error(c, err, "could not login")
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	response.OK(c, resp)
+}
+
+// Refresh godoc
+// @Summary      Refresh token
+// @Description  Exchange a refresh token for a new access and refresh token pair. The presented refresh token is revoked.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.RefreshRequest  true  "Refresh token"
+// @Success      200   {object}  response.ApiResponse{data=dto.AuthResponse}
+// @Failure      400   {object}  response.Problem
+// @Failure      401   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /auth/refresh [post]
+func (h *Handler) Refresh(c *gin.Context) {
+	var rr dto.RefreshRequest
+	if err := c.ShouldBindJSON(&rr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	resp, err := h.Service.Refresh(&rr)
+	if err != nil {
+		response.Error(c, err, "could not refresh token")
+		return
+	}
+
+	response.OK(c, resp)
+}
+
+// Logout godoc
+// @Security BearerAuth
+// @Summary      Logout user
+// @Description  Revoke the current access token and, when given, the refresh token issued with it
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.LogoutRequest  false  "Refresh token to revoke"
+// @Success      200   {object}  response.ApiResponse
+// @Failure      400   {object}  response.Problem
+// @Failure      401   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /auth/logout [post]
+func (h *Handler) Logout(c *gin.Context) {
+	var lr dto.LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&lr); err != nil {
+			response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+			return
+		}
+	}
+
+	claims, err := util.GetClaims(c)
+	if err != nil {
+		response.Error(c, apperror.Unauthorized("invalid claims"), "")
+		return
+	}
+
+	if err := h.Service.Logout(claims, &lr); err != nil {
+		response.Error(c, err, "could not logout")
+		return
+	}
+
+	response.Message(c, "Logout succefully")
+}
+
+// RevokeSessions godoc
+// @Security BearerAuth
+// @Summary      Revoke user sessions
+// @Description  Revoke every access and refresh token issued to a user
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int  true  "User ID"
+// @Success      200   {object}  response.ApiResponse
+// @Failure      401   {object}  response.Problem
+// @Failure      403   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /auth/sessions/revoke/{id} [post]
+func (h *Handler) RevokeSessions(c *gin.Context) {
+	id := c.Param("id")
+
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+
+	idInt := utils.ConvertStringToInt(id)
+
+	if err := h.Service.RevokeSessions(idInt, by); err != nil {
+		response.Error(c, err, "could not revoke sessions")
+		return
+	}
+
+	response.Message(c, "Sessions has been revoked.")
+}
+
+// JWKS godoc
+// @Summary      JSON Web Key Set
+// @Description  Public keys used to verify access tokens, selected by the token's kid header
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  keys.JWKS
+// @Router       /.well-known/jwks.json [get]
+func (h *Handler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.Service.JWKS())
 }
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dwilanang/psp/internal/auth/dto"
+	"github.com/dwilanang/psp/internal/auth/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func authResponse() dto.AuthResponse {
+	return dto.AuthResponse{
+		Type:             "Bearer",
+		Token:            "access-token",
+		Expire:           "15m",
+		ExpiresAt:        time.Date(2025, 7, 1, 8, 15, 0, 0, time.UTC),
+		RefreshToken:     "refresh-token",
+		RefreshExpiresAt: time.Date(2025, 7, 8, 8, 0, 0, 0, time.UTC),
+	}
+}
+
+const authResponseJSON = `{
+	"status": true,
+	"message": "",
+	"data": {
+		"type": "Bearer",
+		"token": "access-token",
+		"expire": "15m",
+		"expires_at": "2025-07-01T08:15:00Z",
+		"refresh_token": "refresh-token",
+		"refresh_expires_at": "2025-07-08T08:00:00Z"
+	}
+}`
+
+func serveJSON(handler gin.HandlerFunc, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler(c)
+	return w
+}
+
+func TestHandler_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockService(ctrl)
+	svc.EXPECT().Login(&dto.AuthRequest{Username: "johndoe", Password: "secret123"}).Return(authResponse(), nil)
+
+	w := serveJSON(NewHandler(svc).Login, `{"username": "johndoe", "password": "secret123"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, authResponseJSON, w.Body.String())
+}
+
+func TestHandler_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockService(ctrl)
+	svc.EXPECT().Refresh(&dto.RefreshRequest{RefreshToken: "old-token"}).Return(authResponse(), nil)
+
+	w := serveJSON(NewHandler(svc).Refresh, `{"refresh_token": "old-token"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, authResponseJSON, w.Body.String())
+}
@@ -0,0 +1,246 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dwilanang/psp/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	privateKeySuffix = ".pem"
+	publicKeySuffix  = ".pub.pem"
+)
+
+// Key is a single JWT key identified by its kid.
+// Private is nil for keys that are only kept to verify tokens signed before a rotation.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.PrivateKey
+	Public  crypto.PublicKey
+}
+
+// KeySet holds the key used to sign new tokens and every key accepted when verifying tokens.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+}
+
+// Load builds the KeySet described by the configuration.
+//
+// With JWT_SIGNING_METHOD=HS256 tokens are signed with JWT_SECRET, which must not be empty.
+// For RS256, ES256 and EdDSA every "<kid>.pem" private key and "<kid>.pub.pem" public key in JWT_KEYS_DIR
+// is loaded for verification, and JWT_ACTIVE_KEY_ID (or the greatest kid when empty) signs new tokens.
+// Rotating keys is done by adding a new private key, then removing the private part of the old key
+// once it is no longer active while keeping its public key until the tokens it signed have expired.
+func Load(cfg *config.Config) (*KeySet, error) {
+	method := strings.ToUpper(cfg.JWTSigningMethod)
+	if method == "" || method == jwt.SigningMethodHS256.Alg() {
+		return NewHMACKeySet(cfg.JWTActiveKeyID, cfg.JWTSecret)
+	}
+
+	ks, err := LoadDir(cfg.JWTKeysDir, cfg.JWTActiveKeyID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(ks.signing.Method.Alg(), method) {
+		return nil, fmt.Errorf("keys: active key %q is %s but JWT_SIGNING_METHOD is %s", ks.signing.ID, ks.signing.Method.Alg(), method)
+	}
+	return ks, nil
+}
+
+// NewHMACKeySet returns a KeySet that signs and verifies with a shared HS256 secret.
+func NewHMACKeySet(kid string, secret string) (*KeySet, error) {
+	if secret == "" {
+		return nil, errors.New("keys: JWT_SECRET is required for HS256")
+	}
+	if kid == "" {
+		kid = "default"
+	}
+
+	key := &Key{
+		ID:      kid,
+		Method:  jwt.SigningMethodHS256,
+		Private: []byte(secret),
+		Public:  []byte(secret),
+	}
+	return &KeySet{signing: key, keys: map[string]*Key{kid: key}}, nil
+}
+
+// LoadDir loads every asymmetric key from dir and selects activeKID as the signing key.
+func LoadDir(dir string, activeKID string) (*KeySet, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("keys: read %s: %w", dir, err)
+	}
+
+	ks := &KeySet{keys: map[string]*Key{}}
+	var privateKIDs []string
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, privateKeySuffix) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("keys: read %s: %w", name, err)
+		}
+
+		var key *Key
+		if strings.HasSuffix(name, publicKeySuffix) {
+			key, err = ParsePublicKey(strings.TrimSuffix(name, publicKeySuffix), data)
+		} else {
+			key, err = ParsePrivateKey(strings.TrimSuffix(name, privateKeySuffix), data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("keys: %s: %w", name, err)
+		}
+
+		// A private key always wins over the public-only copy of the same kid.
+		if existing, ok := ks.keys[key.ID]; ok && existing.Private != nil {
+			continue
+		}
+		ks.keys[key.ID] = key
+		if key.Private != nil {
+			privateKIDs = append(privateKIDs, key.ID)
+		}
+	}
+
+	if activeKID == "" {
+		if len(privateKIDs) == 0 {
+			return nil, fmt.Errorf("keys: no private key found in %s", dir)
+		}
+		sort.Strings(privateKIDs)
+		activeKID = privateKIDs[len(privateKIDs)-1]
+	}
+
+	signing, ok := ks.keys[activeKID]
+	if !ok || signing.Private == nil {
+		return nil, fmt.Errorf("keys: no private key for active kid %q in %s", activeKID, dir)
+	}
+	ks.signing = signing
+
+	return ks, nil
+}
+
+// ParsePrivateKey parses a PEM encoded RSA, P-256 ECDSA or Ed25519 private key.
+func ParsePrivateKey(kid string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var private crypto.PrivateKey
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		private, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", private)
+	}
+
+	key, err := newKey(kid, signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	key.Private = private
+	return key, nil
+}
+
+// ParsePublicKey parses a PEM encoded PKIX RSA, P-256 ECDSA or Ed25519 public key.
+func ParsePublicKey(kid string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return newKey(kid, public)
+}
+
+func newKey(kid string, public crypto.PublicKey) (*Key, error) {
+	key := &Key{ID: kid, Public: public}
+
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("unsupported ECDSA curve %s", pub.Curve.Params().Name)
+		}
+		key.Method = jwt.SigningMethodES256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", public)
+	}
+
+	return key, nil
+}
+
+// SigningKey returns the key used to sign new tokens.
+func (ks *KeySet) SigningKey() *Key {
+	return ks.signing
+}
+
+// Keys returns every verification key ordered by kid.
+func (ks *KeySet) Keys() []*Key {
+	keys := make([]*Key, 0, len(ks.keys))
+	for _, key := range ks.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys
+}
+
+// Sign signs the claims with the active key and sets the kid header.
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signing.Method, claims)
+	token.Header["kid"] = ks.signing.ID
+	return token.SignedString(ks.signing.Private)
+}
+
+// Keyfunc resolves the verification key of a token from its kid header.
+// It is meant to be passed to jwt.Parse; the token's alg must match the algorithm of the selected key.
+// Tokens without a kid were issued before key rotation was introduced and are checked against the signing key.
+func (ks *KeySet) Keyfunc(token *jwt.Token) (any, error) {
+	key := ks.signing
+	if kid, ok := token.Header["kid"].(string); ok {
+		if key, ok = ks.keys[kid]; !ok {
+			return nil, fmt.Errorf("keys: unknown kid %q", kid)
+		}
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("keys: unexpected signing method %s for kid %q", token.Method.Alg(), key.ID)
+	}
+	return key.Public, nil
+}
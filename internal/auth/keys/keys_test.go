@@ -0,0 +1,135 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dwilanang/psp/config"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func writePrivateKey(t *testing.T, dir, kid string, key crypto.PrivateKey) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, kid+privateKeySuffix), data, 0o600))
+}
+
+func writePublicKey(t *testing.T, dir, kid string, key crypto.PublicKey) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	assert.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, kid+publicKeySuffix), data, 0o644))
+}
+
+func TestNewHMACKeySet_RequiresSecret(t *testing.T) {
+	_, err := NewHMACKeySet("", "")
+	assert.Error(t, err)
+}
+
+func TestLoad_SignAndVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		method string
+		key    crypto.PrivateKey
+	}{
+		{"RS256", rsaKey},
+		{"ES256", ecKey},
+		{"EdDSA", edKey},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.method, func(t *testing.T) {
+			dir := t.TempDir()
+			writePrivateKey(t, dir, "2025-01", tc.key)
+
+			ks, err := Load(&config.Config{JWTSigningMethod: tc.method, JWTKeysDir: dir})
+			assert.NoError(t, err)
+			assert.Equal(t, "2025-01", ks.SigningKey().ID)
+
+			tokenStr, err := ks.Sign(jwt.MapClaims{"uid": 1})
+			assert.NoError(t, err)
+
+			token, err := jwt.Parse(tokenStr, ks.Keyfunc)
+			assert.NoError(t, err)
+			assert.True(t, token.Valid)
+			assert.Equal(t, "2025-01", token.Header["kid"])
+			assert.Equal(t, tc.method, token.Method.Alg())
+		})
+	}
+}
+
+func TestLoadDir_Rotation(t *testing.T) {
+	dir := t.TempDir()
+
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	_, newKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	old, err := loadDirFromKey(t, dir, "2025-01", oldKey)
+	assert.NoError(t, err)
+	oldToken, err := old.Sign(jwt.MapClaims{"uid": 1})
+	assert.NoError(t, err)
+
+	// Retire the old private key, keeping only its public half, and add a new active key.
+	assert.NoError(t, os.Remove(filepath.Join(dir, "2025-01"+privateKeySuffix)))
+	writePublicKey(t, dir, "2025-01", oldKey.Public())
+	writePrivateKey(t, dir, "2025-06", newKey)
+
+	ks, err := LoadDir(dir, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-06", ks.SigningKey().ID)
+	assert.Len(t, ks.Keys(), 2)
+
+	token, err := jwt.Parse(oldToken, ks.Keyfunc)
+	assert.NoError(t, err)
+	assert.True(t, token.Valid)
+
+	jwks := ks.JWKS()
+	assert.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "Ed25519", jwks.Keys[0].Crv)
+}
+
+func TestKeyfunc_RejectsUnknownKidAndAlgorithmMismatch(t *testing.T) {
+	ks, err := NewHMACKeySet("hmac", "test-secret")
+	assert.NoError(t, err)
+
+	unknown := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"uid": 1})
+	unknown.Header["kid"] = "missing"
+	tokenStr, err := unknown.SignedString([]byte("test-secret"))
+	assert.NoError(t, err)
+	_, err = jwt.Parse(tokenStr, ks.Keyfunc)
+	assert.Error(t, err)
+
+	mismatch := jwt.NewWithClaims(jwt.SigningMethodHS512, jwt.MapClaims{"uid": 1})
+	mismatch.Header["kid"] = "hmac"
+	tokenStr, err = mismatch.SignedString([]byte("test-secret"))
+	assert.NoError(t, err)
+	_, err = jwt.Parse(tokenStr, ks.Keyfunc)
+	assert.Error(t, err)
+
+	assert.Empty(t, ks.JWKS().Keys)
+}
+
+func loadDirFromKey(t *testing.T, dir, kid string, key crypto.PrivateKey) (*KeySet, error) {
+	writePrivateKey(t, dir, kid, key)
+	return LoadDir(dir, kid)
+}
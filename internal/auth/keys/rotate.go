@@ -0,0 +1,132 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// rsaBits is the size of generated RSA keys.
+const rsaBits = 3072
+
+// Generate creates a key pair for an asymmetric signing method: RS256, ES256 or EdDSA.
+func Generate(kid string, method string) (*Key, error) {
+	if kid == "" || strings.ContainsAny(kid, `/\`) || strings.HasPrefix(kid, ".") {
+		return nil, fmt.Errorf("keys: invalid kid %q", kid)
+	}
+
+	var private crypto.Signer
+	var err error
+	switch strings.ToUpper(method) {
+	case jwt.SigningMethodRS256.Alg():
+		private, err = rsa.GenerateKey(rand.Reader, rsaBits)
+	case jwt.SigningMethodES256.Alg():
+		private, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case strings.ToUpper(jwt.SigningMethodEdDSA.Alg()):
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("keys: cannot generate keys for %s, use RS256, ES256 or EdDSA", method)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := newKey(kid, private.Public())
+	if err != nil {
+		return nil, err
+	}
+	key.Private = private
+	return key, nil
+}
+
+// Write stores a key in dir as a PKCS#8 "<kid>.pem" private key and a PKIX "<kid>.pub.pem" public key.
+// Existing files are never overwritten.
+func Write(dir string, key *Key) error {
+	if key.Private == nil {
+		return errors.New("keys: only keys with a private part can be written")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	private, err := x509.MarshalPKCS8PrivateKey(key.Private)
+	if err != nil {
+		return err
+	}
+	if err := writePEM(filepath.Join(dir, key.ID+privateKeySuffix), "PRIVATE KEY", private, 0o600); err != nil {
+		return err
+	}
+	return writePublic(dir, key)
+}
+
+// Retire removes the private key of every kid in dir except keep, first writing its public key when missing,
+// so tokens signed before a rotation still verify until they expire while only keep can sign new ones.
+// Returns the retired kids.
+func Retire(dir string, keep string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("keys: read %s: %w", dir, err)
+	}
+
+	var retired []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, privateKeySuffix) || strings.HasSuffix(name, publicKeySuffix) {
+			continue
+		}
+		kid := strings.TrimSuffix(name, privateKeySuffix)
+		if kid == keep {
+			continue
+		}
+
+		file := filepath.Join(dir, name)
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return retired, err
+		}
+		key, err := ParsePrivateKey(kid, data)
+		if err != nil {
+			return retired, fmt.Errorf("keys: %s: %w", name, err)
+		}
+		if err := writePublic(dir, key); err != nil && !errors.Is(err, os.ErrExist) {
+			return retired, err
+		}
+		if err := os.Remove(file); err != nil {
+			return retired, err
+		}
+		retired = append(retired, kid)
+	}
+	return retired, nil
+}
+
+func writePublic(dir string, key *Key) error {
+	public, err := x509.MarshalPKIXPublicKey(key.Public)
+	if err != nil {
+		return err
+	}
+	return writePEM(filepath.Join(dir, key.ID+publicKeySuffix), "PUBLIC KEY", public, 0o644)
+}
+
+func writePEM(file string, blockType string, der []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if err := pem.Encode(f, &pem.Block{Type: blockType, Bytes: der}); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,55 @@
+package keys
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_Methods(t *testing.T) {
+	for _, method := range []string{"ES256", "EdDSA", "eddsa"} {
+		key, err := Generate("k1", method)
+		require.NoError(t, err, method)
+		assert.NotNil(t, key.Private)
+	}
+
+	_, err := Generate("k1", "HS256")
+	assert.Error(t, err)
+	_, err = Generate("../k1", "ES256")
+	assert.Error(t, err)
+}
+
+func TestWriteAndRetire(t *testing.T) {
+	dir := t.TempDir()
+
+	old, err := Generate("2025-01", "ES256")
+	require.NoError(t, err)
+	require.NoError(t, Write(dir, old))
+	// Keys written before this command existed may have no public half.
+	require.NoError(t, os.Remove(filepath.Join(dir, "2025-01"+publicKeySuffix)))
+	ks, err := LoadDir(dir, "")
+	require.NoError(t, err)
+	oldToken, err := ks.Sign(jwt.MapClaims{"uid": 1})
+	require.NoError(t, err)
+
+	current, err := Generate("2025-06", "ES256")
+	require.NoError(t, err)
+	require.NoError(t, Write(dir, current))
+	assert.Error(t, Write(dir, current), "existing keys are not overwritten")
+
+	retired, err := Retire(dir, "2025-06")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2025-01"}, retired)
+	assert.NoFileExists(t, filepath.Join(dir, "2025-01"+privateKeySuffix))
+
+	ks, err = LoadDir(dir, "")
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06", ks.SigningKey().ID)
+	token, err := jwt.Parse(oldToken, ks.Keyfunc)
+	assert.NoError(t, err)
+	assert.True(t, token.Valid)
+}
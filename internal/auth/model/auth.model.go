@@ -1,6 +1,10 @@
 package model
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
 
 type TokenClaims struct {
 	UUID string `json:"sub"`
@@ -8,3 +12,27 @@ type TokenClaims struct {
 	Role string `json:"role"`
 	jwt.RegisteredClaims
 }
+
+// RefreshToken is a server-side record of an opaque refresh token.
+// Only the SHA-256 hash of the token is stored. Tokens issued from the same login
+// share a FamilyID, so reusing a rotated token can revoke the whole chain.
+type RefreshToken struct {
+	ID         int64      `db:"id"`
+	UserID     int64      `db:"user_id"`
+	FamilyID   string     `db:"family_id"`
+	TokenHash  string     `db:"token_hash"`
+	ReplacedBy *int64     `db:"replaced_by"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// RevokedToken is an access token that was explicitly revoked before its expiry.
+// It is kept until ExpiresAt, after which the token would be rejected anyway.
+type RevokedToken struct {
+	JTI       string    `db:"jti"`
+	UserID    int64     `db:"user_id"`
+	ExpiresAt time.Time `db:"expires_at"`
+	RevokedBy int64     `db:"revoked_by"`
+	RevokedAt time.Time `db:"revoked_at"`
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/internal/auth/model"
+)
+
+//go:generate mockgen -source=auth.repository.go -package=mocks -destination=mocks/mock_auth_repository.go
+
+// Repository defines the interface for data access operations related to authentication,
+// such as persisting and rotating refresh tokens.
+type Repository interface {
+	// CreateRefreshToken inserts a new refresh token record into the data store.
+	// Param: token - a pointer to the RefreshToken model; ID and CreatedAt are filled on success.
+	// Returns an error if the insertion fails.
+	CreateRefreshToken(token *model.RefreshToken) error
+
+	// FindRefreshTokenByHash retrieves a refresh token by the hash of its opaque value.
+	// Param: hash - the hex encoded SHA-256 hash of the token.
+	// Returns a pointer to the RefreshToken and an error if it is not found or the query fails.
+	FindRefreshTokenByHash(hash string) (*model.RefreshToken, error)
+
+	// RevokeRefreshToken marks a still-active refresh token as revoked and links it to its replacement.
+	// Param: id - the ID of the token to revoke; replacedBy - the ID of the new token, if any.
+	// Returns true if the token was revoked by this call, false if it had already been revoked.
+	RevokeRefreshToken(id int64, replacedBy *int64) (bool, error)
+
+	// RevokeRefreshTokenFamily revokes every active refresh token that belongs to the given family.
+	// Param: familyID - the family shared by all tokens rotated from the same login.
+	// Returns an error if the update fails.
+	RevokeRefreshTokenFamily(familyID string) error
+
+	// RevokeUserRefreshTokens revokes every active refresh token issued to the given user.
+	// Param: userID - the ID of the user whose refresh tokens are revoked.
+	// Returns an error if the update fails.
+	RevokeUserRefreshTokens(userID int64) error
+
+	// CreateRevokedToken records an access token as revoked. Revoking the same token twice is a no-op.
+	// Param: token - a pointer to the RevokedToken model.
+	// Returns an error if the insertion fails.
+	CreateRevokedToken(token *model.RevokedToken) error
+
+	// IsTokenRevoked reports whether the access token with the given ID has been revoked.
+	// Param: jti - the JWT ID of the access token.
+	// Returns true if the token is revoked and an error if the query fails.
+	IsTokenRevoked(jti string) (bool, error)
+
+	// RevokeUserSessions invalidates every access token issued to the user up to now.
+	// Param: userID - the ID of the user; by - the ID of the user performing the revocation.
+	// Returns the cut-off time recorded for the user and an error if the upsert fails.
+	RevokeUserSessions(userID int64, by int64) (time.Time, error)
+
+	// FindSessionsRevokedBefore retrieves the cut-off time before which the user's access tokens are invalid.
+	// Param: userID - the ID of the user.
+	// Returns nil if the user's sessions were never revoked, and an error if the query fails.
+	FindSessionsRevokedBefore(userID int64) (*time.Time, error)
+}
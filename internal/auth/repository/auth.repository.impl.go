@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/auth/model"
+)
+
+type repository struct {
+	db *postgres.DB
+}
+
+func NewRepository(db *postgres.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) CreateRefreshToken(token *model.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, created_at
+	`
+	return r.db.QueryRowx(
+		query,
+		token.UserID,
+		token.FamilyID,
+		token.TokenHash,
+		token.ExpiresAt,
+	).Scan(&token.ID, &token.CreatedAt)
+}
+
+func (r *repository) FindRefreshTokenByHash(hash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	query := `
+		SELECT id, user_id, family_id, token_hash, replaced_by, expires_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+	err := r.db.Get(&token, query, hash)
+	if err != nil {
+		return nil, postgres.WrapError(err, "refresh token")
+	}
+	return &token, nil
+}
+
+func (r *repository) RevokeRefreshToken(id int64, replacedBy *int64) (bool, error) {
+	query := `
+		UPDATE refresh_tokens SET revoked_at = NOW(), replaced_by = $1 WHERE id = $2 AND revoked_at IS NULL
+	`
+	res, err := r.db.Exec(query, replacedBy, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *repository) RevokeRefreshTokenFamily(familyID string) error {
+	query := `
+		UPDATE refresh_tokens SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL
+	`
+	_, err := r.db.Exec(query, familyID)
+	return err
+}
+
+func (r *repository) RevokeUserRefreshTokens(userID int64) error {
+	query := `
+		UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL
+	`
+	_, err := r.db.Exec(query, userID)
+	return err
+}
+
+func (r *repository) CreateRevokedToken(token *model.RevokedToken) error {
+	query := `
+		INSERT INTO revoked_tokens (jti, user_id, expires_at, revoked_by, revoked_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (jti) DO NOTHING
+	`
+	_, err := r.db.Exec(
+		query,
+		token.JTI,
+		token.UserID,
+		token.ExpiresAt,
+		token.RevokedBy,
+	)
+	return err
+}
+
+func (r *repository) IsTokenRevoked(jti string) (bool, error) {
+	var revoked bool
+	query := `
+		SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $1)
+	`
+	err := r.db.Get(&revoked, query, jti)
+	return revoked, err
+}
+
+func (r *repository) RevokeUserSessions(userID int64, by int64) (time.Time, error) {
+	var revokedBefore time.Time
+	query := `
+		INSERT INTO user_session_revocations (user_id, revoked_before, revoked_by, updated_at)
+		VALUES ($1, NOW(), $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET revoked_before = NOW(), revoked_by = $2, updated_at = NOW()
+		RETURNING revoked_before
+	`
+	err := r.db.QueryRowx(query, userID, by).Scan(&revokedBefore)
+	return revokedBefore, err
+}
+
+func (r *repository) FindSessionsRevokedBefore(userID int64) (*time.Time, error) {
+	var revokedBefore time.Time
+	query := `
+		SELECT revoked_before FROM user_session_revocations WHERE user_id = $1
+	`
+	err := r.db.Get(&revokedBefore, query, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &revokedBefore, nil
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/auth/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupDBMock(t *testing.T) (*postgres.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	return postgres.NewDB(sqlx.NewDb(db, "postgres")), mock
+}
+
+func TestRepository_CreateRefreshToken(t *testing.T) {
+	db, mock := setupDBMock(t)
+	defer db.Close()
+
+	token := &model.RefreshToken{
+		UserID:    1,
+		FamilyID:  "family-1",
+		TokenHash: "hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	createdAt := time.Now()
+	mock.ExpectQuery("INSERT INTO refresh_tokens").
+		WithArgs(token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, createdAt))
+
+	repo := NewRepository(db)
+	err := repo.CreateRefreshToken(token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), token.ID)
+}
+
+func TestRepository_FindRefreshTokenByHash(t *testing.T) {
+	db, mock := setupDBMock(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`
+		SELECT id, user_id, family_id, token_hash, replaced_by, expires_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "family_id", "token_hash", "replaced_by", "expires_at", "revoked_at", "created_at",
+	}).AddRow(7, 1, "family-1", "hash", nil, now.Add(time.Hour), nil, now)
+
+	mock.ExpectQuery(query).WithArgs("hash").WillReturnRows(rows)
+
+	repo := NewRepository(db)
+	result, err := repo.FindRefreshTokenByHash("hash")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "family-1", result.FamilyID)
+	assert.Nil(t, result.RevokedAt)
+}
+
+func TestRepository_RevokeRefreshToken(t *testing.T) {
+	db, mock := setupDBMock(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`UPDATE refresh_tokens SET revoked_at = NOW(), replaced_by = $1 WHERE id = $2 AND revoked_at IS NULL`)
+
+	replacedBy := int64(8)
+	mock.ExpectExec(query).
+		WithArgs(&replacedBy, int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).
+		WithArgs(&replacedBy, int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewRepository(db)
+
+	revoked, err := repo.RevokeRefreshToken(7, &replacedBy)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = repo.RevokeRefreshToken(7, &replacedBy)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestRepository_RevokeRefreshTokenFamily(t *testing.T) {
+	db, mock := setupDBMock(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`UPDATE refresh_tokens SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL`)
+
+	mock.ExpectExec(query).
+		WithArgs("family-1").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	repo := NewRepository(db)
+	err := repo.RevokeRefreshTokenFamily("family-1")
+	assert.NoError(t, err)
+}
+
+func TestRepository_IsTokenRevoked(t *testing.T) {
+	db, mock := setupDBMock(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $1)`)
+
+	mock.ExpectQuery(query).
+		WithArgs("jti-1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	repo := NewRepository(db)
+	revoked, err := repo.IsTokenRevoked("jti-1")
+
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestRepository_RevokeUserSessions(t *testing.T) {
+	db, mock := setupDBMock(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("INSERT INTO user_session_revocations").
+		WithArgs(int64(3), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"revoked_before"}).AddRow(now))
+
+	repo := NewRepository(db)
+	revokedBefore, err := repo.RevokeUserSessions(3, 1)
+
+	assert.NoError(t, err)
+	assert.WithinDuration(t, now, revokedBefore, time.Second)
+}
+
+func TestRepository_FindSessionsRevokedBefore_NotRevoked(t *testing.T) {
+	db, mock := setupDBMock(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`SELECT revoked_before FROM user_session_revocations WHERE user_id = $1`)
+
+	mock.ExpectQuery(query).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"revoked_before"}))
+
+	repo := NewRepository(db)
+	revokedBefore, err := repo.FindSessionsRevokedBefore(3)
+
+	assert.NoError(t, err)
+	assert.Nil(t, revokedBefore)
+}
@@ -0,0 +1,116 @@
+package revocation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dwilanang/psp/internal/auth/model"
+	mockrepo "github.com/dwilanang/psp/internal/auth/repository/mocks"
+)
+
+func newClaims(jti string, issuedAt time.Time) *model.TokenClaims {
+	return &model.TokenClaims{
+		ID: 1,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(time.Hour)),
+		},
+	}
+}
+
+func TestStore_IsRevoked_CachesLookups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	store := NewStore(mockRepo, time.Minute)
+
+	claims := newClaims("jti-1", time.Now())
+
+	mockRepo.EXPECT().IsTokenRevoked("jti-1").Return(false, nil).Times(1)
+	mockRepo.EXPECT().FindSessionsRevokedBefore(int64(1)).Return(nil, nil).Times(1)
+
+	for i := 0; i < 3; i++ {
+		revoked, err := store.IsRevoked(claims)
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+	}
+}
+
+func TestStore_RevokeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	store := NewStore(mockRepo, time.Minute)
+
+	claims := newClaims("jti-1", time.Now())
+
+	mockRepo.EXPECT().
+		CreateRevokedToken(gomock.AssignableToTypeOf(&model.RevokedToken{})).
+		DoAndReturn(func(token *model.RevokedToken) error {
+			assert.Equal(t, "jti-1", token.JTI)
+			assert.Equal(t, int64(1), token.UserID)
+			return nil
+		})
+
+	assert.NoError(t, store.RevokeToken(claims, 1))
+
+	revoked, err := store.IsRevoked(claims)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestStore_RevokeUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	store := NewStore(mockRepo, time.Minute)
+
+	revokedBefore := time.Now()
+	mockRepo.EXPECT().RevokeUserSessions(int64(1), int64(2)).Return(revokedBefore, nil)
+	assert.NoError(t, store.RevokeUser(1, 2))
+
+	oldClaims := newClaims("jti-old", revokedBefore.Add(-time.Hour))
+	mockRepo.EXPECT().IsTokenRevoked("jti-old").Return(false, nil)
+	revoked, err := store.IsRevoked(oldClaims)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	newClaims := newClaims("jti-new", revokedBefore.Add(time.Hour))
+	mockRepo.EXPECT().IsTokenRevoked("jti-new").Return(false, nil)
+	revoked, err = store.IsRevoked(newClaims)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestStore_RevokeUser_SameSecond(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	store := NewStore(mockRepo, time.Minute)
+
+	revokedBefore := time.Date(2025, 6, 14, 9, 0, 0, 750000000, time.UTC)
+	mockRepo.EXPECT().RevokeUserSessions(int64(1), int64(2)).Return(revokedBefore, nil)
+	assert.NoError(t, store.RevokeUser(1, 2))
+
+	// A login later in the second of the revocation carries an iat truncated to that second.
+	sameSecond := newClaims("jti-same", time.Date(2025, 6, 14, 9, 0, 0, 0, time.UTC))
+	mockRepo.EXPECT().IsTokenRevoked("jti-same").Return(false, nil)
+	revoked, err := store.IsRevoked(sameSecond)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	previousSecond := newClaims("jti-previous", time.Date(2025, 6, 14, 8, 59, 59, 0, time.UTC))
+	mockRepo.EXPECT().IsTokenRevoked("jti-previous").Return(false, nil)
+	revoked, err = store.IsRevoked(previousSecond)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
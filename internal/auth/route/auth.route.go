@@ -1,6 +1,7 @@
 package route
 
 import (
+	"github.com/dwilanang/psp/internal/permission"
 	"github.com/dwilanang/psp/internal/registry"
 	"github.com/gin-gonic/gin"
 )
@@ -11,5 +12,21 @@ func RegisterRoutes(rg *gin.RouterGroup, registry *registry.Registry) {
 	authGroup := rg.Group("/auth")
 	{
 		authGroup.POST("/login", h.Login)
+		authGroup.POST("/refresh", h.Refresh)
 	}
+
+	// Session management requires an authenticated caller.
+	sessionGroup := rg.Group("/auth", registry.NewAuthMiddleware())
+	{
+		sessionGroup.POST("/logout", h.Logout)
+		sessionGroup.POST("/sessions/revoke/:id", registry.RequirePermission(permission.SessionsRevoke), h.RevokeSessions)
+	}
+}
+
+// RegisterWellKnownRoutes registers the unversioned discovery endpoints, such as the JWKS used by
+// other services to verify access tokens.
+func RegisterWellKnownRoutes(r gin.IRoutes, registry *registry.Registry) {
+	h := registry.NewAuthHandler()
+
+	r.GET("/.well-known/jwks.json", h.JWKS)
 }
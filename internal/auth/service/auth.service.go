@@ -2,9 +2,33 @@ package service
 
 import (
 	"github.com/dwilanang/psp/internal/auth/dto"
-	"github.com/dwilanang/psp/utils/response"
+	"github.com/dwilanang/psp/internal/auth/keys"
+	"github.com/dwilanang/psp/internal/auth/model"
 )
 
+// Service defines the interface for authentication business logic.
 type Service interface {
-	Login(request *dto.AuthRequest) (response.ApiResponse, error)
+	// Login verifies the user's credentials and issues an access token and a refresh token.
+	// Param: request - a pointer to AuthRequest DTO containing the credentials.
+	// Returns the issued AuthResponse and an error if authentication fails.
+	Login(request *dto.AuthRequest) (dto.AuthResponse, error)
+
+	// Refresh exchanges a valid refresh token for a new token pair and revokes the presented token.
+	// Presenting a token that was already rotated revokes every token in its family.
+	// Param: request - a pointer to RefreshRequest DTO containing the refresh token.
+	// Returns the issued AuthResponse and an error if the token is invalid.
+	Refresh(request *dto.RefreshRequest) (dto.AuthResponse, error)
+
+	// Logout revokes the access token described by claims and, when given, the refresh token family it was issued with.
+	// Param: claims - the claims of the authenticated access token; request - a pointer to LogoutRequest DTO.
+	// Returns an error if the revocation fails.
+	Logout(claims *model.TokenClaims, request *dto.LogoutRequest) error
+
+	// RevokeSessions revokes every access and refresh token issued to a user so far.
+	// Param: userID - the ID of the user whose sessions are revoked; by - the ID of the acting administrator.
+	// Returns an error if the revocation fails.
+	RevokeSessions(userID int64, by int64) error
+
+	// JWKS returns the public keys that verify tokens issued by Login and Refresh.
+	JWKS() keys.JWKS
 }
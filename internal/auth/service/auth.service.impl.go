@@ -1,73 +1,224 @@
 package service
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/dwilanang/psp/config"
 	"github.com/dwilanang/psp/internal/auth/dto"
+	"github.com/dwilanang/psp/internal/auth/keys"
+	"github.com/dwilanang/psp/internal/auth/model"
+	authrepository "github.com/dwilanang/psp/internal/auth/repository"
+	"github.com/dwilanang/psp/internal/auth/revocation"
+	usermodel "github.com/dwilanang/psp/internal/user/model"
 	userrepository "github.com/dwilanang/psp/internal/user/repository"
+	"github.com/dwilanang/psp/pkg/apperror"
 	"github.com/dwilanang/psp/utils"
-	"github.com/dwilanang/psp/utils/response"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	ErrInvalidRefreshToken = apperror.Unauthorized("invalid refresh token")
+
+	// ErrInvalidCredentials is returned when the username is unknown or the password does not match;
+	// the two cases are not told apart.
+	ErrInvalidCredentials = apperror.Unauthorized("Login failed")
+)
+
 type service struct {
-	cfg      *config.Config
-	userRepo userrepository.Repository
+	cfg         *config.Config
+	keys        *keys.KeySet
+	repo        authrepository.Repository
+	userRepo    userrepository.Repository
+	revocations revocation.Store
 }
 
-func NewService(cfg *config.Config, repo userrepository.Repository) Service {
+func NewService(cfg *config.Config, keys *keys.KeySet, repo authrepository.Repository, userRepo userrepository.Repository, revocations revocation.Store) Service {
 	return &service{
-		cfg:      cfg,
-		userRepo: repo,
+		cfg:         cfg,
+		keys:        keys,
+		repo:        repo,
+		userRepo:    userRepo,
+		revocations: revocations,
 	}
 }
 
-func (s *service) Login(request *dto.AuthRequest) (response.ApiResponse, error) {
+func (s *service) Login(request *dto.AuthRequest) (dto.AuthResponse, error) {
 	u, err := s.userRepo.FindByUsername(request.Username)
 	if err != nil {
-		if err.Error() == "failed" {
-			return response.ApiResponse{}, errors.New("Login failed")
+		if apperror.IsNotFound(err) {
+			return dto.AuthResponse{}, ErrInvalidCredentials
 		}
-		return response.ApiResponse{}, err
+		return dto.AuthResponse{}, err
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(request.Password))
 	if err != nil {
-		fmt.Println("Login: CompareHashAndPassword ", err)
-		return response.ApiResponse{}, errors.New("Login failed")
+		return dto.AuthResponse{}, ErrInvalidCredentials
+	}
+
+	resp, _, err := s.issue(u, uuid.New().String())
+	if err != nil {
+		return dto.AuthResponse{}, err
+	}
+
+	return resp, nil
+}
+
+// Refresh implements the Service interface.
+func (s *service) Refresh(request *dto.RefreshRequest) (dto.AuthResponse, error) {
+	current, err := s.repo.FindRefreshTokenByHash(hashToken(request.RefreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dto.AuthResponse{}, ErrInvalidRefreshToken
+		}
+		return dto.AuthResponse{}, err
 	}
 
+	// A revoked token being presented again means it was stolen or replayed,
+	// so every token derived from the same login is revoked.
+	if current.RevokedAt != nil {
+		if err := s.repo.RevokeRefreshTokenFamily(current.FamilyID); err != nil {
+			return dto.AuthResponse{}, err
+		}
+		return dto.AuthResponse{}, ErrInvalidRefreshToken
+	}
+
+	if time.Now().After(current.ExpiresAt) {
+		return dto.AuthResponse{}, ErrInvalidRefreshToken
+	}
+
+	u, err := s.userRepo.FindByID(current.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dto.AuthResponse{}, ErrInvalidRefreshToken
+		}
+		return dto.AuthResponse{}, err
+	}
+
+	resp, next, err := s.issue(u, current.FamilyID)
+	if err != nil {
+		return dto.AuthResponse{}, err
+	}
+
+	// Only one caller can rotate a given token; a concurrent loser is treated as reuse.
+	rotated, err := s.repo.RevokeRefreshToken(current.ID, &next.ID)
+	if err != nil {
+		return dto.AuthResponse{}, err
+	}
+	if !rotated {
+		if err := s.repo.RevokeRefreshTokenFamily(current.FamilyID); err != nil {
+			return dto.AuthResponse{}, err
+		}
+		return dto.AuthResponse{}, ErrInvalidRefreshToken
+	}
+
+	return resp, nil
+}
+
+// Logout implements the Service interface.
+func (s *service) Logout(claims *model.TokenClaims, request *dto.LogoutRequest) error {
+	if err := s.revocations.RevokeToken(claims, claims.ID); err != nil {
+		return err
+	}
+
+	if request.RefreshToken == "" {
+		return nil
+	}
+
+	refresh, err := s.repo.FindRefreshTokenByHash(hashToken(request.RefreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	// Never let one user log out another user's refresh tokens.
+	if refresh.UserID != claims.ID {
+		return nil
+	}
+
+	return s.repo.RevokeRefreshTokenFamily(refresh.FamilyID)
+}
+
+// RevokeSessions implements the Service interface.
+func (s *service) RevokeSessions(userID int64, by int64) error {
+	if err := s.repo.RevokeUserRefreshTokens(userID); err != nil {
+		return err
+	}
+
+	return s.revocations.RevokeUser(userID, by)
+}
+
+// JWKS implements the Service interface.
+func (s *service) JWKS() keys.JWKS {
+	return s.keys.JWKS()
+}
+
+// issue signs a new access token for the user and stores a new refresh token in the given family.
+func (s *service) issue(u *usermodel.User, familyID string) (dto.AuthResponse, *model.RefreshToken, error) {
+	now := time.Now()
 	jwtExpiration := utils.ConvertStringToInt(s.cfg.JWTExpiration)
+	expiresAt := now.Add(time.Duration(jwtExpiration) * time.Hour)
 
 	claims := jwt.MapClaims{
+		"jti":  uuid.New().String(),
 		"uid":  u.ID,
 		"sub":  u.UUID,
 		"role": u.Role,
-		"exp":  jwt.NewNumericDate(time.Now().Add(time.Duration(jwtExpiration) * time.Hour)),
+		"iat":  jwt.NewNumericDate(now),
+		"exp":  jwt.NewNumericDate(expiresAt),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	secret := s.cfg.JWTSecret
-	if secret == "" {
-		secret = "secret"
+	tokenStr, err := s.keys.Sign(claims)
+	if err != nil {
+		return dto.AuthResponse{}, nil, err
 	}
 
-	tokenStr, err := token.SignedString([]byte(secret))
+	refreshStr, err := newRefreshToken()
 	if err != nil {
-		return response.ApiResponse{}, err
-	}
-
-	return response.ApiResponse{
-		Status:  true,
-		Message: "Login succefully",
-		Data: dto.AuthResponse{
-			Type:   s.cfg.JWTType,
-			Token:  tokenStr,
-			Expire: fmt.Sprintf("%d Hour", jwtExpiration),
-		},
-	}, nil // or an error if authentication fails
+		return dto.AuthResponse{}, nil, err
+	}
+
+	refreshExpiration := utils.ConvertStringToInt(s.cfg.JWTRefreshExpiration)
+	refresh := &model.RefreshToken{
+		UserID:    u.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshStr),
+		ExpiresAt: now.Add(time.Duration(refreshExpiration) * time.Hour),
+	}
+	if err := s.repo.CreateRefreshToken(refresh); err != nil {
+		return dto.AuthResponse{}, nil, err
+	}
+
+	return dto.AuthResponse{
+		Type:             s.cfg.JWTType,
+		Token:            tokenStr,
+		Expire:           fmt.Sprintf("%d Hour", jwtExpiration),
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshStr,
+		RefreshExpiresAt: refresh.ExpiresAt,
+	}, refresh, nil
+}
+
+// newRefreshToken returns an opaque, URL-safe random token.
+func newRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
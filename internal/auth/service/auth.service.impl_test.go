@@ -0,0 +1,181 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/dwilanang/psp/config"
+	"github.com/dwilanang/psp/internal/auth/dto"
+	"github.com/dwilanang/psp/internal/auth/keys"
+	"github.com/dwilanang/psp/internal/auth/model"
+	mockauthrepo "github.com/dwilanang/psp/internal/auth/repository/mocks"
+	mockrevocation "github.com/dwilanang/psp/internal/auth/revocation/mocks"
+	usermodel "github.com/dwilanang/psp/internal/user/model"
+	mockuserrepo "github.com/dwilanang/psp/internal/user/repository/mocks"
+)
+
+func testConfig() *config.Config {
+	return &config.Config{
+		JWTSecret:            "test-secret",
+		JWTExpiration:        "1",
+		JWTType:              "bearer",
+		JWTRefreshExpiration: "24",
+	}
+}
+
+func testKeys(t *testing.T) *keys.KeySet {
+	ks, err := keys.NewHMACKeySet("", "test-secret")
+	assert.NoError(t, err)
+	return ks
+}
+
+func TestService_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockauthrepo.NewMockRepository(ctrl)
+	mockUserRepo := mockuserrepo.NewMockRepository(ctrl)
+	svc := NewService(testConfig(), testKeys(t), mockRepo, mockUserRepo, nil)
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	mockUserRepo.EXPECT().FindByUsername("johndoe").Return(&usermodel.User{
+		ID: 1, UUID: "uuid-123", PasswordHash: string(hashed), Role: "ADMIN",
+	}, nil)
+	mockRepo.EXPECT().
+		CreateRefreshToken(gomock.AssignableToTypeOf(&model.RefreshToken{})).
+		DoAndReturn(func(token *model.RefreshToken) error {
+			assert.Equal(t, int64(1), token.UserID)
+			assert.NotEmpty(t, token.FamilyID)
+			assert.Len(t, token.TokenHash, 64)
+			token.ID = 1
+			return nil
+		})
+
+	resp, err := svc.Login(&dto.AuthRequest{Username: "johndoe", Password: "secret123"})
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, resp.Token)
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.True(t, resp.RefreshExpiresAt.After(resp.ExpiresAt))
+}
+
+func TestService_Refresh_Rotates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockauthrepo.NewMockRepository(ctrl)
+	mockUserRepo := mockuserrepo.NewMockRepository(ctrl)
+	svc := NewService(testConfig(), testKeys(t), mockRepo, mockUserRepo, nil)
+
+	current := &model.RefreshToken{ID: 5, UserID: 1, FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour)}
+	mockRepo.EXPECT().FindRefreshTokenByHash(hashToken("old-token")).Return(current, nil)
+	mockUserRepo.EXPECT().FindByID(int64(1)).Return(&usermodel.User{ID: 1, UUID: "uuid-123", Role: "ADMIN"}, nil)
+	mockRepo.EXPECT().
+		CreateRefreshToken(gomock.AssignableToTypeOf(&model.RefreshToken{})).
+		DoAndReturn(func(token *model.RefreshToken) error {
+			assert.Equal(t, "family-1", token.FamilyID)
+			token.ID = 6
+			return nil
+		})
+	mockRepo.EXPECT().RevokeRefreshToken(int64(5), gomock.Any()).
+		DoAndReturn(func(id int64, replacedBy *int64) (bool, error) {
+			assert.Equal(t, int64(6), *replacedBy)
+			return true, nil
+		})
+
+	resp, err := svc.Refresh(&dto.RefreshRequest{RefreshToken: "old-token"})
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, "old-token", resp.RefreshToken)
+}
+
+func TestService_Refresh_ReuseRevokesFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockauthrepo.NewMockRepository(ctrl)
+	mockUserRepo := mockuserrepo.NewMockRepository(ctrl)
+	svc := NewService(testConfig(), testKeys(t), mockRepo, mockUserRepo, nil)
+
+	revokedAt := time.Now().Add(-time.Minute)
+	current := &model.RefreshToken{ID: 5, UserID: 1, FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}
+	mockRepo.EXPECT().FindRefreshTokenByHash(hashToken("old-token")).Return(current, nil)
+	mockRepo.EXPECT().RevokeRefreshTokenFamily("family-1").Return(nil)
+
+	_, err := svc.Refresh(&dto.RefreshRequest{RefreshToken: "old-token"})
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestService_Refresh_Expired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockauthrepo.NewMockRepository(ctrl)
+	mockUserRepo := mockuserrepo.NewMockRepository(ctrl)
+	svc := NewService(testConfig(), testKeys(t), mockRepo, mockUserRepo, nil)
+
+	current := &model.RefreshToken{ID: 5, UserID: 1, FamilyID: "family-1", ExpiresAt: time.Now().Add(-time.Hour)}
+	mockRepo.EXPECT().FindRefreshTokenByHash(hashToken("old-token")).Return(current, nil)
+
+	_, err := svc.Refresh(&dto.RefreshRequest{RefreshToken: "old-token"})
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestService_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockauthrepo.NewMockRepository(ctrl)
+	mockUserRepo := mockuserrepo.NewMockRepository(ctrl)
+	mockStore := mockrevocation.NewMockStore(ctrl)
+	svc := NewService(testConfig(), testKeys(t), mockRepo, mockUserRepo, mockStore)
+
+	claims := &model.TokenClaims{ID: 1}
+
+	mockStore.EXPECT().RevokeToken(claims, int64(1)).Return(nil)
+	mockRepo.EXPECT().FindRefreshTokenByHash(hashToken("refresh-token")).
+		Return(&model.RefreshToken{ID: 5, UserID: 1, FamilyID: "family-1"}, nil)
+	mockRepo.EXPECT().RevokeRefreshTokenFamily("family-1").Return(nil)
+
+	err := svc.Logout(claims, &dto.LogoutRequest{RefreshToken: "refresh-token"})
+	assert.NoError(t, err)
+}
+
+func TestService_Logout_IgnoresForeignRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockauthrepo.NewMockRepository(ctrl)
+	mockUserRepo := mockuserrepo.NewMockRepository(ctrl)
+	mockStore := mockrevocation.NewMockStore(ctrl)
+	svc := NewService(testConfig(), testKeys(t), mockRepo, mockUserRepo, mockStore)
+
+	claims := &model.TokenClaims{ID: 1}
+
+	mockStore.EXPECT().RevokeToken(claims, int64(1)).Return(nil)
+	mockRepo.EXPECT().FindRefreshTokenByHash(hashToken("refresh-token")).
+		Return(&model.RefreshToken{ID: 5, UserID: 2, FamilyID: "family-2"}, nil)
+
+	err := svc.Logout(claims, &dto.LogoutRequest{RefreshToken: "refresh-token"})
+	assert.NoError(t, err)
+}
+
+func TestService_RevokeSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockauthrepo.NewMockRepository(ctrl)
+	mockUserRepo := mockuserrepo.NewMockRepository(ctrl)
+	mockStore := mockrevocation.NewMockStore(ctrl)
+	svc := NewService(testConfig(), testKeys(t), mockRepo, mockUserRepo, mockStore)
+
+	mockRepo.EXPECT().RevokeUserRefreshTokens(int64(3)).Return(nil)
+	mockStore.EXPECT().RevokeUser(int64(3), int64(1)).Return(nil)
+
+	err := svc.RevokeSessions(3, 1)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,22 @@
+package util
+
+import (
+	auditmodel "github.com/dwilanang/psp/internal/audit/model"
+	"github.com/dwilanang/psp/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// GetActor returns who is making the request, as recorded in the audit log: the signed-in user, the IP
+// address and the request ID set by the request logger.
+func GetActor(c *gin.Context) (auditmodel.Actor, error) {
+	id, err := GetClaimsID(c)
+	if err != nil {
+		return auditmodel.Actor{}, err
+	}
+
+	return auditmodel.Actor{
+		UserID:    id,
+		IPAddress: logger.GetIPAddress(c),
+		RequestID: logger.GetRequestID(c),
+	}, nil
+}
@@ -20,3 +20,17 @@ func GetClaimsID(c *gin.Context) (int64, error) {
 
 	return id, nil
 }
+
+func GetClaims(c *gin.Context) (*model.TokenClaims, error) {
+	val, exists := c.Get("user")
+	if !exists {
+		return nil, errors.New("error: Missing token claims")
+	}
+
+	claims, ok := val.(*model.TokenClaims)
+	if !ok {
+		return nil, errors.New("error: Invalid token claims")
+	}
+
+	return claims, nil
+}
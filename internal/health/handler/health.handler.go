@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dwilanang/psp/internal/health"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	Checker *health.Checker
+}
+
+func NewHandler(checker *health.Checker) *Handler {
+	return &Handler{
+		Checker: checker,
+	}
+}
+
+// Liveness godoc
+// @Summary      Liveness probe
+// @Description  Reports that the process is up; it does not check any dependency
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  map[string]string
+// @Router       /healthz [get]
+func (h *Handler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readiness godoc
+// @Summary      Readiness probe
+// @Description  Pings every master and slave database and checks for pending migrations; a slave being down does not fail the probe.
+// @Description  Responds 503 when the master is down, migrations are pending or the server is shutting down.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  health.Report
+// @Failure      503  {object}  health.Report
+// @Router       /readyz [get]
+func (h *Handler) Readiness(c *gin.Context) {
+	report := h.Checker.Readiness(c.Request.Context())
+
+	c.Header("Cache-Control", "no-store")
+	if !report.Ready() {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
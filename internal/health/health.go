@@ -0,0 +1,108 @@
+// Package health reports whether the process is alive and whether it is ready to serve traffic.
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Check statuses.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Report statuses.
+const (
+	StatusReady        = "ready"
+	StatusUnavailable  = "unavailable"
+	StatusShuttingDown = "shutting_down"
+)
+
+// DatabasePinger pings every database instance within timeout, keyed by instance.
+// It is satisfied by postgres.Ping. Replicas, keyed "<name>/slave/<n>", are reported but do not fail
+// the readiness check: reads fall back to the master while a replica is down.
+type DatabasePinger func(ctx context.Context, timeout time.Duration) map[string]error
+
+// MigrationCounter counts the migrations not applied yet.
+// It is satisfied by the migrator.
+type MigrationCounter interface {
+	Pending(ctx context.Context) (int, error)
+}
+
+// Check is the status of one dependency.
+type Check struct {
+	Status string `json:"status" example:"up"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the readiness of the process with the status of every dependency, such as "postgres/main/master".
+type Report struct {
+	Status string           `json:"status" example:"ready"`
+	Checks map[string]Check `json:"checks"`
+}
+
+// Ready reports whether the process should receive traffic.
+func (r Report) Ready() bool {
+	return r.Status == StatusReady
+}
+
+// Checker checks the dependencies the process needs to serve requests.
+type Checker struct {
+	databases    DatabasePinger
+	migrations   MigrationCounter
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+// NewChecker creates a Checker pinging databases and counting pending migrations, each within timeout.
+// migrations may be nil to skip the migration check.
+func NewChecker(databases DatabasePinger, migrations MigrationCounter, timeout time.Duration) *Checker {
+	return &Checker{databases: databases, migrations: migrations, timeout: timeout}
+}
+
+// SetShuttingDown makes every following readiness check fail, so load balancers stop sending traffic
+// while in-flight requests drain.
+func (c *Checker) SetShuttingDown() {
+	c.shuttingDown.Store(true)
+}
+
+// Readiness checks every dependency. The process is ready when every check is up and it is not shutting down.
+func (c *Checker) Readiness(ctx context.Context) Report {
+	report := Report{Status: StatusReady, Checks: map[string]Check{}}
+
+	for instance, err := range c.databases(ctx, c.timeout) {
+		report.add("postgres/"+instance, err, !strings.Contains(instance, "/slave/"))
+	}
+
+	if c.migrations != nil {
+		migrationCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+
+		pending, err := c.migrations.Pending(migrationCtx)
+		if err == nil && pending > 0 {
+			err = fmt.Errorf("%d migrations pending", pending)
+		}
+		report.add("migrations", err, true)
+	}
+
+	if c.shuttingDown.Load() {
+		report.Status = StatusShuttingDown
+	}
+	return report
+}
+
+// add records a check; a failing critical check makes the process unavailable.
+func (r *Report) add(name string, err error, critical bool) {
+	if err == nil {
+		r.Checks[name] = Check{Status: StatusUp}
+		return
+	}
+	r.Checks[name] = Check{Status: StatusDown, Error: err.Error()}
+	if critical {
+		r.Status = StatusUnavailable
+	}
+}
@@ -0,0 +1,83 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMigrations struct {
+	pending int
+	err     error
+}
+
+func (f fakeMigrations) Pending(ctx context.Context) (int, error) {
+	return f.pending, f.err
+}
+
+func databases(results map[string]error) DatabasePinger {
+	return func(ctx context.Context, timeout time.Duration) map[string]error {
+		return results
+	}
+}
+
+func TestReadiness(t *testing.T) {
+	checker := NewChecker(databases(map[string]error{"main/master": nil, "main/slave/1": nil}), fakeMigrations{}, time.Second)
+
+	report := checker.Readiness(context.Background())
+
+	assert.True(t, report.Ready())
+	assert.Equal(t, map[string]Check{
+		"postgres/main/master":  {Status: StatusUp},
+		"postgres/main/slave/1": {Status: StatusUp},
+		"migrations":            {Status: StatusUp},
+	}, report.Checks)
+}
+
+func TestReadiness_DependencyDown(t *testing.T) {
+	checker := NewChecker(databases(map[string]error{"main/master": context.DeadlineExceeded, "main/slave/1": nil}), fakeMigrations{}, time.Second)
+
+	report := checker.Readiness(context.Background())
+
+	assert.False(t, report.Ready())
+	assert.Equal(t, StatusUnavailable, report.Status)
+	assert.Equal(t, Check{Status: StatusDown, Error: "context deadline exceeded"}, report.Checks["postgres/main/master"])
+	assert.Equal(t, StatusUp, report.Checks["postgres/main/slave/1"].Status)
+}
+
+func TestReadiness_ReplicaDown(t *testing.T) {
+	checker := NewChecker(databases(map[string]error{"main/master": nil, "main/slave/1": context.DeadlineExceeded}), fakeMigrations{}, time.Second)
+
+	report := checker.Readiness(context.Background())
+
+	// Reads fall back to the master, so the process stays ready.
+	assert.True(t, report.Ready())
+	assert.Equal(t, StatusDown, report.Checks["postgres/main/slave/1"].Status)
+}
+
+func TestReadiness_Migrations(t *testing.T) {
+	checker := NewChecker(databases(nil), fakeMigrations{pending: 2}, time.Second)
+	report := checker.Readiness(context.Background())
+	assert.False(t, report.Ready())
+	assert.Equal(t, Check{Status: StatusDown, Error: "2 migrations pending"}, report.Checks["migrations"])
+
+	checker = NewChecker(databases(nil), fakeMigrations{err: errors.New("relation does not exist")}, time.Second)
+	report = checker.Readiness(context.Background())
+	assert.False(t, report.Ready())
+	assert.Equal(t, StatusDown, report.Checks["migrations"].Status)
+}
+
+func TestReadiness_ShuttingDown(t *testing.T) {
+	checker := NewChecker(databases(map[string]error{"main/master": nil}), nil, time.Second)
+	assert.True(t, checker.Readiness(context.Background()).Ready())
+
+	checker.SetShuttingDown()
+
+	report := checker.Readiness(context.Background())
+	assert.False(t, report.Ready())
+	assert.Equal(t, StatusShuttingDown, report.Status)
+	assert.Equal(t, StatusUp, report.Checks["postgres/main/master"].Status)
+}
@@ -0,0 +1,14 @@
+package route
+
+import (
+	"github.com/dwilanang/psp/internal/registry"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes registers the unversioned, unauthenticated liveness and readiness probes.
+func RegisterRoutes(r gin.IRoutes, registry *registry.Registry) {
+	h := registry.NewHealthHandler()
+
+	r.GET("/healthz", h.Liveness)
+	r.GET("/readyz", h.Readiness)
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+type LeaveRequest struct {
+	LeaveTypeID int64  `json:"leave_type_id" binding:"required"`
+	StartDate   string `json:"start_date" binding:"required" example:"2025-01-20"`
+	EndDate     string `json:"end_date" binding:"required" example:"2025-01-22"`
+	Reason      string `json:"reason" binding:"required" example:"Family event"`
+	By          int64  `json:"by" swaggerignore:"true"`
+}
+
+type LeaveFilterRequest struct {
+	UserID      int64  `form:"user_id"`
+	LeaveTypeID int64  `form:"leave_type_id"`
+	Status      string `form:"status" binding:"omitempty,oneof=pending approved rejected cancelled"`
+	Period      string `form:"period" example:"2025-01"`
+}
+
+type LeaveReviewRequest struct {
+	ID   int64  `json:"id" swaggerignore:"true"`
+	Note string `json:"note"`
+	By   int64  `json:"by" swaggerignore:"true"`
+}
+
+type LeaveTypeRequest struct {
+	ID              int64           `json:"id" swaggerignore:"true"`
+	Name            string          `json:"name" binding:"required" example:"Annual Leave"`
+	YearlyAllowance decimal.Decimal `json:"yearly_allowance" swaggertype:"string" example:"12"`
+	Accrual         string          `json:"accrual" binding:"required,oneof=yearly monthly" example:"monthly"`
+	MaxCarryOver    decimal.Decimal `json:"max_carry_over" swaggertype:"string" example:"5"`
+	IsActive        bool            `json:"is_active" example:"true"`
+	By              int64           `json:"by" swaggerignore:"true"`
+}
+
+type BalanceRequest struct {
+	Year int `form:"year" example:"2025"`
+}
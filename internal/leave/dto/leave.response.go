@@ -0,0 +1,73 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+type LeaveResponse struct {
+	Data LeaveData `json:"data"`
+}
+
+type LeaveListResponse struct {
+	Data []LeaveData `json:"data"`
+}
+
+type LeaveData struct {
+	ID          int64            `json:"id"`
+	UserID      int64            `json:"user_id"`
+	FullName    string           `json:"full_name"`
+	LeaveTypeID int64            `json:"leave_type_id"`
+	TypeCode    string           `json:"type_code" example:"annual"`
+	TypeName    string           `json:"type_name" example:"Annual Leave"`
+	StartDate   string           `json:"start_date" example:"2025-01-20"`
+	EndDate     string           `json:"end_date" example:"2025-01-22"`
+	Days        int              `json:"days" example:"3"`
+	Reason      string           `json:"reason"`
+	Status      string           `json:"status" example:"pending"`
+	ReviewNote  string           `json:"review_note"`
+	Transitions []TransitionData `json:"transitions,omitempty"`
+}
+
+type TransitionData struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Note       string `json:"note"`
+	By         int64  `json:"by"`
+	ByName     string `json:"by_name"`
+	At         string `json:"at"`
+}
+
+type LeaveTypeResponse struct {
+	Data LeaveTypeData `json:"data"`
+}
+
+type LeaveTypeListResponse struct {
+	Data []LeaveTypeData `json:"data"`
+}
+
+type LeaveTypeData struct {
+	ID              int64           `json:"id"`
+	Code            string          `json:"code" example:"annual"`
+	Name            string          `json:"name" example:"Annual Leave"`
+	IsPaid          bool            `json:"is_paid"`
+	YearlyAllowance decimal.Decimal `json:"yearly_allowance" swaggertype:"string" example:"12"`
+	Accrual         string          `json:"accrual" example:"monthly"`
+	MaxCarryOver    decimal.Decimal `json:"max_carry_over" swaggertype:"string" example:"5"`
+	IsActive        bool            `json:"is_active"`
+}
+
+type BalanceListResponse struct {
+	Data []BalanceData `json:"data"`
+}
+
+// BalanceData is the balance of a paid leave type; Available is what may still be requested.
+type BalanceData struct {
+	LeaveTypeID int64           `json:"leave_type_id"`
+	TypeCode    string          `json:"type_code" example:"annual"`
+	TypeName    string          `json:"type_name" example:"Annual Leave"`
+	Year        int             `json:"year" example:"2025"`
+	Entitled    decimal.Decimal `json:"entitled" swaggertype:"string" example:"12"`
+	Accrued     decimal.Decimal `json:"accrued" swaggertype:"string" example:"3"`
+	CarriedOver decimal.Decimal `json:"carried_over" swaggertype:"string" example:"2"`
+	Used        int             `json:"used" example:"1"`
+	Pending     int             `json:"pending" example:"0"`
+	Available   decimal.Decimal `json:"available" swaggertype:"string" example:"4"`
+}
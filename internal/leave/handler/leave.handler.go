@@ -0,0 +1,332 @@
+package handler
+
+import (
+	"github.com/dwilanang/psp/internal/auth/util"
+	"github.com/dwilanang/psp/internal/leave"
+	"github.com/dwilanang/psp/internal/leave/dto"
+	"github.com/dwilanang/psp/utils"
+	utilrequest "github.com/dwilanang/psp/utils/request"
+	"github.com/dwilanang/psp/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	Deps leave.Dependencies
+}
+
+func NewHandler(deps leave.Dependencies) *Handler {
+	return &Handler{
+		Deps: deps,
+	}
+}
+
+// Types godoc
+// @Security BearerAuth
+// @Summary      Get leave types
+// @Description  Get every leave type with its allowance, accrual method and carry-over limit
+// @Tags         leave
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.ApiResponse{data=[]dto.LeaveTypeData}
+// @Failure      500  {object}  response.Problem
+// @Router       /leave/types [get]
+func (h *Handler) Types(c *gin.Context) {
+	resp, err := h.Deps.Service.Types()
+	if err != nil {
+		response.Error(c, err, "could not fetch leave types")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// UpdateType godoc
+// @Security BearerAuth
+// @Summary      Update leave type
+// @Description  Update the allowance, accrual method and carry-over limit of a leave type. Years already opened keep their entitlement
+// @Tags         leave
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                   true  "Leave type ID"
+// @Param        body  body      dto.LeaveTypeRequest  true  "Leave type payload"
+// @Success      200   {object}  response.ApiResponse{data=dto.LeaveTypeData}
+// @Failure      400   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /leave/types/update/{id} [put]
+func (h *Handler) UpdateType(c *gin.Context) {
+	var tr dto.LeaveTypeRequest
+	if err := c.ShouldBindJSON(&tr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	tr.ID = utils.ConvertStringToInt(c.Param("id"))
+	tr.By = by
+
+	resp, err := h.Deps.Service.UpdateType(&tr)
+	if err != nil {
+		response.Error(c, err, "could not update leave type")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// MyBalances godoc
+// @Security BearerAuth
+// @Summary      Get my leave balances
+// @Description  Get the accrued, carried-over, used, pending and available days of every paid leave type for the authenticated user
+// @Tags         leave
+// @Accept       json
+// @Produce      json
+// @Param        year  query     int  false  "Year, defaults to the current one"
+// @Success      200  {object}  response.ApiResponse{data=[]dto.BalanceData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /leave/balances/me [get]
+func (h *Handler) MyBalances(c *gin.Context) {
+	id, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	h.balances(c, id)
+}
+
+// UserBalances godoc
+// @Security BearerAuth
+// @Summary      Get leave balances of a user
+// @Description  Get the accrued, carried-over, used, pending and available days of every paid leave type for a user
+// @Tags         leave
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int  true   "User ID"
+// @Param        year  query     int  false  "Year, defaults to the current one"
+// @Success      200  {object}  response.ApiResponse{data=[]dto.BalanceData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /leave/balances/user/{id} [get]
+func (h *Handler) UserBalances(c *gin.Context) {
+	h.balances(c, utils.ConvertStringToInt(c.Param("id")))
+}
+
+// Request godoc
+// @Security BearerAuth
+// @Summary      Request leave
+// @Description  File a pending leave request for the authenticated user. Paid leave must fit in the balance accrued by its end date
+// @Tags         leave
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.LeaveRequest  true  "Leave request payload"
+// @Success      201   {object}  response.ApiResponse{data=dto.LeaveData}
+// @Failure      400   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /leave/request [post]
+func (h *Handler) Request(c *gin.Context) {
+	var lr dto.LeaveRequest
+	if err := c.ShouldBindJSON(&lr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	lr.By = by
+
+	resp, err := h.Deps.Service.Request(&lr)
+	if err != nil {
+		response.Error(c, err, "could not request leave")
+		return
+	}
+	response.Created(c, resp.Data)
+}
+
+// Mine godoc
+// @Security BearerAuth
+// @Summary      Get my leave
+// @Description  Get the leave requests of the authenticated user
+// @Tags         leave
+// @Accept       json
+// @Produce      json
+// @Param        leave_type_id  query     int     false  "Leave type ID"
+// @Param        status         query     string  false  "Status (pending, approved, rejected or cancelled)"
+// @Param        period         query     string  false  "Period the leave overlaps (YYYY-MM)"
+// @Success      200  {object}  response.ApiResponse{data=[]dto.LeaveData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /leave/me [get]
+func (h *Handler) Mine(c *gin.Context) {
+	var fr dto.LeaveFilterRequest
+	if err := c.ShouldBindQuery(&fr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	id, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	fr.UserID = id
+
+	h.list(c, &fr)
+}
+
+// GetAll godoc
+// @Security BearerAuth
+// @Summary      Get leave requests
+// @Description  Get the leave requests of every user, optionally filtered by user, leave type, status and period
+// @Tags         leave
+// @Accept       json
+// @Produce      json
+// @Param        user_id        query     int     false  "User ID"
+// @Param        leave_type_id  query     int     false  "Leave type ID"
+// @Param        status         query     string  false  "Status (pending, approved, rejected or cancelled)"
+// @Param        period         query     string  false  "Period the leave overlaps (YYYY-MM)"
+// @Success      200  {object}  response.ApiResponse{data=[]dto.LeaveData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /leave/all [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	var fr dto.LeaveFilterRequest
+	if err := c.ShouldBindQuery(&fr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+	h.list(c, &fr)
+}
+
+// Get godoc
+// @Security BearerAuth
+// @Summary      Get leave request
+// @Description  Get a leave request with the history of its status transitions
+// @Tags         leave
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Leave request ID"
+// @Success      200  {object}  response.ApiResponse{data=dto.LeaveData}
+// @Failure      404  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /leave/detail/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	resp, err := h.Deps.Service.Get(utils.ConvertStringToInt(c.Param("id")))
+	if err != nil {
+		response.Error(c, err, "could not fetch leave")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// Approve godoc
+// @Security BearerAuth
+// @Summary      Approve leave
+// @Description  Approve a pending leave request after checking the balance again. Leave in a month whose payslip was already issued is rejected
+// @Tags         leave
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                     true  "Leave request ID"
+// @Param        body  body      dto.LeaveReviewRequest  true  "Review payload"
+// @Success      200   {object}  response.ApiResponse{data=dto.LeaveData}
+// @Failure      400   {object}  response.Problem
+// @Failure      403   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /leave/approve/{id} [put]
+func (h *Handler) Approve(c *gin.Context) {
+	h.transition(c, h.Deps.Service.Approve, "could not approve leave")
+}
+
+// Reject godoc
+// @Security BearerAuth
+// @Summary      Reject leave
+// @Description  Reject a pending leave request
+// @Tags         leave
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                     true  "Leave request ID"
+// @Param        body  body      dto.LeaveReviewRequest  true  "Review payload"
+// @Success      200   {object}  response.ApiResponse{data=dto.LeaveData}
+// @Failure      400   {object}  response.Problem
+// @Failure      403   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /leave/reject/{id} [put]
+func (h *Handler) Reject(c *gin.Context) {
+	h.transition(c, h.Deps.Service.Reject, "could not reject leave")
+}
+
+// Cancel godoc
+// @Security BearerAuth
+// @Summary      Cancel leave
+// @Description  Cancel a leave request of the authenticated user while it is pending or, once approved, before it starts
+// @Tags         leave
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                     true  "Leave request ID"
+// @Param        body  body      dto.LeaveReviewRequest  true  "Cancellation payload"
+// @Success      200   {object}  response.ApiResponse{data=dto.LeaveData}
+// @Failure      400   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /leave/cancel/{id} [put]
+func (h *Handler) Cancel(c *gin.Context) {
+	h.transition(c, h.Deps.Service.Cancel, "could not cancel leave")
+}
+
+func (h *Handler) balances(c *gin.Context, userID int64) {
+	var br dto.BalanceRequest
+	if err := c.ShouldBindQuery(&br); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	resp, err := h.Deps.Service.Balances(userID, br.Year)
+	if err != nil {
+		response.Error(c, err, "could not fetch leave balances")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+func (h *Handler) list(c *gin.Context, request *dto.LeaveFilterRequest) {
+	resp, err := h.Deps.Service.List(request)
+	if err != nil {
+		response.Error(c, err, "could not fetch leave")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+func (h *Handler) transition(c *gin.Context, decide func(*dto.LeaveReviewRequest) (dto.LeaveResponse, error), message string) {
+	var rr dto.LeaveReviewRequest
+	if err := c.ShouldBindJSON(&rr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	rr.ID = utils.ConvertStringToInt(c.Param("id"))
+	rr.By = by
+
+	resp, err := decide(&rr)
+	if err != nil {
+		response.Error(c, err, message)
+		return
+	}
+	response.OK(c, resp.Data)
+}
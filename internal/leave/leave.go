@@ -0,0 +1,11 @@
+package leave
+
+import (
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/leave/service"
+)
+
+type Dependencies struct {
+	DBPostgres *postgres.DB
+	Service    service.Service
+}
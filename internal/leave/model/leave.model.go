@@ -0,0 +1,96 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Request statuses. A request is submitted pending; approvers approve or reject it, and its owner may cancel it
+// while it is pending or, once approved, before it starts.
+const (
+	StatusPending   = "pending"
+	StatusApproved  = "approved"
+	StatusRejected  = "rejected"
+	StatusCancelled = "cancelled"
+)
+
+// Accrual methods. Yearly grants the whole allowance on the first day of the year;
+// monthly grants a twelfth of it at the start of every month.
+const (
+	AccrualYearly  = "yearly"
+	AccrualMonthly = "monthly"
+)
+
+// LeaveType is a kind of leave with its accrual rules. Paid leave is limited by the balance;
+// unpaid leave is unlimited and deducted from the payslip.
+type LeaveType struct {
+	ID              int64           `db:"id"`
+	Code            string          `db:"code"`
+	Name            string          `db:"name"`
+	IsPaid          bool            `db:"is_paid"`
+	YearlyAllowance decimal.Decimal `db:"yearly_allowance"`
+	Accrual         string          `db:"accrual"`
+	MaxCarryOver    decimal.Decimal `db:"max_carry_over"`
+	IsActive        bool            `db:"is_active"`
+	CreatedBy       int64           `db:"created_by"`
+	UpdatedBy       int64           `db:"updated_by"`
+}
+
+// Balance is the entitlement of a user to a leave type in a year, fixed when the year is opened.
+// Accrual, carry-over and usage are derived from it and from the requests.
+type Balance struct {
+	ID          int64           `db:"id"`
+	UserID      int64           `db:"user_id"`
+	LeaveTypeID int64           `db:"leave_type_id"`
+	Year        int             `db:"year"`
+	Entitled    decimal.Decimal `db:"entitled"`
+}
+
+// Usage sums the days of a user's requests of one leave type in a year.
+type Usage struct {
+	Approved int `db:"approved"`
+	Pending  int `db:"pending"`
+}
+
+type LeaveRequest struct {
+	ID          int64         `db:"id"`
+	UserID      int64         `db:"user_id"`
+	FullName    string        `db:"full_name"`
+	LeaveTypeID int64         `db:"leave_type_id"`
+	TypeCode    string        `db:"type_code"`
+	TypeName    string        `db:"type_name"`
+	IsPaid      bool          `db:"is_paid"`
+	StartDate   time.Time     `db:"start_date"`
+	EndDate     time.Time     `db:"end_date"`
+	Days        int           `db:"days"`
+	Reason      string        `db:"reason"`
+	Status      string        `db:"status"`
+	ReviewNote  string        `db:"review_note"`
+	CreatedBy   int64         `db:"created_by"`
+	CreatedAt   time.Time     `db:"created_at"`
+	UpdatedBy   int64         `db:"updated_by"`
+	UpdatedAt   time.Time     `db:"updated_at"`
+	Transitions []*Transition `db:"-"`
+}
+
+// Transition records who moved a request between two statuses and when.
+// FromStatus is empty for the submission.
+type Transition struct {
+	ID             int64     `db:"id"`
+	LeaveRequestID int64     `db:"leave_request_id"`
+	FromStatus     string    `db:"from_status"`
+	ToStatus       string    `db:"to_status"`
+	Note           string    `db:"note"`
+	CreatedBy      int64     `db:"created_by"`
+	CreatedByName  string    `db:"created_by_name"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+type LeaveFilter struct {
+	UserID      int64
+	LeaveTypeID int64
+	Status      string
+	From        *time.Time
+	To          *time.Time
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/internal/leave/model"
+)
+
+//go:generate mockgen -source=leave.repository.go -package=mocks -destination=mocks/mock_leave_repository.go
+
+// Repository defines an interface for data operations related to leave types, balances and requests.
+type Repository interface {
+	// FetchTypes retrieves every leave type, ordered by ID.
+	// Returns a slice of LeaveType pointers and an error if the operation fails.
+	FetchTypes() ([]*model.LeaveType, error)
+
+	// FindType retrieves a leave type by ID.
+	// Param: id - the ID of the leave type.
+	// Returns a pointer to the LeaveType and an error if the operation fails or the type is not found.
+	FindType(id int64) (*model.LeaveType, error)
+
+	// UpdateType modifies the name and accrual rules of a leave type; balances already opened are not affected.
+	// Param: leaveType - a pointer to the LeaveType with updated data.
+	// Returns sql.ErrNoRows if the type does not exist, or an error if the operation fails.
+	UpdateType(leaveType *model.LeaveType) error
+
+	// FindBalance retrieves the balance of a user for a leave type and year.
+	// Param: userID - the ID of the user; leaveTypeID - the ID of the leave type; year - the calendar year.
+	// Returns a pointer to the Balance and an error if the operation fails or the balance is not opened yet.
+	FindBalance(userID int64, leaveTypeID int64, year int) (*model.Balance, error)
+
+	// CreateBalance opens the balance of a user for a leave type and year, keeping the existing one if it was
+	// opened concurrently.
+	// Param: balance - a pointer to the Balance to be created; it is updated with the stored balance.
+	// Returns an error if the operation fails.
+	CreateBalance(balance *model.Balance) error
+
+	// Usage sums the days of the approved and pending requests of a user for a leave type in a year.
+	// Param: userID - the ID of the user; leaveTypeID - the ID of the leave type; year - the calendar year.
+	// Returns the Usage and an error if the operation fails.
+	Usage(userID int64, leaveTypeID int64, year int) (model.Usage, error)
+
+	// Fetch retrieves leave requests matching the filter, most recent first.
+	// Param: filter - a pointer to LeaveFilter; zero fields are ignored.
+	// Returns a slice of LeaveRequest pointers and an error if the operation fails.
+	Fetch(filter *model.LeaveFilter) ([]*model.LeaveRequest, error)
+
+	// FindByID retrieves a leave request and its transitions by ID.
+	// Param: id - the ID of the request.
+	// Returns a pointer to the LeaveRequest and an error if the operation fails or the request is not found.
+	FindByID(id int64) (*model.LeaveRequest, error)
+
+	// Create inserts a pending leave request and its submission transition in a single transaction.
+	// Param: request - a pointer to the LeaveRequest entity to be created.
+	// Returns sql.ErrNoRows if it overlaps a pending or approved request of the user, or an error if the operation fails.
+	Create(request *model.LeaveRequest) error
+
+	// Transition moves a leave request from one status to another and records the transition.
+	// Param: id - the ID of the request; from, to - the expected and new status; by - the ID of the acting user;
+	// note - an optional note explaining the decision.
+	// Returns false if the request was not in the expected status, and an error if the operation fails.
+	Transition(id int64, from string, to string, by int64, note string) (bool, error)
+
+	// FetchApproved retrieves the approved leave of a user overlapping a date range.
+	// Param: userID - the ID of the user; from, to - the inclusive date range.
+	// Returns a slice of LeaveRequest pointers and an error if the operation fails.
+	FetchApproved(userID int64, from time.Time, to time.Time) ([]*model.LeaveRequest, error)
+}
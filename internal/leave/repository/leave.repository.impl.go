@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/leave/model"
+	"github.com/jmoiron/sqlx"
+)
+
+const selectLeaveType = `
+		SELECT 
+			id, code, name, is_paid, yearly_allowance, accrual, max_carry_over, is_active,
+			COALESCE(created_by, 0) AS created_by,
+			COALESCE(updated_by, created_by, 0) AS updated_by
+		FROM leave_types
+`
+
+const selectLeaveRequest = `
+		SELECT 
+			l.id, l.user_id,
+			COALESCE(u.full_name, '') AS full_name,
+			l.leave_type_id,
+			t.code AS type_code, t.name AS type_name, t.is_paid,
+			l.start_date, l.end_date, l.days, l.reason, l.status,
+			COALESCE(l.review_note, '') AS review_note,
+			COALESCE(l.created_by, 0) AS created_by,
+			l.created_at,
+			COALESCE(l.updated_by, l.created_by, 0) AS updated_by,
+			COALESCE(l.updated_at, l.created_at) AS updated_at
+		FROM leave_requests l
+		INNER JOIN users u ON(u.id=l.user_id)
+		INNER JOIN leave_types t ON(t.id=l.leave_type_id)
+`
+
+type repository struct {
+	db *postgres.DB
+}
+
+func NewRepository(db *postgres.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) FetchTypes() ([]*model.LeaveType, error) {
+	leaveTypes := []*model.LeaveType{}
+	err := r.db.Select(&leaveTypes, selectLeaveType+` ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	return leaveTypes, nil
+}
+
+func (r *repository) FindType(id int64) (*model.LeaveType, error) {
+	var leaveType model.LeaveType
+	err := r.db.Get(&leaveType, selectLeaveType+` WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	return &leaveType, nil
+}
+
+func (r *repository) UpdateType(leaveType *model.LeaveType) error {
+	query := `
+		UPDATE leave_types SET name = $2, yearly_allowance = $3, accrual = $4, max_carry_over = $5, is_active = $6,
+			updated_by = $7, updated_at = NOW()
+		WHERE id = $1
+		RETURNING code, is_paid
+	`
+	return r.db.QueryRowx(
+		query,
+		leaveType.ID,
+		leaveType.Name,
+		leaveType.YearlyAllowance,
+		leaveType.Accrual,
+		leaveType.MaxCarryOver,
+		leaveType.IsActive,
+		leaveType.UpdatedBy,
+	).Scan(&leaveType.Code, &leaveType.IsPaid)
+}
+
+func (r *repository) FindBalance(userID int64, leaveTypeID int64, year int) (*model.Balance, error) {
+	var balance model.Balance
+	query := `
+		SELECT id, user_id, leave_type_id, year, entitled
+		FROM leave_balances
+		WHERE user_id = $1 AND leave_type_id = $2 AND year = $3
+	`
+	err := r.db.Get(&balance, query, userID, leaveTypeID, year)
+	if err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+func (r *repository) CreateBalance(balance *model.Balance) error {
+	// The no-op update makes RETURNING yield the row opened by a concurrent request.
+	query := `
+		INSERT INTO leave_balances (user_id, leave_type_id, year, entitled, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id, leave_type_id, year) DO UPDATE SET year = EXCLUDED.year
+		RETURNING id, entitled
+	`
+	return r.db.QueryRowx(
+		query,
+		balance.UserID,
+		balance.LeaveTypeID,
+		balance.Year,
+		balance.Entitled,
+	).Scan(&balance.ID, &balance.Entitled)
+}
+
+func (r *repository) Usage(userID int64, leaveTypeID int64, year int) (model.Usage, error) {
+	var usage model.Usage
+	query := `
+		SELECT 
+			COALESCE(SUM(days) FILTER (WHERE status = 'approved'), 0) AS approved,
+			COALESCE(SUM(days) FILTER (WHERE status = 'pending'), 0) AS pending
+		FROM leave_requests
+		WHERE user_id = $1 AND leave_type_id = $2 AND EXTRACT(YEAR FROM start_date) = $3
+	`
+	err := r.db.Get(&usage, query, userID, leaveTypeID, year)
+	return usage, err
+}
+
+func (r *repository) Fetch(filter *model.LeaveFilter) ([]*model.LeaveRequest, error) {
+	requests := []*model.LeaveRequest{}
+	query := selectLeaveRequest + `
+		WHERE ($1 = 0 OR l.user_id = $1)
+		AND ($2 = 0 OR l.leave_type_id = $2)
+		AND ($3 = '' OR l.status = $3)
+		AND ($4::date IS NULL OR l.end_date >= $4)
+		AND ($5::date IS NULL OR l.start_date <= $5)
+		ORDER BY l.start_date DESC, l.id DESC
+	`
+	err := r.db.Replica().Select(&requests, query, filter.UserID, filter.LeaveTypeID, filter.Status, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (r *repository) FindByID(id int64) (*model.LeaveRequest, error) {
+	var request model.LeaveRequest
+	query := selectLeaveRequest + `
+		WHERE l.id = $1
+	`
+	err := r.db.Get(&request, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	transitionQuery := `
+		SELECT 
+			t.id, t.leave_request_id,
+			COALESCE(t.from_status, '') AS from_status,
+			t.to_status,
+			COALESCE(t.note, '') AS note,
+			t.created_by,
+			COALESCE(u.full_name, '') AS created_by_name,
+			t.created_at
+		FROM leave_transitions t
+		INNER JOIN users u ON(u.id=t.created_by)
+		WHERE t.leave_request_id = $1
+		ORDER BY t.id
+	`
+	err = r.db.Select(&request.Transitions, transitionQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *repository) Create(request *model.LeaveRequest) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Overlapping live requests violate the exclusion constraint and are skipped.
+	query := `
+		INSERT INTO leave_requests (user_id, leave_type_id, start_date, end_date, days, reason, status,
+			created_by, created_at, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), $8, NOW())
+		ON CONFLICT DO NOTHING
+		RETURNING id, created_at
+	`
+	err = tx.QueryRowx(
+		query,
+		request.UserID,
+		request.LeaveTypeID,
+		request.StartDate,
+		request.EndDate,
+		request.Days,
+		request.Reason,
+		request.Status,
+		request.CreatedBy,
+	).Scan(&request.ID, &request.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	err = insertTransition(tx, request.ID, "", request.Status, request.CreatedBy, "")
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *repository) Transition(id int64, from string, to string, by int64, note string) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE leave_requests SET status = $3, review_note = NULLIF($4, ''), updated_by = $5, updated_at = NOW()
+		WHERE id = $1 AND status = $2
+	`
+	result, err := tx.Exec(query, id, from, to, note, by)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+
+	err = insertTransition(tx, id, from, to, by, note)
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+func (r *repository) FetchApproved(userID int64, from time.Time, to time.Time) ([]*model.LeaveRequest, error) {
+	requests := []*model.LeaveRequest{}
+	query := selectLeaveRequest + `
+		WHERE l.user_id = $1 AND l.status = 'approved' AND l.start_date <= $3 AND l.end_date >= $2
+		ORDER BY l.start_date
+	`
+	err := r.db.Select(&requests, query, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func insertTransition(tx *sqlx.Tx, requestID int64, from string, to string, by int64, note string) error {
+	query := `
+		INSERT INTO leave_transitions (leave_request_id, from_status, to_status, note, created_by, created_at)
+		VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''), $5, NOW())
+	`
+	_, err := tx.Exec(query, requestID, from, to, note, by)
+	return err
+}
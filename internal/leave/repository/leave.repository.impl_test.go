@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/leave/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*postgres.DB, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return postgres.NewDB(sqlx.NewDb(db, "postgres")), mock, func() { db.Close() }
+}
+
+func TestCreate_RecordsRequest(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO leave_requests").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+	mock.ExpectExec("INSERT INTO leave_transitions").
+		WithArgs(int64(1), "", model.StatusPending, "", int64(7)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	request := &model.LeaveRequest{UserID: 7, LeaveTypeID: 1, Days: 3, Status: model.StatusPending, CreatedBy: 7}
+	err := repo.Create(request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), request.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate_Overlap(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	// The exclusion constraint skips the insert, so no row is returned.
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO leave_requests").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}))
+	mock.ExpectRollback()
+
+	err := repo.Create(&model.LeaveRequest{UserID: 7, LeaveTypeID: 1, Days: 3, Status: model.StatusPending, CreatedBy: 7})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateBalance(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	// A balance opened concurrently keeps its entitlement.
+	mock.ExpectQuery("INSERT INTO leave_balances").
+		WithArgs(int64(7), int64(1), 2025, decimal.NewFromInt(12)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "entitled"}).AddRow(3, "10"))
+
+	balance := &model.Balance{UserID: 7, LeaveTypeID: 1, Year: 2025, Entitled: decimal.NewFromInt(12)}
+	err := repo.CreateBalance(balance)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), balance.ID)
+	assert.Equal(t, "10", balance.Entitled.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUsage(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectQuery("FROM leave_requests").
+		WithArgs(int64(7), int64(1), 2025).
+		WillReturnRows(sqlmock.NewRows([]string{"approved", "pending"}).AddRow(4, 2))
+
+	usage, err := repo.Usage(7, 1, 2025)
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.Usage{Approved: 4, Pending: 2}, usage)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
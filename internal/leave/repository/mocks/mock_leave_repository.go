@@ -0,0 +1,198 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: leave.repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/dwilanang/psp/internal/leave/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(request *model.LeaveRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), request)
+}
+
+// CreateBalance mocks base method.
+func (m *MockRepository) CreateBalance(balance *model.Balance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBalance", balance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBalance indicates an expected call of CreateBalance.
+func (mr *MockRepositoryMockRecorder) CreateBalance(balance interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBalance", reflect.TypeOf((*MockRepository)(nil).CreateBalance), balance)
+}
+
+// Fetch mocks base method.
+func (m *MockRepository) Fetch(filter *model.LeaveFilter) ([]*model.LeaveRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fetch", filter)
+	ret0, _ := ret[0].([]*model.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fetch indicates an expected call of Fetch.
+func (mr *MockRepositoryMockRecorder) Fetch(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockRepository)(nil).Fetch), filter)
+}
+
+// FetchApproved mocks base method.
+func (m *MockRepository) FetchApproved(userID int64, from, to time.Time) ([]*model.LeaveRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchApproved", userID, from, to)
+	ret0, _ := ret[0].([]*model.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchApproved indicates an expected call of FetchApproved.
+func (mr *MockRepositoryMockRecorder) FetchApproved(userID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchApproved", reflect.TypeOf((*MockRepository)(nil).FetchApproved), userID, from, to)
+}
+
+// FetchTypes mocks base method.
+func (m *MockRepository) FetchTypes() ([]*model.LeaveType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTypes")
+	ret0, _ := ret[0].([]*model.LeaveType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTypes indicates an expected call of FetchTypes.
+func (mr *MockRepositoryMockRecorder) FetchTypes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTypes", reflect.TypeOf((*MockRepository)(nil).FetchTypes))
+}
+
+// FindBalance mocks base method.
+func (m *MockRepository) FindBalance(userID, leaveTypeID int64, year int) (*model.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBalance", userID, leaveTypeID, year)
+	ret0, _ := ret[0].(*model.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBalance indicates an expected call of FindBalance.
+func (mr *MockRepositoryMockRecorder) FindBalance(userID, leaveTypeID, year interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBalance", reflect.TypeOf((*MockRepository)(nil).FindBalance), userID, leaveTypeID, year)
+}
+
+// FindByID mocks base method.
+func (m *MockRepository) FindByID(id int64) (*model.LeaveRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*model.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), id)
+}
+
+// FindType mocks base method.
+func (m *MockRepository) FindType(id int64) (*model.LeaveType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindType", id)
+	ret0, _ := ret[0].(*model.LeaveType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindType indicates an expected call of FindType.
+func (mr *MockRepositoryMockRecorder) FindType(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindType", reflect.TypeOf((*MockRepository)(nil).FindType), id)
+}
+
+// Transition mocks base method.
+func (m *MockRepository) Transition(id int64, from, to string, by int64, note string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", id, from, to, by, note)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockRepositoryMockRecorder) Transition(id, from, to, by, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockRepository)(nil).Transition), id, from, to, by, note)
+}
+
+// UpdateType mocks base method.
+func (m *MockRepository) UpdateType(leaveType *model.LeaveType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateType", leaveType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateType indicates an expected call of UpdateType.
+func (mr *MockRepositoryMockRecorder) UpdateType(leaveType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateType", reflect.TypeOf((*MockRepository)(nil).UpdateType), leaveType)
+}
+
+// Usage mocks base method.
+func (m *MockRepository) Usage(userID, leaveTypeID int64, year int) (model.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage", userID, leaveTypeID, year)
+	ret0, _ := ret[0].(model.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MockRepositoryMockRecorder) Usage(userID, leaveTypeID, year interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockRepository)(nil).Usage), userID, leaveTypeID, year)
+}
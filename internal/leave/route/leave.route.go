@@ -0,0 +1,28 @@
+package route
+
+import (
+	"github.com/dwilanang/psp/internal/permission"
+	"github.com/dwilanang/psp/internal/registry"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, registry *registry.Registry) {
+	h := registry.NewLeaveHandler()
+
+	leaveGroup := rg.Group("/leave")
+	{
+		// Every authenticated user reads the leave types and their own balances, and requests and cancels their own leave.
+		leaveGroup.GET("/types", h.Types)
+		leaveGroup.GET("/balances/me", h.MyBalances)
+		leaveGroup.POST("/request", h.Request)
+		leaveGroup.GET("/me", h.Mine)
+		leaveGroup.PUT("/cancel/:id", h.Cancel)
+
+		leaveGroup.PUT("/types/update/:id", registry.RequirePermission(permission.LeaveConfigure), h.UpdateType)
+		leaveGroup.GET("/balances/user/:id", registry.RequirePermission(permission.LeaveRead), h.UserBalances)
+		leaveGroup.GET("/all", registry.RequirePermission(permission.LeaveRead), h.GetAll)
+		leaveGroup.GET("/detail/:id", registry.RequirePermission(permission.LeaveRead), h.Get)
+		leaveGroup.PUT("/approve/:id", registry.RequirePermission(permission.LeaveApprove), h.Approve)
+		leaveGroup.PUT("/reject/:id", registry.RequirePermission(permission.LeaveApprove), h.Reject)
+	}
+}
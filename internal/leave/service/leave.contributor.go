@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dwilanang/psp/internal/leave/model"
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+	"github.com/shopspring/decimal"
+)
+
+// ApprovedFetcher retrieves the approved leave of a user overlapping a date range.
+// It is satisfied by the leave repository.
+type ApprovedFetcher interface {
+	FetchApproved(userID int64, from time.Time, to time.Time) ([]*model.LeaveRequest, error)
+}
+
+// PayslipContributor deducts unpaid leave from payslips: the base salary pro-rated over the working days
+// of the period, for every working day of unpaid leave in it.
+type PayslipContributor struct {
+	leaves ApprovedFetcher
+}
+
+func NewPayslipContributor(leaves ApprovedFetcher) *PayslipContributor {
+	return &PayslipContributor{leaves: leaves}
+}
+
+// Contribute implements the payslip Contributor interface.
+func (p *PayslipContributor) Contribute(calc *payslipmodel.Calculation) ([]*payslipmodel.PayslipItem, error) {
+	leaves, err := p.leaves.FetchApproved(calc.UserID, calc.Period.Start, calc.Period.End)
+	if err != nil {
+		return nil, err
+	}
+
+	days := 0
+	for _, leave := range leaves {
+		if leave.IsPaid {
+			continue
+		}
+		days += workingDays(maxDate(leave.StartDate, calc.Period.Start), minDate(leave.EndDate, calc.Period.End))
+	}
+	periodDays := workingDays(calc.Period.Start, calc.Period.End)
+	if days == 0 || periodDays == 0 {
+		return nil, nil
+	}
+
+	daily := calc.BaseSalary.Div(decimal.NewFromInt(int64(periodDays)))
+	return []*payslipmodel.PayslipItem{{
+		Code:   "UNPAID_LEAVE",
+		Name:   fmt.Sprintf("Unpaid Leave (%d days)", days),
+		Type:   payslipmodel.TypeDeduction,
+		Amount: daily.Mul(decimal.NewFromInt(int64(days))).Round(2),
+	}}, nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/internal/leave/dto"
+)
+
+//go:generate mockgen -source=leave.service.go -package=mocks -destination=mocks/mock_leave_service.go
+
+// Service defines the interface for business logic related to leave.
+// Leave is counted in working days, Monday to Friday, and a request never spans two calendar years.
+type Service interface {
+	// Types retrieves every leave type with its accrual rules.
+	// Returns a LeaveTypeListResponse DTO and an error if the operation fails.
+	Types() (dto.LeaveTypeListResponse, error)
+
+	// UpdateType modifies the name and accrual rules of a leave type; balances already opened keep their entitlement.
+	// Param: request - a pointer to LeaveTypeRequest DTO.
+	// Returns the updated type, ErrLeaveTypeNotFound, or ErrInvalidLeaveType if an amount is negative.
+	UpdateType(request *dto.LeaveTypeRequest) (dto.LeaveTypeResponse, error)
+
+	// Balances computes the balance of every active paid leave type of a user for a year.
+	// Param: userID - the ID of the user; year - the calendar year, or 0 for the current one.
+	// Returns a BalanceListResponse DTO and an error if the operation fails.
+	Balances(userID int64, year int) (dto.BalanceListResponse, error)
+
+	// Request files a pending leave request for the authenticated user.
+	// Param: request - a pointer to LeaveRequest DTO.
+	// Returns the created request, ErrInvalidDates, ErrOverlap, ErrInsufficientBalance or ErrPeriodLocked.
+	Request(request *dto.LeaveRequest) (dto.LeaveResponse, error)
+
+	// List retrieves leave requests matching the filter, most recent first.
+	// Param: request - a pointer to LeaveFilterRequest DTO.
+	// Returns a LeaveListResponse DTO and an error if the operation fails.
+	List(request *dto.LeaveFilterRequest) (dto.LeaveListResponse, error)
+
+	// Get retrieves a leave request with its transitions.
+	// Param: id - the ID of the request.
+	// Returns a LeaveResponse DTO and ErrLeaveNotFound if it does not exist.
+	Get(id int64) (dto.LeaveResponse, error)
+
+	// Approve approves a pending leave request, checking the balance again.
+	// Param: request - a pointer to LeaveReviewRequest DTO.
+	// Returns the updated request, ErrNotPending, ErrInsufficientBalance, ErrPayslipIssued if the payslip of a month
+	// the leave falls in was already issued, or ErrSelfReview if approvers review their own request.
+	Approve(request *dto.LeaveReviewRequest) (dto.LeaveResponse, error)
+
+	// Reject rejects a pending leave request.
+	// Param: request - a pointer to LeaveReviewRequest DTO.
+	// Returns the updated request and the same errors as Approve.
+	Reject(request *dto.LeaveReviewRequest) (dto.LeaveResponse, error)
+
+	// Cancel withdraws a request on behalf of its owner, either while pending or, once approved, before it starts.
+	// Param: request - a pointer to LeaveReviewRequest DTO.
+	// Returns the updated request, ErrNotCancellable, or ErrLeaveNotFound if the request belongs to someone else.
+	Cancel(request *dto.LeaveReviewRequest) (dto.LeaveResponse, error)
+
+	// LeaveDays lists the working days a user is on approved leave between two dates, for use by other modules such as attendance.
+	// Param: userID - the ID of the user; from, to - the inclusive date range.
+	// Returns the days as UTC midnights and an error if the operation fails.
+	LeaveDays(userID int64, from time.Time, to time.Time) ([]time.Time, error)
+}
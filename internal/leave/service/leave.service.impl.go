@@ -0,0 +1,523 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dwilanang/psp/internal/leave/dto"
+	"github.com/dwilanang/psp/internal/leave/model"
+	"github.com/dwilanang/psp/internal/leave/repository"
+	"github.com/dwilanang/psp/internal/payroll/lock"
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/shopspring/decimal"
+)
+
+const dateLayout = "2006-01-02"
+
+var (
+	ErrLeaveNotFound       = apperror.NotFound("leave request not found")
+	ErrLeaveTypeNotFound   = apperror.NotFound("leave type not found")
+	ErrInvalidLeaveType    = apperror.Validation("leave type is inactive or its allowance and carry-over are negative")
+	ErrInvalidDates        = apperror.Validation("start_date and end_date must be formatted as YYYY-MM-DD, in order and in the same year")
+	ErrNoWorkingDays       = apperror.Validation("leave must include at least one working day")
+	ErrOverlap             = apperror.Conflict("leave overlaps another pending or approved request")
+	ErrInsufficientBalance = apperror.Conflict("insufficient leave balance")
+	ErrNotPending          = apperror.Conflict("leave request has already been reviewed")
+	ErrNotCancellable      = apperror.Conflict("only pending leave, or approved leave that has not started, can be cancelled")
+	ErrSelfReview          = apperror.Forbidden("you cannot review your own leave request")
+	ErrPeriodLocked        = apperror.Conflict("leave falls in a closed payroll period")
+	ErrPayslipIssued       = apperror.Conflict("leave falls in a period whose payslip has already been issued")
+)
+
+type service struct {
+	repo     repository.Repository
+	payslips lock.PayslipFetcher
+	periods  lock.PeriodLocker
+	now      func() time.Time
+}
+
+// NewService creates the leave service. payslips and periods may be nil when payslips and payroll periods
+// are not enforced.
+func NewService(r repository.Repository, payslips lock.PayslipFetcher, periods lock.PeriodLocker) *service {
+	return &service{repo: r, payslips: payslips, periods: periods, now: time.Now}
+}
+
+// Types implements the Service interface.
+func (s *service) Types() (dto.LeaveTypeListResponse, error) {
+	leaveTypes, err := s.repo.FetchTypes()
+	if err != nil {
+		return dto.LeaveTypeListResponse{}, err
+	}
+
+	data := make([]dto.LeaveTypeData, 0, len(leaveTypes))
+	for _, leaveType := range leaveTypes {
+		data = append(data, toTypeData(leaveType))
+	}
+	return dto.LeaveTypeListResponse{Data: data}, nil
+}
+
+// UpdateType implements the Service interface.
+func (s *service) UpdateType(request *dto.LeaveTypeRequest) (dto.LeaveTypeResponse, error) {
+	if request.YearlyAllowance.IsNegative() || request.MaxCarryOver.IsNegative() {
+		return dto.LeaveTypeResponse{}, ErrInvalidLeaveType
+	}
+
+	leaveType := &model.LeaveType{
+		ID:              request.ID,
+		Name:            request.Name,
+		YearlyAllowance: request.YearlyAllowance.Round(2),
+		Accrual:         request.Accrual,
+		MaxCarryOver:    request.MaxCarryOver.Round(2),
+		IsActive:        request.IsActive,
+		UpdatedBy:       request.By,
+	}
+	err := s.repo.UpdateType(leaveType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dto.LeaveTypeResponse{}, ErrLeaveTypeNotFound
+		}
+		return dto.LeaveTypeResponse{}, err
+	}
+	return dto.LeaveTypeResponse{Data: toTypeData(leaveType)}, nil
+}
+
+// Balances implements the Service interface.
+func (s *service) Balances(userID int64, year int) (dto.BalanceListResponse, error) {
+	today := s.today()
+	if year == 0 {
+		year = today.Year()
+	}
+
+	leaveTypes, err := s.repo.FetchTypes()
+	if err != nil {
+		return dto.BalanceListResponse{}, err
+	}
+
+	data := []dto.BalanceData{}
+	for _, leaveType := range leaveTypes {
+		if !leaveType.IsPaid || !leaveType.IsActive {
+			continue
+		}
+		balance, err := s.balance(userID, leaveType, year, today)
+		if err != nil {
+			return dto.BalanceListResponse{}, err
+		}
+		data = append(data, balance)
+	}
+	return dto.BalanceListResponse{Data: data}, nil
+}
+
+// Request implements the Service interface.
+func (s *service) Request(request *dto.LeaveRequest) (dto.LeaveResponse, error) {
+	start, errStart := time.Parse(dateLayout, request.StartDate)
+	end, errEnd := time.Parse(dateLayout, request.EndDate)
+	if errStart != nil || errEnd != nil || end.Before(start) || start.Year() != end.Year() {
+		return dto.LeaveResponse{}, ErrInvalidDates
+	}
+	days := workingDays(start, end)
+	if days == 0 {
+		return dto.LeaveResponse{}, ErrNoWorkingDays
+	}
+
+	leaveType, err := s.findType(request.LeaveTypeID)
+	if err != nil {
+		return dto.LeaveResponse{}, err
+	}
+	if !leaveType.IsActive {
+		return dto.LeaveResponse{}, ErrInvalidLeaveType
+	}
+	if err := s.checkUnlocked(start, end); err != nil {
+		return dto.LeaveResponse{}, err
+	}
+
+	leave := &model.LeaveRequest{
+		UserID:      request.By,
+		LeaveTypeID: leaveType.ID,
+		TypeCode:    leaveType.Code,
+		TypeName:    leaveType.Name,
+		IsPaid:      leaveType.IsPaid,
+		StartDate:   start,
+		EndDate:     end,
+		Days:        days,
+		Reason:      request.Reason,
+		Status:      model.StatusPending,
+		CreatedBy:   request.By,
+		UpdatedBy:   request.By,
+	}
+	if err := s.checkBalance(leave, leaveType, 0); err != nil {
+		return dto.LeaveResponse{}, err
+	}
+
+	err = s.repo.Create(leave)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dto.LeaveResponse{}, ErrOverlap
+		}
+		return dto.LeaveResponse{}, err
+	}
+	return dto.LeaveResponse{Data: toData(leave)}, nil
+}
+
+// List implements the Service interface.
+func (s *service) List(request *dto.LeaveFilterRequest) (dto.LeaveListResponse, error) {
+	filter := &model.LeaveFilter{UserID: request.UserID, LeaveTypeID: request.LeaveTypeID, Status: request.Status}
+	if request.Period != "" {
+		month, err := payslipmodel.ParsePeriod(request.Period)
+		if err != nil {
+			return dto.LeaveListResponse{}, err
+		}
+		filter.From, filter.To = &month.Start, &month.End
+	}
+
+	requests, err := s.repo.Fetch(filter)
+	if err != nil {
+		return dto.LeaveListResponse{}, err
+	}
+
+	data := make([]dto.LeaveData, 0, len(requests))
+	for _, leave := range requests {
+		data = append(data, toData(leave))
+	}
+	return dto.LeaveListResponse{Data: data}, nil
+}
+
+// Get implements the Service interface.
+func (s *service) Get(id int64) (dto.LeaveResponse, error) {
+	leave, err := s.find(id)
+	if err != nil {
+		return dto.LeaveResponse{}, err
+	}
+	return dto.LeaveResponse{Data: toData(leave)}, nil
+}
+
+// Approve implements the Service interface.
+func (s *service) Approve(request *dto.LeaveReviewRequest) (dto.LeaveResponse, error) {
+	return s.review(request, model.StatusApproved)
+}
+
+// Reject implements the Service interface.
+func (s *service) Reject(request *dto.LeaveReviewRequest) (dto.LeaveResponse, error) {
+	return s.review(request, model.StatusRejected)
+}
+
+// Cancel implements the Service interface.
+func (s *service) Cancel(request *dto.LeaveReviewRequest) (dto.LeaveResponse, error) {
+	leave, err := s.find(request.ID)
+	if err != nil {
+		return dto.LeaveResponse{}, err
+	}
+	if leave.UserID != request.By {
+		return dto.LeaveResponse{}, ErrLeaveNotFound
+	}
+	switch {
+	case leave.Status == model.StatusPending:
+	case leave.Status == model.StatusApproved && leave.StartDate.After(s.today()):
+		if err := s.checkUnlocked(leave.StartDate, leave.EndDate); err != nil {
+			return dto.LeaveResponse{}, err
+		}
+	default:
+		return dto.LeaveResponse{}, ErrNotCancellable
+	}
+	return s.transition(leave, leave.Status, model.StatusCancelled, request, ErrNotCancellable)
+}
+
+// LeaveDays implements the Service interface.
+func (s *service) LeaveDays(userID int64, from time.Time, to time.Time) ([]time.Time, error) {
+	requests, err := s.repo.FetchApproved(userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var days []time.Time
+	for _, leave := range requests {
+		for day := maxDate(leave.StartDate, from); !day.After(minDate(leave.EndDate, to)); day = day.AddDate(0, 0, 1) {
+			if isWorkingDay(day) {
+				days = append(days, day)
+			}
+		}
+	}
+	return days, nil
+}
+
+func (s *service) review(request *dto.LeaveReviewRequest, status string) (dto.LeaveResponse, error) {
+	leave, err := s.find(request.ID)
+	if err != nil {
+		return dto.LeaveResponse{}, err
+	}
+	if leave.UserID == request.By {
+		return dto.LeaveResponse{}, ErrSelfReview
+	}
+	if leave.Status != model.StatusPending {
+		return dto.LeaveResponse{}, ErrNotPending
+	}
+	if err := s.checkUnlocked(leave.StartDate, leave.EndDate); err != nil {
+		return dto.LeaveResponse{}, err
+	}
+	if status == model.StatusApproved {
+		if err := s.checkUnissued(leave); err != nil {
+			return dto.LeaveResponse{}, err
+		}
+		leaveType, err := s.findType(leave.LeaveTypeID)
+		if err != nil {
+			return dto.LeaveResponse{}, err
+		}
+		// The request itself is counted as pending in the balance.
+		if err := s.checkBalance(leave, leaveType, leave.Days); err != nil {
+			return dto.LeaveResponse{}, err
+		}
+	}
+	return s.transition(leave, model.StatusPending, status, request, ErrNotPending)
+}
+
+func (s *service) transition(leave *model.LeaveRequest, from string, to string, request *dto.LeaveReviewRequest, stale error) (dto.LeaveResponse, error) {
+	ok, err := s.repo.Transition(leave.ID, from, to, request.By, request.Note)
+	if err != nil {
+		return dto.LeaveResponse{}, err
+	}
+	if !ok {
+		return dto.LeaveResponse{}, stale
+	}
+
+	leave, err = s.find(leave.ID)
+	if err != nil {
+		return dto.LeaveResponse{}, err
+	}
+	return dto.LeaveResponse{Data: toData(leave)}, nil
+}
+
+// checkBalance ensures a paid leave request fits in the balance of its year as accrued by the month it ends.
+// reserved is the part of the pending days that belongs to the request itself.
+func (s *service) checkBalance(leave *model.LeaveRequest, leaveType *model.LeaveType, reserved int) error {
+	if !leaveType.IsPaid {
+		return nil
+	}
+
+	asOf := leave.EndDate
+	if today := s.today(); today.After(asOf) {
+		asOf = today
+	}
+	balance, err := s.balance(leave.UserID, leaveType, leave.StartDate.Year(), asOf)
+	if err != nil {
+		return err
+	}
+	if balance.Available.Add(decimal.NewFromInt(int64(reserved))).LessThan(decimal.NewFromInt(int64(leave.Days))) {
+		return ErrInsufficientBalance
+	}
+	return nil
+}
+
+// balance computes the balance of a leave type in a year as accrued on asOf, opening the year if needed.
+func (s *service) balance(userID int64, leaveType *model.LeaveType, year int, asOf time.Time) (dto.BalanceData, error) {
+	balance, err := s.repo.FindBalance(userID, leaveType.ID, year)
+	if errors.Is(err, sql.ErrNoRows) {
+		balance = &model.Balance{UserID: userID, LeaveTypeID: leaveType.ID, Year: year, Entitled: leaveType.YearlyAllowance}
+		err = s.repo.CreateBalance(balance)
+	}
+	if err != nil {
+		return dto.BalanceData{}, err
+	}
+
+	carried, err := s.carryOver(userID, leaveType, year-1)
+	if err != nil {
+		return dto.BalanceData{}, err
+	}
+	usage, err := s.repo.Usage(userID, leaveType.ID, year)
+	if err != nil {
+		return dto.BalanceData{}, err
+	}
+
+	accrued := accrue(leaveType.Accrual, balance.Entitled, year, asOf)
+	return dto.BalanceData{
+		LeaveTypeID: leaveType.ID,
+		TypeCode:    leaveType.Code,
+		TypeName:    leaveType.Name,
+		Year:        year,
+		Entitled:    balance.Entitled,
+		Accrued:     accrued,
+		CarriedOver: carried,
+		Used:        usage.Approved,
+		Pending:     usage.Pending,
+		Available:   accrued.Add(carried).Sub(decimal.NewFromInt(int64(usage.Approved + usage.Pending))),
+	}, nil
+}
+
+// carryOver returns the days carried from a year into the next: what was left unused at its end,
+// up to the maximum of the leave type. A year that was never opened carries nothing.
+func (s *service) carryOver(userID int64, leaveType *model.LeaveType, year int) (decimal.Decimal, error) {
+	if !leaveType.MaxCarryOver.IsPositive() {
+		return decimal.Zero, nil
+	}
+
+	balance, err := s.repo.FindBalance(userID, leaveType.ID, year)
+	if errors.Is(err, sql.ErrNoRows) {
+		return decimal.Zero, nil
+	}
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	carried, err := s.carryOver(userID, leaveType, year-1)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	usage, err := s.repo.Usage(userID, leaveType.ID, year)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	left := balance.Entitled.Add(carried).Sub(decimal.NewFromInt(int64(usage.Approved)))
+	if left.IsNegative() {
+		return decimal.Zero, nil
+	}
+	return decimal.Min(left, leaveType.MaxCarryOver), nil
+}
+
+func (s *service) find(id int64) (*model.LeaveRequest, error) {
+	leave, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrLeaveNotFound
+		}
+		return nil, err
+	}
+	return leave, nil
+}
+
+func (s *service) findType(id int64) (*model.LeaveType, error) {
+	leaveType, err := s.repo.FindType(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrLeaveTypeNotFound
+		}
+		return nil, err
+	}
+	return leaveType, nil
+}
+
+func (s *service) checkUnlocked(from time.Time, to time.Time) error {
+	if s.periods == nil {
+		return nil
+	}
+
+	locked, err := s.periods.IsLocked(from, &to)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return ErrPeriodLocked
+	}
+	return nil
+}
+
+// checkUnissued ensures no payslip of the user was issued for a month the leave falls in: that payslip
+// neither deducted the unpaid days nor excused the absences, and it cannot be changed any more.
+func (s *service) checkUnissued(leave *model.LeaveRequest) error {
+	if s.payslips == nil {
+		return nil
+	}
+
+	last := leave.EndDate.Format(payslipmodel.PeriodLayout)
+	for month := leave.StartDate; ; month = month.AddDate(0, 1, 0) {
+		period := month.Format(payslipmodel.PeriodLayout)
+		payslips, err := s.payslips.Fetch(&payslipmodel.PayslipFilter{UserID: leave.UserID, Period: period})
+		if err != nil {
+			return err
+		}
+		if len(payslips) > 0 {
+			return ErrPayslipIssued
+		}
+		if period == last {
+			return nil
+		}
+	}
+}
+
+// today returns the current date as a UTC midnight like the dates read from the database.
+func (s *service) today() time.Time {
+	now := s.now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// accrue returns how much of a yearly entitlement has accrued in a year on asOf.
+// Monthly accrual grants a twelfth of it at the start of every month.
+func accrue(method string, entitled decimal.Decimal, year int, asOf time.Time) decimal.Decimal {
+	if method != model.AccrualMonthly || asOf.Year() > year {
+		return entitled
+	}
+	if asOf.Year() < year {
+		return decimal.Zero
+	}
+	return entitled.Mul(decimal.NewFromInt(int64(asOf.Month()))).Div(decimal.NewFromInt(12)).Round(2)
+}
+
+func isWorkingDay(day time.Time) bool {
+	return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+}
+
+// workingDays counts the Monday to Friday days from `from` through `to`, inclusive.
+func workingDays(from time.Time, to time.Time) int {
+	days := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if isWorkingDay(day) {
+			days++
+		}
+	}
+	return days
+}
+
+func minDate(a time.Time, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxDate(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func toTypeData(leaveType *model.LeaveType) dto.LeaveTypeData {
+	return dto.LeaveTypeData{
+		ID:              leaveType.ID,
+		Code:            leaveType.Code,
+		Name:            leaveType.Name,
+		IsPaid:          leaveType.IsPaid,
+		YearlyAllowance: leaveType.YearlyAllowance,
+		Accrual:         leaveType.Accrual,
+		MaxCarryOver:    leaveType.MaxCarryOver,
+		IsActive:        leaveType.IsActive,
+	}
+}
+
+func toData(leave *model.LeaveRequest) dto.LeaveData {
+	data := dto.LeaveData{
+		ID:          leave.ID,
+		UserID:      leave.UserID,
+		FullName:    leave.FullName,
+		LeaveTypeID: leave.LeaveTypeID,
+		TypeCode:    leave.TypeCode,
+		TypeName:    leave.TypeName,
+		StartDate:   leave.StartDate.Format(dateLayout),
+		EndDate:     leave.EndDate.Format(dateLayout),
+		Days:        leave.Days,
+		Reason:      leave.Reason,
+		Status:      leave.Status,
+		ReviewNote:  leave.ReviewNote,
+	}
+	for _, transition := range leave.Transitions {
+		data.Transitions = append(data.Transitions, dto.TransitionData{
+			FromStatus: transition.FromStatus,
+			ToStatus:   transition.ToStatus,
+			Note:       transition.Note,
+			By:         transition.CreatedBy,
+			ByName:     transition.CreatedByName,
+			At:         transition.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return data
+}
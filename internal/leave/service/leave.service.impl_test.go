@@ -0,0 +1,311 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	mockrepo "github.com/dwilanang/psp/internal/leave/repository/mocks"
+	"github.com/dwilanang/psp/internal/payroll/lock"
+
+	"github.com/dwilanang/psp/internal/leave/dto"
+	"github.com/dwilanang/psp/internal/leave/model"
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+)
+
+func day(value string) time.Time {
+	t, _ := time.Parse(dateLayout, value)
+	return t
+}
+
+var annual = &model.LeaveType{ID: 1, Code: "annual", Name: "Annual Leave", IsPaid: true, IsActive: true,
+	YearlyAllowance: decimal.NewFromInt(12), Accrual: model.AccrualMonthly, MaxCarryOver: decimal.NewFromInt(5)}
+
+var sick = &model.LeaveType{ID: 2, Code: "sick", Name: "Sick Leave", IsPaid: true, IsActive: true,
+	YearlyAllowance: decimal.NewFromInt(14), Accrual: model.AccrualYearly}
+
+var unpaid = &model.LeaveType{ID: 3, Code: "unpaid", Name: "Unpaid Leave", IsActive: true, Accrual: model.AccrualYearly}
+
+type fakePeriodLocker struct {
+	locked bool
+}
+
+func (f *fakePeriodLocker) IsLocked(from time.Time, to *time.Time) (bool, error) {
+	return f.locked, nil
+}
+
+func newTestService(mockRepo *mockrepo.MockRepository, periods lock.PeriodLocker) *service {
+	svc := NewService(mockRepo, nil, periods)
+	svc.now = func() time.Time { return time.Date(2025, 3, 15, 10, 0, 0, 0, time.UTC) }
+	return svc
+}
+
+// expectAnnualBalance sets up a 2025 annual balance carrying 5 days over from 2024, when 4 of 12 days were used.
+func expectAnnualBalance(mockRepo *mockrepo.MockRepository, usage model.Usage) {
+	mockRepo.EXPECT().FindBalance(int64(7), int64(1), 2025).Return(&model.Balance{UserID: 7, LeaveTypeID: 1, Year: 2025, Entitled: decimal.NewFromInt(12)}, nil)
+	mockRepo.EXPECT().FindBalance(int64(7), int64(1), 2024).Return(&model.Balance{UserID: 7, LeaveTypeID: 1, Year: 2024, Entitled: decimal.NewFromInt(12)}, nil)
+	mockRepo.EXPECT().FindBalance(int64(7), int64(1), 2023).Return(nil, sql.ErrNoRows)
+	mockRepo.EXPECT().Usage(int64(7), int64(1), 2024).Return(model.Usage{Approved: 4}, nil)
+	mockRepo.EXPECT().Usage(int64(7), int64(1), 2025).Return(usage, nil)
+}
+
+func TestService_Balances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := newTestService(mockRepo, nil)
+
+	mockRepo.EXPECT().FetchTypes().Return([]*model.LeaveType{annual, sick, unpaid}, nil)
+	expectAnnualBalance(mockRepo, model.Usage{Approved: 1, Pending: 1})
+	// The sick leave year is opened on first use.
+	mockRepo.EXPECT().FindBalance(int64(7), int64(2), 2025).Return(nil, sql.ErrNoRows)
+	mockRepo.EXPECT().CreateBalance(gomock.AssignableToTypeOf(&model.Balance{})).DoAndReturn(func(balance *model.Balance) error {
+		assert.Equal(t, "14", balance.Entitled.String())
+		balance.ID = 9
+		return nil
+	})
+	mockRepo.EXPECT().Usage(int64(7), int64(2), 2025).Return(model.Usage{}, nil)
+
+	resp, err := svc.Balances(7, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Data, 2)
+	// Three months of a monthly allowance, plus the capped carry-over, minus approved and pending days.
+	assert.Equal(t, "3", resp.Data[0].Accrued.String())
+	assert.Equal(t, "5", resp.Data[0].CarriedOver.String())
+	assert.Equal(t, "6", resp.Data[0].Available.String())
+	assert.Equal(t, "14", resp.Data[1].Available.String())
+}
+
+func TestService_Request(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := newTestService(mockRepo, &fakePeriodLocker{})
+
+	mockRepo.EXPECT().FindType(int64(1)).Return(annual, nil)
+	expectAnnualBalance(mockRepo, model.Usage{})
+	mockRepo.EXPECT().Create(gomock.AssignableToTypeOf(&model.LeaveRequest{})).DoAndReturn(func(leave *model.LeaveRequest) error {
+		leave.ID = 1
+		return nil
+	})
+
+	// Friday to Tuesday spans three working days.
+	resp, err := svc.Request(&dto.LeaveRequest{LeaveTypeID: 1, StartDate: "2025-03-21", EndDate: "2025-03-25", Reason: "Family event", By: 7})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, resp.Data.Days)
+	assert.Equal(t, model.StatusPending, resp.Data.Status)
+}
+
+func TestService_Request_AccruesByEndDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := newTestService(mockRepo, nil)
+
+	// Nine days fit in what has accrued by the end of May with the carry-over, but not in what has accrued by March.
+	mockRepo.EXPECT().FindType(int64(1)).Return(annual, nil).Times(2)
+	expectAnnualBalance(mockRepo, model.Usage{})
+	expectAnnualBalance(mockRepo, model.Usage{})
+	mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	_, err := svc.Request(&dto.LeaveRequest{LeaveTypeID: 1, StartDate: "2025-03-17", EndDate: "2025-03-27", By: 7})
+	assert.ErrorIs(t, err, ErrInsufficientBalance)
+
+	_, err = svc.Request(&dto.LeaveRequest{LeaveTypeID: 1, StartDate: "2025-05-19", EndDate: "2025-05-29", By: 7})
+	assert.NoError(t, err)
+}
+
+func TestService_Request_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := newTestService(mockRepo, nil)
+
+	_, err := svc.Request(&dto.LeaveRequest{LeaveTypeID: 3, StartDate: "2025-12-31", EndDate: "2026-01-02", By: 7})
+	assert.ErrorIs(t, err, ErrInvalidDates)
+
+	_, err = svc.Request(&dto.LeaveRequest{LeaveTypeID: 3, StartDate: "2025-03-22", EndDate: "2025-03-23", By: 7})
+	assert.ErrorIs(t, err, ErrNoWorkingDays)
+
+	// Unpaid leave is not limited by a balance, but may not overlap another request.
+	mockRepo.EXPECT().FindType(int64(3)).Return(unpaid, nil)
+	mockRepo.EXPECT().Create(gomock.Any()).Return(sql.ErrNoRows)
+	_, err = svc.Request(&dto.LeaveRequest{LeaveTypeID: 3, StartDate: "2025-03-24", EndDate: "2025-03-24", By: 7})
+	assert.ErrorIs(t, err, ErrOverlap)
+
+	svc.periods = &fakePeriodLocker{locked: true}
+	mockRepo.EXPECT().FindType(int64(3)).Return(unpaid, nil)
+	_, err = svc.Request(&dto.LeaveRequest{LeaveTypeID: 3, StartDate: "2025-02-24", EndDate: "2025-02-24", By: 7})
+	assert.ErrorIs(t, err, ErrPeriodLocked)
+}
+
+func TestService_Approve_CountsOwnPendingDays(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := newTestService(mockRepo, nil)
+
+	pending := &model.LeaveRequest{ID: 1, UserID: 7, LeaveTypeID: 1, StartDate: day("2025-03-17"), EndDate: day("2025-03-24"), Days: 6, Status: model.StatusPending}
+	gomock.InOrder(
+		mockRepo.EXPECT().FindByID(int64(1)).Return(pending, nil),
+		mockRepo.EXPECT().FindType(int64(1)).Return(annual, nil),
+		mockRepo.EXPECT().Transition(int64(1), model.StatusPending, model.StatusApproved, int64(2), "ok").Return(true, nil),
+		mockRepo.EXPECT().FindByID(int64(1)).Return(&model.LeaveRequest{ID: 1, UserID: 7, Status: model.StatusApproved}, nil),
+	)
+	// The 6 pending days are the request itself, so all 8 days are still available to it.
+	expectAnnualBalance(mockRepo, model.Usage{Pending: 6})
+
+	resp, err := svc.Approve(&dto.LeaveReviewRequest{ID: 1, Note: "ok", By: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusApproved, resp.Data.Status)
+}
+
+// fakePayslipFetcher reports the periods in which payslips were issued.
+type fakePayslipFetcher struct {
+	issued map[string]bool
+}
+
+func (f *fakePayslipFetcher) Fetch(filter *payslipmodel.PayslipFilter) ([]*payslipmodel.Payslip, error) {
+	if f.issued[filter.Period] {
+		return []*payslipmodel.Payslip{{UserID: filter.UserID, Period: filter.Period}}, nil
+	}
+	return nil, nil
+}
+
+func TestService_Approve_PayslipIssued(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := newTestService(mockRepo, &fakePeriodLocker{})
+	payslips := &fakePayslipFetcher{}
+	svc.payslips = payslips
+
+	pending := &model.LeaveRequest{ID: 1, UserID: 7, LeaveTypeID: 3, StartDate: day("2025-02-27"), EndDate: day("2025-03-04"), Days: 4, Status: model.StatusPending}
+	gomock.InOrder(
+		mockRepo.EXPECT().FindByID(int64(1)).Return(pending, nil),
+		mockRepo.EXPECT().FindType(int64(3)).Return(unpaid, nil),
+		mockRepo.EXPECT().Transition(int64(1), model.StatusPending, model.StatusApproved, int64(2), "").Return(true, nil),
+		mockRepo.EXPECT().FindByID(int64(1)).Return(&model.LeaveRequest{ID: 1, UserID: 7, Status: model.StatusApproved}, nil),
+		mockRepo.EXPECT().FindByID(int64(1)).Return(pending, nil),
+	)
+
+	// Approved before the February payslip is issued, the unpaid days are deducted from it.
+	resp, err := svc.Approve(&dto.LeaveReviewRequest{ID: 1, By: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusApproved, resp.Data.Status)
+
+	// Approved after it, the February payslip can no longer deduct them.
+	payslips.issued = map[string]bool{"2025-02": true}
+	_, err = svc.Approve(&dto.LeaveReviewRequest{ID: 1, By: 2})
+	assert.ErrorIs(t, err, ErrPayslipIssued)
+}
+
+func TestService_Review_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := newTestService(mockRepo, nil)
+
+	mockRepo.EXPECT().FindByID(int64(1)).Return(&model.LeaveRequest{ID: 1, UserID: 7, Status: model.StatusPending}, nil).Times(2)
+	mockRepo.EXPECT().FindByID(int64(2)).Return(&model.LeaveRequest{ID: 2, UserID: 7, Status: model.StatusApproved, StartDate: day("2025-03-10")}, nil).Times(2)
+	mockRepo.EXPECT().FindByID(int64(3)).Return(nil, sql.ErrNoRows)
+
+	_, err := svc.Approve(&dto.LeaveReviewRequest{ID: 1, By: 7})
+	assert.ErrorIs(t, err, ErrSelfReview)
+
+	// Only the owner may cancel, and someone else's request is not disclosed.
+	_, err = svc.Cancel(&dto.LeaveReviewRequest{ID: 1, By: 8})
+	assert.ErrorIs(t, err, ErrLeaveNotFound)
+
+	_, err = svc.Reject(&dto.LeaveReviewRequest{ID: 2, By: 2})
+	assert.ErrorIs(t, err, ErrNotPending)
+
+	// Approved leave that has already started cannot be withdrawn.
+	_, err = svc.Cancel(&dto.LeaveReviewRequest{ID: 2, By: 7})
+	assert.ErrorIs(t, err, ErrNotCancellable)
+
+	_, err = svc.Reject(&dto.LeaveReviewRequest{ID: 3, By: 2})
+	assert.ErrorIs(t, err, ErrLeaveNotFound)
+}
+
+func TestService_Cancel_ApprovedBeforeStart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := newTestService(mockRepo, &fakePeriodLocker{})
+
+	gomock.InOrder(
+		mockRepo.EXPECT().FindByID(int64(1)).Return(&model.LeaveRequest{ID: 1, UserID: 7, Status: model.StatusApproved, StartDate: day("2025-04-01"), EndDate: day("2025-04-02")}, nil),
+		mockRepo.EXPECT().Transition(int64(1), model.StatusApproved, model.StatusCancelled, int64(7), "").Return(true, nil),
+		mockRepo.EXPECT().FindByID(int64(1)).Return(&model.LeaveRequest{ID: 1, UserID: 7, Status: model.StatusCancelled}, nil),
+	)
+
+	resp, err := svc.Cancel(&dto.LeaveReviewRequest{ID: 1, By: 7})
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusCancelled, resp.Data.Status)
+}
+
+func TestService_LeaveDays(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := newTestService(mockRepo, nil)
+
+	mockRepo.EXPECT().FetchApproved(int64(7), day("2025-03-01"), day("2025-03-31")).Return([]*model.LeaveRequest{
+		{StartDate: day("2025-02-27"), EndDate: day("2025-03-04")},
+	}, nil)
+
+	days, err := svc.LeaveDays(7, day("2025-03-01"), day("2025-03-31"))
+
+	// The weekend and the February days are left out.
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{day("2025-03-03"), day("2025-03-04")}, days)
+}
+
+type fakeApprovedFetcher struct {
+	leaves []*model.LeaveRequest
+}
+
+func (f *fakeApprovedFetcher) FetchApproved(userID int64, from time.Time, to time.Time) ([]*model.LeaveRequest, error) {
+	return f.leaves, nil
+}
+
+func TestPayslipContributor(t *testing.T) {
+	period, _ := payslipmodel.ParsePeriod("2025-01")
+	calc := &payslipmodel.Calculation{UserID: 7, Period: period, BaseSalary: decimal.NewFromInt(4600000)}
+
+	items, err := NewPayslipContributor(&fakeApprovedFetcher{leaves: []*model.LeaveRequest{
+		{IsPaid: true, StartDate: day("2025-01-06"), EndDate: day("2025-01-07")},
+	}}).Contribute(calc)
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	// January 2025 has 23 working days; two unpaid days fall in it.
+	items, err = NewPayslipContributor(&fakeApprovedFetcher{leaves: []*model.LeaveRequest{
+		{IsPaid: true, StartDate: day("2025-01-06"), EndDate: day("2025-01-07")},
+		{StartDate: day("2025-01-30"), EndDate: day("2025-02-04")},
+	}}).Contribute(calc)
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "UNPAID_LEAVE", items[0].Code)
+	assert.Equal(t, "Unpaid Leave (2 days)", items[0].Name)
+	assert.Equal(t, payslipmodel.TypeDeduction, items[0].Type)
+	assert.Equal(t, "400000.00", items[0].Amount.StringFixed(2))
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/dwilanang/psp/pkg/logger"
+	"github.com/dwilanang/psp/utils/response"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrorHandler returns a Gin middleware handler that writes the error response of a request.
+//
+// Handlers and middleware report errors with response.Error instead of writing a response themselves.
+// Once the request is handled, the last reported error is written as an RFC 7807 problem whose status is
+// mapped from its apperror.Kind: NotFound to 404, Conflict to 409, Validation to 400, Unprocessable to 422,
+// Unauthorized to 401 and Forbidden to 403; the invalid fields of a validation error are listed in it.
+// Any other error is logged with the request ID and answered with 500 and the handler's fallback message,
+// so database errors and the like never reach the client.
+//
+// Returns:
+//   - gin.HandlerFunc: the middleware function, to be registered before every route.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		last := c.Errors.Last()
+		fallback, _ := last.Meta.(string)
+		if fallback == "" {
+			fallback = http.StatusText(http.StatusInternalServerError)
+		}
+
+		kind := apperror.KindOf(last.Err)
+		if kind == apperror.KindInternal {
+			logrus.WithFields(logrus.Fields{
+				"request_id": logger.GetRequestID(c),
+				"method":     c.Request.Method,
+				"path":       c.Request.URL.Path,
+			}).WithError(last.Err).Error(fallback)
+		}
+
+		problem := response.NewProblem(c, apperror.Status(kind), apperror.Message(last.Err, fallback), apperror.FieldsOf(last.Err))
+		response.WriteProblem(c, problem)
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/dwilanang/psp/pkg/logger"
+	"github.com/dwilanang/psp/utils/response"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveError(t *testing.T, err error) (*httptest.ResponseRecorder, response.Problem) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(logger.RequestLogger(), ErrorHandler())
+	r.GET("/roles/7", func(c *gin.Context) {
+		response.Error(c, err, "could not update role")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/roles/7", nil))
+
+	var problem response.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	return w, problem
+}
+
+func TestErrorHandler(t *testing.T) {
+	w, problem := serveError(t, apperror.Wrap(apperror.KindNotFound, "role not found", sql.ErrNoRows))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, response.ProblemContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "/problems/not-found", problem.Type)
+	assert.Equal(t, "Not Found", problem.Title)
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+	assert.Equal(t, "role not found", problem.Detail)
+	assert.Equal(t, "/roles/7", problem.Instance)
+	assert.NotEmpty(t, problem.RequestID)
+	assert.Equal(t, w.Header().Get("X-Request-ID"), problem.RequestID)
+
+	w, problem = serveError(t, apperror.Conflict("role already exists"))
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "/problems/conflict", problem.Type)
+
+	w, _ = serveError(t, apperror.Forbidden("you cannot review your own leave request"))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w, problem = serveError(t, errors.New("pq: connection reset"))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "could not update role", problem.Detail)
+}
+
+func TestErrorHandler_InvalidFields(t *testing.T) {
+	w, problem := serveError(t, apperror.InvalidFields("one or more fields are invalid", map[string]string{
+		"Privilege": "This field is required",
+		"Name":      "This field is required",
+	}))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, []response.FieldError{
+		{Field: "Name", Message: "This field is required"},
+		{Field: "Privilege", Message: "This field is required"},
+	}, problem.Errors)
+}
@@ -1,54 +1,72 @@
 package middleware
 
 import (
-	"net/http"
-
 	"github.com/dwilanang/psp/internal/auth/model"
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/dwilanang/psp/utils/response"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// RevocationChecker reports whether an otherwise valid token has been revoked.
+type RevocationChecker interface {
+	IsRevoked(claims *model.TokenClaims) (bool, error)
+}
+
 // JWTAuthMiddleware returns a Gin middleware handler that performs JWT authentication.
 //
 // It expects the incoming HTTP request to have an "Authorization" header with the format:
 //
 //	"Bearer <token>"
 //
-// The middleware extracts the JWT token from the header, parses it using the provided secret key,
-// and validates the token's signature and claims.
+// The middleware extracts the JWT token from the header, parses it using the verification key selected by
+// keyfunc (usually from the token's "kid" header), and validates the token's signature and claims.
 //
-// If the token is missing, malformed, or invalid, the middleware aborts the request with a 401 Unauthorized status
-// and a JSON error message.
+// If the token is missing, malformed, or invalid, the middleware aborts the request with a 401 Unauthorized problem.
+//
+// Tokens that pass validation are then checked against the revocation list, so tokens revoked through logout
+// or by an administrator are rejected with 401 Unauthorized even though their signature is still valid.
 //
 // On successful validation, the parsed token claims are saved into the Gin context with the key "user",
 // allowing subsequent handlers to access authenticated user information.
 //
 // Parameters:
-//   - secret: the secret key string used to validate the JWT token signature.
+//   - keyfunc: resolves the key used to validate the JWT token signature.
+//   - revocations: the checker consulted for revoked tokens; nil disables the check.
 //
 // Returns:
 //   - gin.HandlerFunc: the middleware function to be used in Gin routes.
-func JWTAuthMiddleware(secret string) gin.HandlerFunc {
+func JWTAuthMiddleware(keyfunc jwt.Keyfunc, revocations RevocationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 
 		if len(authHeader) < 7 || authHeader[:7] != "Bearer " {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid token"})
+			response.Error(c, apperror.Unauthorized("missing or invalid token"), "")
 			return
 		}
 
 		tokenStr := authHeader[7:]
 		claims := &model.TokenClaims{}
 
-		token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-			return []byte(secret), nil
-		})
+		token, err := jwt.ParseWithClaims(tokenStr, claims, keyfunc)
 
 		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			response.Error(c, apperror.Unauthorized("invalid token"), "")
 			return
 		}
 
+		if revocations != nil {
+			revoked, err := revocations.IsRevoked(claims)
+			if err != nil {
+				response.Error(c, err, "could not verify token")
+				return
+			}
+			if revoked {
+				response.Error(c, apperror.Unauthorized("token has been revoked"), "")
+				return
+			}
+		}
+
 		// save struct on context
 		c.Set("user", claims)
 		c.Next()
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"github.com/dwilanang/psp/internal/auth/model"
+	"github.com/dwilanang/psp/internal/permission"
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/dwilanang/psp/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionResolver resolves the permissions granted to a role name.
+type PermissionResolver interface {
+	Permissions(role string) (permission.Set, error)
+}
+
+// RequirePermission returns a Gin middleware handler that enforces permission-based access control.
+//
+// The middleware reads the authenticated user's role from the token claims stored in the Gin context
+// under the key "user", resolves the role's permissions and checks that every required permission is granted,
+// either directly (e.g. "users:create") or through a wildcard ("users:*" or "*").
+//
+// If the user is not authenticated or the token claims are invalid, it aborts the request with
+// a 401 Unauthorized status. If any required permission is missing, it aborts with a 403 Forbidden status.
+//
+// Parameters:
+//   - resolver: resolves a role name to its permissions.
+//   - required: the permissions the caller must hold.
+//
+// Returns:
+//   - gin.HandlerFunc: the middleware function that enforces permission restrictions.
+func RequirePermission(resolver PermissionResolver, required ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		val, exists := c.Get("user")
+		if !exists {
+			response.Error(c, apperror.Unauthorized("Unauthorized"), "")
+			return
+		}
+
+		claims, ok := val.(*model.TokenClaims)
+		if !ok {
+			response.Error(c, apperror.Unauthorized("Invalid token claims"), "")
+			return
+		}
+
+		set, err := resolver.Permissions(claims.Role)
+		if err != nil {
+			response.Error(c, err, "could not resolve permissions")
+			return
+		}
+
+		if set.AllowsAll(required...) {
+			c.Set("permissions", set)
+			c.Next()
+			return
+		}
+
+		response.Error(c, apperror.Forbidden("Forbidden: insufficient privileges"), "")
+	}
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+type OvertimeRequest struct {
+	WorkDate string          `json:"work_date" binding:"required" example:"2025-01-18"`
+	Hours    decimal.Decimal `json:"hours" binding:"required" swaggertype:"string" example:"3.5"`
+	Reason   string          `json:"reason" binding:"required" example:"Month-end closing"`
+	By       int64           `json:"by" swaggerignore:"true"`
+}
+
+type OvertimeFilterRequest struct {
+	UserID int64  `form:"user_id"`
+	Status string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	Period string `form:"period" example:"2025-01"`
+}
+
+type OvertimeReviewRequest struct {
+	ID   int64  `json:"id" swaggerignore:"true"`
+	Note string `json:"note"`
+	By   int64  `json:"by" swaggerignore:"true"`
+}
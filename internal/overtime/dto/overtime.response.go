@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+type OvertimeResponse struct {
+	Data OvertimeData `json:"data"`
+}
+
+type OvertimeListResponse struct {
+	Data []OvertimeData `json:"data"`
+}
+
+type OvertimeData struct {
+	ID          int64            `json:"id"`
+	UserID      int64            `json:"user_id"`
+	FullName    string           `json:"full_name"`
+	WorkDate    string           `json:"work_date" example:"2025-01-18"`
+	Hours       decimal.Decimal  `json:"hours" swaggertype:"string" example:"3.5"`
+	Reason      string           `json:"reason"`
+	Status      string           `json:"status" example:"pending"`
+	ReviewNote  string           `json:"review_note"`
+	PayPeriod   string           `json:"pay_period" example:"2025-01"`
+	Transitions []TransitionData `json:"transitions,omitempty"`
+}
+
+type TransitionData struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Note       string `json:"note"`
+	By         int64  `json:"by"`
+	ByName     string `json:"by_name"`
+	At         string `json:"at"`
+}
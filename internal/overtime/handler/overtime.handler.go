@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"github.com/dwilanang/psp/internal/auth/util"
+	"github.com/dwilanang/psp/internal/overtime"
+	"github.com/dwilanang/psp/internal/overtime/dto"
+	"github.com/dwilanang/psp/utils"
+	utilrequest "github.com/dwilanang/psp/utils/request"
+	"github.com/dwilanang/psp/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	Deps overtime.Dependencies
+}
+
+func NewHandler(deps overtime.Dependencies) *Handler {
+	return &Handler{
+		Deps: deps,
+	}
+}
+
+// Submit godoc
+// @Security BearerAuth
+// @Summary      Submit overtime
+// @Description  Submit a pending overtime request for the authenticated user
+// @Tags         overtime
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.OvertimeRequest  true  "Overtime request payload"
+// @Success      201   {object}  response.ApiResponse{data=dto.OvertimeData}
+// @Failure      400   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /overtime/submit [post]
+func (h *Handler) Submit(c *gin.Context) {
+	var or dto.OvertimeRequest
+	if err := c.ShouldBindJSON(&or); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	or.By = by
+
+	resp, err := h.Deps.Service.Submit(&or)
+	if err != nil {
+		response.Error(c, err, "could not submit overtime")
+		return
+	}
+	response.Created(c, resp.Data)
+}
+
+// Mine godoc
+// @Security BearerAuth
+// @Summary      Get my overtime
+// @Description  Get the overtime requests of the authenticated user
+// @Tags         overtime
+// @Accept       json
+// @Produce      json
+// @Param        status  query     string  false  "Status (pending, approved or rejected)"
+// @Param        period  query     string  false  "Period (YYYY-MM)"
+// @Success      200  {object}  response.ApiResponse{data=[]dto.OvertimeData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /overtime/me [get]
+func (h *Handler) Mine(c *gin.Context) {
+	var fr dto.OvertimeFilterRequest
+	if err := c.ShouldBindQuery(&fr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	id, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	fr.UserID = id
+
+	h.list(c, &fr)
+}
+
+// GetAll godoc
+// @Security BearerAuth
+// @Summary      Get overtime requests
+// @Description  Get the overtime requests of every user, optionally filtered by user, status and period
+// @Tags         overtime
+// @Accept       json
+// @Produce      json
+// @Param        user_id  query     int     false  "User ID"
+// @Param        status   query     string  false  "Status (pending, approved or rejected)"
+// @Param        period   query     string  false  "Period (YYYY-MM)"
+// @Success      200  {object}  response.ApiResponse{data=[]dto.OvertimeData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /overtime/all [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	var fr dto.OvertimeFilterRequest
+	if err := c.ShouldBindQuery(&fr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+	h.list(c, &fr)
+}
+
+// Get godoc
+// @Security BearerAuth
+// @Summary      Get overtime request
+// @Description  Get an overtime request with the history of its status transitions
+// @Tags         overtime
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Overtime ID"
+// @Success      200  {object}  response.ApiResponse{data=dto.OvertimeData}
+// @Failure      404  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /overtime/detail/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	resp, err := h.Deps.Service.Get(utils.ConvertStringToInt(c.Param("id")))
+	if err != nil {
+		response.Error(c, err, "could not fetch overtime")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// Approve godoc
+// @Security BearerAuth
+// @Summary      Approve overtime
+// @Description  Approve a pending overtime request so it is paid on the payslip of the month it was worked, or of the next month without a payslip when that one was already issued
+// @Tags         overtime
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                        true  "Overtime ID"
+// @Param        body  body      dto.OvertimeReviewRequest  true  "Review payload"
+// @Success      200   {object}  response.ApiResponse{data=dto.OvertimeData}
+// @Failure      400   {object}  response.Problem
+// @Failure      403   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /overtime/approve/{id} [put]
+func (h *Handler) Approve(c *gin.Context) {
+	h.review(c, h.Deps.Service.Approve, "could not approve overtime")
+}
+
+// Reject godoc
+// @Security BearerAuth
+// @Summary      Reject overtime
+// @Description  Reject a pending overtime request
+// @Tags         overtime
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                        true  "Overtime ID"
+// @Param        body  body      dto.OvertimeReviewRequest  true  "Review payload"
+// @Success      200   {object}  response.ApiResponse{data=dto.OvertimeData}
+// @Failure      400   {object}  response.Problem
+// @Failure      403   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /overtime/reject/{id} [put]
+func (h *Handler) Reject(c *gin.Context) {
+	h.review(c, h.Deps.Service.Reject, "could not reject overtime")
+}
+
+func (h *Handler) list(c *gin.Context, request *dto.OvertimeFilterRequest) {
+	resp, err := h.Deps.Service.List(request)
+	if err != nil {
+		response.Error(c, err, "could not fetch overtime")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+func (h *Handler) review(c *gin.Context, decide func(*dto.OvertimeReviewRequest) (dto.OvertimeResponse, error), message string) {
+	var rr dto.OvertimeReviewRequest
+	if err := c.ShouldBindJSON(&rr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	rr.ID = utils.ConvertStringToInt(c.Param("id"))
+	rr.By = by
+
+	resp, err := decide(&rr)
+	if err != nil {
+		response.Error(c, err, message)
+		return
+	}
+	response.OK(c, resp.Data)
+}
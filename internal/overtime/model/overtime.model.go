@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Overtime request statuses. A request is submitted pending and is then approved or rejected once.
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+type Overtime struct {
+	ID          int64           `db:"id"`
+	UserID      int64           `db:"user_id"`
+	FullName    string          `db:"full_name"`
+	WorkDate    time.Time       `db:"work_date"`
+	Hours       decimal.Decimal `db:"hours"`
+	Reason      string          `db:"reason"`
+	Status      string          `db:"status"`
+	ReviewNote  string          `db:"review_note"`
+	PayPeriod   string          `db:"pay_period"`
+	CreatedBy   int64           `db:"created_by"`
+	CreatedAt   time.Time       `db:"created_at"`
+	UpdatedBy   int64           `db:"updated_by"`
+	UpdatedAt   time.Time       `db:"updated_at"`
+	Transitions []*Transition   `db:"-"`
+}
+
+// Transition records who moved a request between two statuses and when.
+// FromStatus is empty for the submission.
+type Transition struct {
+	ID            int64     `db:"id"`
+	OvertimeID    int64     `db:"overtime_id"`
+	FromStatus    string    `db:"from_status"`
+	ToStatus      string    `db:"to_status"`
+	Note          string    `db:"note"`
+	CreatedBy     int64     `db:"created_by"`
+	CreatedByName string    `db:"created_by_name"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+type OvertimeFilter struct {
+	UserID int64
+	Status string
+	From   *time.Time
+	To     *time.Time
+}
@@ -0,0 +1,11 @@
+package overtime
+
+import (
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/overtime/service"
+)
+
+type Dependencies struct {
+	DBPostgres *postgres.DB
+	Service    service.Service
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: overtime.repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	model "github.com/dwilanang/psp/internal/overtime/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(overtime *model.Overtime) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", overtime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(overtime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), overtime)
+}
+
+// Fetch mocks base method.
+func (m *MockRepository) Fetch(filter *model.OvertimeFilter) ([]*model.Overtime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fetch", filter)
+	ret0, _ := ret[0].([]*model.Overtime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fetch indicates an expected call of Fetch.
+func (mr *MockRepositoryMockRecorder) Fetch(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockRepository)(nil).Fetch), filter)
+}
+
+// FetchPayable mocks base method.
+func (m *MockRepository) FetchPayable(userID int64, period string) ([]*model.Overtime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPayable", userID, period)
+	ret0, _ := ret[0].([]*model.Overtime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchPayable indicates an expected call of FetchPayable.
+func (mr *MockRepositoryMockRecorder) FetchPayable(userID, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPayable", reflect.TypeOf((*MockRepository)(nil).FetchPayable), userID, period)
+}
+
+// FindByID mocks base method.
+func (m *MockRepository) FindByID(id int64) (*model.Overtime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*model.Overtime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), id)
+}
+
+// Transition mocks base method.
+func (m *MockRepository) Transition(id int64, from, to string, by int64, note, payPeriod string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", id, from, to, by, note, payPeriod)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockRepositoryMockRecorder) Transition(id, from, to, by, note, payPeriod interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockRepository)(nil).Transition), id, from, to, by, note, payPeriod)
+}
@@ -0,0 +1,34 @@
+package repository
+
+import "github.com/dwilanang/psp/internal/overtime/model"
+
+//go:generate mockgen -source=overtime.repository.go -package=mocks -destination=mocks/mock_overtime_repository.go
+
+// Repository defines an interface for data operations related to overtime requests and their transitions.
+type Repository interface {
+	// Fetch retrieves overtime requests matching the filter, most recent work date first.
+	// Param: filter - a pointer to OvertimeFilter; zero fields are ignored.
+	// Returns a slice of Overtime pointers and an error if the operation fails.
+	Fetch(filter *model.OvertimeFilter) ([]*model.Overtime, error)
+
+	// FindByID retrieves an overtime request and its transitions by ID.
+	// Param: id - the ID of the overtime request.
+	// Returns a pointer to the Overtime and an error if the operation fails or the request is not found.
+	FindByID(id int64) (*model.Overtime, error)
+
+	// Create inserts a pending overtime request and its submission transition in a single transaction.
+	// Param: overtime - a pointer to the Overtime entity to be created.
+	// Returns sql.ErrNoRows if the user already has a live request for the day, or an error if the operation fails.
+	Create(overtime *model.Overtime) error
+
+	// Transition moves an overtime request from one status to another and records the transition.
+	// Param: id - the ID of the request; from, to - the expected and new status; by - the ID of the acting user;
+	// note - an optional note explaining the decision; payPeriod - the pay period of an approved request, or empty.
+	// Returns false if the request was not in the expected status, and an error if the operation fails.
+	Transition(id int64, from string, to string, by int64, note string, payPeriod string) (bool, error)
+
+	// FetchPayable retrieves the approved overtime of a user paid in a pay period.
+	// Param: userID - the ID of the user; period - the pay period code, e.g. 2025-01.
+	// Returns a slice of Overtime pointers and an error if the operation fails.
+	FetchPayable(userID int64, period string) ([]*model.Overtime, error)
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/overtime/model"
+	"github.com/jmoiron/sqlx"
+)
+
+const selectOvertime = `
+		SELECT 
+			o.id, o.user_id,
+			COALESCE(u.full_name, '') AS full_name,
+			o.work_date, o.hours, o.reason, o.status,
+			COALESCE(o.review_note, '') AS review_note,
+			COALESCE(o.pay_period, '') AS pay_period,
+			COALESCE(o.created_by, 0) AS created_by,
+			o.created_at,
+			COALESCE(o.updated_by, o.created_by, 0) AS updated_by,
+			COALESCE(o.updated_at, o.created_at) AS updated_at
+		FROM overtime_requests o
+		INNER JOIN users u ON(u.id=o.user_id)
+`
+
+type repository struct {
+	db *postgres.DB
+}
+
+func NewRepository(db *postgres.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Fetch(filter *model.OvertimeFilter) ([]*model.Overtime, error) {
+	overtimes := []*model.Overtime{}
+	query := selectOvertime + `
+		WHERE ($1 = 0 OR o.user_id = $1)
+		AND ($2 = '' OR o.status = $2)
+		AND ($3::date IS NULL OR o.work_date >= $3)
+		AND ($4::date IS NULL OR o.work_date <= $4)
+		ORDER BY o.work_date DESC, o.id DESC
+	`
+	err := r.db.Replica().Select(&overtimes, query, filter.UserID, filter.Status, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+	return overtimes, nil
+}
+
+func (r *repository) FindByID(id int64) (*model.Overtime, error) {
+	var overtime model.Overtime
+	query := selectOvertime + `
+		WHERE o.id = $1
+	`
+	err := r.db.Get(&overtime, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	transitionQuery := `
+		SELECT 
+			t.id, t.overtime_id,
+			COALESCE(t.from_status, '') AS from_status,
+			t.to_status,
+			COALESCE(t.note, '') AS note,
+			t.created_by,
+			COALESCE(u.full_name, '') AS created_by_name,
+			t.created_at
+		FROM overtime_transitions t
+		INNER JOIN users u ON(u.id=t.created_by)
+		WHERE t.overtime_id = $1
+		ORDER BY t.id
+	`
+	err = r.db.Select(&overtime.Transitions, transitionQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	return &overtime, nil
+}
+
+func (r *repository) Create(overtime *model.Overtime) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO overtime_requests (user_id, work_date, hours, reason, status, created_by, created_at, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), $6, NOW())
+		ON CONFLICT (user_id, work_date) WHERE status <> 'rejected' DO NOTHING
+		RETURNING id, created_at
+	`
+	err = tx.QueryRowx(
+		query,
+		overtime.UserID,
+		overtime.WorkDate,
+		overtime.Hours,
+		overtime.Reason,
+		overtime.Status,
+		overtime.CreatedBy,
+	).Scan(&overtime.ID, &overtime.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	err = insertTransition(tx, overtime.ID, "", overtime.Status, overtime.CreatedBy, "")
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *repository) Transition(id int64, from string, to string, by int64, note string, payPeriod string) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE overtime_requests SET status = $3, review_note = $4, pay_period = NULLIF($5, ''),
+			updated_by = $6, updated_at = NOW()
+		WHERE id = $1 AND status = $2
+	`
+	result, err := tx.Exec(query, id, from, to, note, payPeriod, by)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+
+	err = insertTransition(tx, id, from, to, by, note)
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+func (r *repository) FetchPayable(userID int64, period string) ([]*model.Overtime, error) {
+	overtimes := []*model.Overtime{}
+	query := selectOvertime + `
+		WHERE o.user_id = $1 AND o.status = 'approved' AND o.pay_period = $2
+		ORDER BY o.work_date
+	`
+	err := r.db.Select(&overtimes, query, userID, period)
+	if err != nil {
+		return nil, err
+	}
+	return overtimes, nil
+}
+
+func insertTransition(tx *sqlx.Tx, overtimeID int64, from string, to string, by int64, note string) error {
+	query := `
+		INSERT INTO overtime_transitions (overtime_id, from_status, to_status, note, created_by, created_at)
+		VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''), $5, NOW())
+	`
+	_, err := tx.Exec(query, overtimeID, from, to, note, by)
+	return err
+}
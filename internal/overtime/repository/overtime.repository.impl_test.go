@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/overtime/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*postgres.DB, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return postgres.NewDB(sqlx.NewDb(db, "postgres")), mock, func() { db.Close() }
+}
+
+func TestCreate_RecordsSubmission(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	day := time.Date(2025, 1, 18, 0, 0, 0, 0, time.UTC)
+	hours := decimal.RequireFromString("3.5")
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO overtime_requests").
+		WithArgs(int64(7), day, hours, "Month-end closing", model.StatusPending, int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+	mock.ExpectExec("INSERT INTO overtime_transitions").
+		WithArgs(int64(1), "", model.StatusPending, "", int64(7)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.Create(&model.Overtime{UserID: 7, WorkDate: day, Hours: hours, Reason: "Month-end closing", Status: model.StatusPending, CreatedBy: 7})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate_Duplicate(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("ON CONFLICT \\(user_id, work_date\\) WHERE status <> 'rejected' DO NOTHING").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}))
+	mock.ExpectRollback()
+
+	err := repo.Create(&model.Overtime{UserID: 7, Status: model.StatusPending, CreatedBy: 7})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransition(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE overtime_requests SET status").
+		WithArgs(int64(1), model.StatusPending, model.StatusApproved, "ok", "2025-01", int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO overtime_transitions").
+		WithArgs(int64(1), model.StatusPending, model.StatusApproved, "ok", int64(2)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE overtime_requests SET status").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	ok, err := repo.Transition(1, model.StatusPending, model.StatusApproved, 2, "ok", "2025-01")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = repo.Transition(1, model.StatusPending, model.StatusApproved, 2, "ok", "2025-01")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
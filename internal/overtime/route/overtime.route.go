@@ -0,0 +1,23 @@
+package route
+
+import (
+	"github.com/dwilanang/psp/internal/permission"
+	"github.com/dwilanang/psp/internal/registry"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, registry *registry.Registry) {
+	h := registry.NewOvertimeHandler()
+
+	overtimeGroup := rg.Group("/overtime")
+	{
+		// Every authenticated user submits and reads their own overtime requests.
+		overtimeGroup.POST("/submit", h.Submit)
+		overtimeGroup.GET("/me", h.Mine)
+
+		overtimeGroup.GET("/all", registry.RequirePermission(permission.OvertimeRead), h.GetAll)
+		overtimeGroup.GET("/detail/:id", registry.RequirePermission(permission.OvertimeRead), h.Get)
+		overtimeGroup.PUT("/approve/:id", registry.RequirePermission(permission.OvertimeApprove), h.Approve)
+		overtimeGroup.PUT("/reject/:id", registry.RequirePermission(permission.OvertimeApprove), h.Reject)
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dwilanang/psp/internal/overtime/model"
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+	"github.com/shopspring/decimal"
+)
+
+// Rates configures overtime pay: the hourly wage is the base salary divided by HourlyDivisor,
+// multiplied by Weekday or Weekend depending on the day the overtime was worked.
+type Rates struct {
+	Weekday       decimal.Decimal
+	Weekend       decimal.Decimal
+	HourlyDivisor decimal.Decimal
+}
+
+// PayableFetcher retrieves the approved overtime of a user paid in a pay period.
+// It is satisfied by the overtime repository.
+type PayableFetcher interface {
+	FetchPayable(userID int64, period string) ([]*model.Overtime, error)
+}
+
+// PayslipContributor pays approved overtime as payslip earnings, one line per rate.
+type PayslipContributor struct {
+	overtimes PayableFetcher
+	rates     Rates
+}
+
+func NewPayslipContributor(overtimes PayableFetcher, rates Rates) *PayslipContributor {
+	return &PayslipContributor{overtimes: overtimes, rates: rates}
+}
+
+// Contribute implements the payslip Contributor interface.
+func (p *PayslipContributor) Contribute(calc *payslipmodel.Calculation) ([]*payslipmodel.PayslipItem, error) {
+	overtimes, err := p.overtimes.FetchPayable(calc.UserID, calc.Period.Code)
+	if err != nil {
+		return nil, err
+	}
+	if len(overtimes) == 0 || !p.rates.HourlyDivisor.IsPositive() {
+		return nil, nil
+	}
+
+	weekday, weekend := decimal.Zero, decimal.Zero
+	for _, overtime := range overtimes {
+		if day := overtime.WorkDate.Weekday(); day == time.Saturday || day == time.Sunday {
+			weekend = weekend.Add(overtime.Hours)
+		} else {
+			weekday = weekday.Add(overtime.Hours)
+		}
+	}
+
+	hourly := calc.BaseSalary.Div(p.rates.HourlyDivisor)
+	var items []*payslipmodel.PayslipItem
+	if weekday.IsPositive() {
+		items = append(items, &payslipmodel.PayslipItem{
+			Code:   "OVERTIME_WEEKDAY",
+			Name:   fmt.Sprintf("Overtime Weekday (%s h x %s)", weekday.String(), p.rates.Weekday.String()),
+			Type:   payslipmodel.TypeEarning,
+			Amount: hourly.Mul(weekday).Mul(p.rates.Weekday).Round(2),
+		})
+	}
+	if weekend.IsPositive() {
+		items = append(items, &payslipmodel.PayslipItem{
+			Code:   "OVERTIME_WEEKEND",
+			Name:   fmt.Sprintf("Overtime Weekend (%s h x %s)", weekend.String(), p.rates.Weekend.String()),
+			Type:   payslipmodel.TypeEarning,
+			Amount: hourly.Mul(weekend).Mul(p.rates.Weekend).Round(2),
+		})
+	}
+	return items, nil
+}
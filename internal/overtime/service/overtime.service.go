@@ -0,0 +1,37 @@
+package service
+
+import (
+	"github.com/dwilanang/psp/internal/overtime/dto"
+)
+
+//go:generate mockgen -source=overtime.service.go -package=mocks -destination=mocks/mock_overtime_service.go
+
+// Service defines the interface for business logic related to overtime requests.
+// Employees submit requests for themselves; approvers approve or reject them once.
+type Service interface {
+	// Submit files a pending overtime request for the authenticated user.
+	// Param: request - a pointer to OvertimeRequest DTO.
+	// Returns the created request and ErrDuplicate if the user already has a live request for the day.
+	Submit(request *dto.OvertimeRequest) (dto.OvertimeResponse, error)
+
+	// List retrieves overtime requests matching the filter, most recent first.
+	// Param: request - a pointer to OvertimeFilterRequest DTO.
+	// Returns an OvertimeListResponse DTO and an error if the operation fails.
+	List(request *dto.OvertimeFilterRequest) (dto.OvertimeListResponse, error)
+
+	// Get retrieves an overtime request with its transitions.
+	// Param: id - the ID of the request.
+	// Returns an OvertimeResponse DTO and ErrOvertimeNotFound if it does not exist.
+	Get(id int64) (dto.OvertimeResponse, error)
+
+	// Approve approves a pending overtime request so it is paid on the payslip of the month it was worked,
+	// or of the next month without a payslip when that one was already issued.
+	// Param: request - a pointer to OvertimeReviewRequest DTO.
+	// Returns the updated request, ErrNotPending, ErrNoPayPeriod, or ErrSelfReview if approvers review their own request.
+	Approve(request *dto.OvertimeReviewRequest) (dto.OvertimeResponse, error)
+
+	// Reject rejects a pending overtime request.
+	// Param: request - a pointer to OvertimeReviewRequest DTO.
+	// Returns the updated request and the same errors as Approve.
+	Reject(request *dto.OvertimeReviewRequest) (dto.OvertimeResponse, error)
+}
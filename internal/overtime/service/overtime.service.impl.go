@@ -0,0 +1,236 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dwilanang/psp/internal/overtime/dto"
+	"github.com/dwilanang/psp/internal/overtime/model"
+	"github.com/dwilanang/psp/internal/overtime/repository"
+	"github.com/dwilanang/psp/internal/payroll/lock"
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/shopspring/decimal"
+)
+
+const dateLayout = "2006-01-02"
+
+// maxDeferredPeriods bounds how far past the month it was worked approved overtime may be paid
+// when payslips are already issued.
+const maxDeferredPeriods = 12
+
+var (
+	ErrOvertimeNotFound = apperror.NotFound("overtime request not found")
+	ErrDuplicate        = apperror.Conflict("an overtime request already exists for this day")
+	ErrInvalidDate      = apperror.Validation("work_date must be formatted as YYYY-MM-DD")
+	ErrInvalidHours     = apperror.Validation("hours must be greater than 0 and at most 24, with at most 2 decimal places")
+	ErrNotPending       = apperror.Conflict("overtime request has already been reviewed")
+	ErrSelfReview       = apperror.Forbidden("you cannot review your own overtime request")
+	ErrPeriodLocked     = apperror.Conflict("overtime falls in a closed payroll period")
+	ErrNoPayPeriod      = apperror.Conflict("no upcoming pay period is open for this overtime")
+)
+
+type service struct {
+	repo     repository.Repository
+	payslips lock.PayslipFetcher
+	periods  lock.PeriodLocker
+}
+
+// NewService creates the overtime service. payslips and periods may be nil when overtime is always paid
+// in the month it was worked and payroll periods are not enforced.
+func NewService(r repository.Repository, payslips lock.PayslipFetcher, periods lock.PeriodLocker) *service {
+	return &service{repo: r, payslips: payslips, periods: periods}
+}
+
+// Submit implements the Service interface.
+func (s *service) Submit(request *dto.OvertimeRequest) (dto.OvertimeResponse, error) {
+	day, err := time.Parse(dateLayout, request.WorkDate)
+	if err != nil {
+		return dto.OvertimeResponse{}, ErrInvalidDate
+	}
+	if !request.Hours.IsPositive() || request.Hours.GreaterThan(decimal.NewFromInt(24)) || !request.Hours.Equal(request.Hours.Round(2)) {
+		return dto.OvertimeResponse{}, ErrInvalidHours
+	}
+	if err := s.checkUnlocked(day); err != nil {
+		return dto.OvertimeResponse{}, err
+	}
+
+	overtime := &model.Overtime{
+		UserID:    request.By,
+		WorkDate:  day,
+		Hours:     request.Hours,
+		Reason:    request.Reason,
+		Status:    model.StatusPending,
+		CreatedBy: request.By,
+		UpdatedBy: request.By,
+	}
+	err = s.repo.Create(overtime)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dto.OvertimeResponse{}, ErrDuplicate
+		}
+		return dto.OvertimeResponse{}, err
+	}
+	return dto.OvertimeResponse{Data: toData(overtime)}, nil
+}
+
+// List implements the Service interface.
+func (s *service) List(request *dto.OvertimeFilterRequest) (dto.OvertimeListResponse, error) {
+	filter := &model.OvertimeFilter{UserID: request.UserID, Status: request.Status}
+	if request.Period != "" {
+		month, err := payslipmodel.ParsePeriod(request.Period)
+		if err != nil {
+			return dto.OvertimeListResponse{}, err
+		}
+		filter.From, filter.To = &month.Start, &month.End
+	}
+
+	overtimes, err := s.repo.Fetch(filter)
+	if err != nil {
+		return dto.OvertimeListResponse{}, err
+	}
+
+	data := make([]dto.OvertimeData, 0, len(overtimes))
+	for _, overtime := range overtimes {
+		data = append(data, toData(overtime))
+	}
+	return dto.OvertimeListResponse{Data: data}, nil
+}
+
+// Get implements the Service interface.
+func (s *service) Get(id int64) (dto.OvertimeResponse, error) {
+	overtime, err := s.find(id)
+	if err != nil {
+		return dto.OvertimeResponse{}, err
+	}
+	return dto.OvertimeResponse{Data: toData(overtime)}, nil
+}
+
+// Approve implements the Service interface.
+func (s *service) Approve(request *dto.OvertimeReviewRequest) (dto.OvertimeResponse, error) {
+	return s.review(request, model.StatusApproved)
+}
+
+// Reject implements the Service interface.
+func (s *service) Reject(request *dto.OvertimeReviewRequest) (dto.OvertimeResponse, error) {
+	return s.review(request, model.StatusRejected)
+}
+
+func (s *service) review(request *dto.OvertimeReviewRequest, status string) (dto.OvertimeResponse, error) {
+	overtime, err := s.find(request.ID)
+	if err != nil {
+		return dto.OvertimeResponse{}, err
+	}
+	if overtime.UserID == request.By {
+		return dto.OvertimeResponse{}, ErrSelfReview
+	}
+	if overtime.Status != model.StatusPending {
+		return dto.OvertimeResponse{}, ErrNotPending
+	}
+	if err := s.checkUnlocked(overtime.WorkDate); err != nil {
+		return dto.OvertimeResponse{}, err
+	}
+
+	payPeriod := ""
+	if status == model.StatusApproved {
+		if payPeriod, err = s.payPeriod(overtime); err != nil {
+			return dto.OvertimeResponse{}, err
+		}
+	}
+
+	ok, err := s.repo.Transition(overtime.ID, model.StatusPending, status, request.By, request.Note, payPeriod)
+	if err != nil {
+		return dto.OvertimeResponse{}, err
+	}
+	if !ok {
+		return dto.OvertimeResponse{}, ErrNotPending
+	}
+
+	overtime, err = s.find(request.ID)
+	if err != nil {
+		return dto.OvertimeResponse{}, err
+	}
+	return dto.OvertimeResponse{Data: toData(overtime)}, nil
+}
+
+// payPeriod returns the period paying approved overtime: the month it was worked, or the first later one
+// that is not closed and has no payslip for the user yet, so overtime approved late is still paid.
+func (s *service) payPeriod(overtime *model.Overtime) (string, error) {
+	month := time.Date(overtime.WorkDate.Year(), overtime.WorkDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxDeferredPeriods; i++ {
+		period, _ := payslipmodel.ParsePeriod(month.AddDate(0, i, 0).Format(payslipmodel.PeriodLayout))
+
+		if s.periods != nil {
+			locked, err := s.periods.IsLocked(period.Start, &period.End)
+			if err != nil {
+				return "", err
+			}
+			if locked {
+				continue
+			}
+		}
+		if s.payslips != nil {
+			payslips, err := s.payslips.Fetch(&payslipmodel.PayslipFilter{UserID: overtime.UserID, Period: period.Code})
+			if err != nil {
+				return "", err
+			}
+			if len(payslips) > 0 {
+				continue
+			}
+		}
+		return period.Code, nil
+	}
+	return "", ErrNoPayPeriod
+}
+
+func (s *service) find(id int64) (*model.Overtime, error) {
+	overtime, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOvertimeNotFound
+		}
+		return nil, err
+	}
+	return overtime, nil
+}
+
+func (s *service) checkUnlocked(day time.Time) error {
+	if s.periods == nil {
+		return nil
+	}
+
+	locked, err := s.periods.IsLocked(day, &day)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return ErrPeriodLocked
+	}
+	return nil
+}
+
+func toData(overtime *model.Overtime) dto.OvertimeData {
+	data := dto.OvertimeData{
+		ID:         overtime.ID,
+		UserID:     overtime.UserID,
+		FullName:   overtime.FullName,
+		WorkDate:   overtime.WorkDate.Format(dateLayout),
+		Hours:      overtime.Hours,
+		Reason:     overtime.Reason,
+		Status:     overtime.Status,
+		ReviewNote: overtime.ReviewNote,
+		PayPeriod:  overtime.PayPeriod,
+	}
+	for _, transition := range overtime.Transitions {
+		data.Transitions = append(data.Transitions, dto.TransitionData{
+			FromStatus: transition.FromStatus,
+			ToStatus:   transition.ToStatus,
+			Note:       transition.Note,
+			By:         transition.CreatedBy,
+			ByName:     transition.CreatedByName,
+			At:         transition.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return data
+}
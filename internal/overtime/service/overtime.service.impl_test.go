@@ -0,0 +1,205 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	mockrepo "github.com/dwilanang/psp/internal/overtime/repository/mocks"
+
+	"github.com/dwilanang/psp/internal/overtime/dto"
+	"github.com/dwilanang/psp/internal/overtime/model"
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+)
+
+func day(value string) time.Time {
+	t, _ := time.Parse(dateLayout, value)
+	return t
+}
+
+type fakePeriodLocker struct {
+	locked bool
+}
+
+func (f *fakePeriodLocker) IsLocked(from time.Time, to *time.Time) (bool, error) {
+	return f.locked, nil
+}
+
+func TestService_Submit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, nil, &fakePeriodLocker{})
+
+	mockRepo.
+		EXPECT().
+		Create(gomock.AssignableToTypeOf(&model.Overtime{})).
+		DoAndReturn(func(overtime *model.Overtime) error {
+			assert.Equal(t, int64(7), overtime.UserID)
+			assert.Equal(t, int64(7), overtime.CreatedBy)
+			assert.Equal(t, model.StatusPending, overtime.Status)
+			overtime.ID = 1
+			return nil
+		})
+	mockRepo.EXPECT().Create(gomock.Any()).Return(sql.ErrNoRows)
+
+	request := &dto.OvertimeRequest{WorkDate: "2025-01-18", Hours: decimal.RequireFromString("3.5"), Reason: "Month-end closing", By: 7}
+	resp, err := svc.Submit(request)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusPending, resp.Data.Status)
+
+	_, err = svc.Submit(request)
+	assert.ErrorIs(t, err, ErrDuplicate)
+}
+
+func TestService_Submit_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := NewService(mockrepo.NewMockRepository(ctrl), nil, &fakePeriodLocker{locked: true})
+
+	_, err := svc.Submit(&dto.OvertimeRequest{WorkDate: "18-01-2025", Hours: decimal.NewFromInt(2)})
+	assert.ErrorIs(t, err, ErrInvalidDate)
+
+	_, err = svc.Submit(&dto.OvertimeRequest{WorkDate: "2025-01-18", Hours: decimal.NewFromInt(25)})
+	assert.ErrorIs(t, err, ErrInvalidHours)
+
+	_, err = svc.Submit(&dto.OvertimeRequest{WorkDate: "2025-01-18", Hours: decimal.RequireFromString("1.255")})
+	assert.ErrorIs(t, err, ErrInvalidHours)
+
+	_, err = svc.Submit(&dto.OvertimeRequest{WorkDate: "2025-01-18", Hours: decimal.NewFromInt(2)})
+	assert.ErrorIs(t, err, ErrPeriodLocked)
+}
+
+func TestService_Approve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, nil, nil)
+
+	pending := &model.Overtime{ID: 1, UserID: 7, WorkDate: day("2025-01-18"), Status: model.StatusPending}
+	approved := &model.Overtime{ID: 1, UserID: 7, WorkDate: day("2025-01-18"), Status: model.StatusApproved, Transitions: []*model.Transition{
+		{ToStatus: model.StatusPending, CreatedBy: 7},
+		{FromStatus: model.StatusPending, ToStatus: model.StatusApproved, CreatedBy: 2, Note: "ok"},
+	}}
+	gomock.InOrder(
+		mockRepo.EXPECT().FindByID(int64(1)).Return(pending, nil),
+		mockRepo.EXPECT().Transition(int64(1), model.StatusPending, model.StatusApproved, int64(2), "ok", "2025-01").Return(true, nil),
+		mockRepo.EXPECT().FindByID(int64(1)).Return(approved, nil),
+	)
+
+	resp, err := svc.Approve(&dto.OvertimeReviewRequest{ID: 1, Note: "ok", By: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusApproved, resp.Data.Status)
+	assert.Len(t, resp.Data.Transitions, 2)
+	assert.Equal(t, int64(2), resp.Data.Transitions[1].By)
+}
+
+// fakePayslipFetcher reports the periods in which payslips were issued.
+type fakePayslipFetcher struct {
+	issued map[string]bool
+}
+
+func (f *fakePayslipFetcher) Fetch(filter *payslipmodel.PayslipFilter) ([]*payslipmodel.Payslip, error) {
+	if f.issued[filter.Period] {
+		return []*payslipmodel.Payslip{{UserID: filter.UserID, Period: filter.Period}}, nil
+	}
+	return nil, nil
+}
+
+func TestService_Approve_PayPeriod(t *testing.T) {
+	tests := []struct {
+		name     string
+		issued   map[string]bool
+		expected string
+	}{
+		{"approved before the payslip is issued", nil, "2025-01"},
+		{"approved after the payslip is issued", map[string]bool{"2025-01": true}, "2025-02"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mockrepo.NewMockRepository(ctrl)
+			svc := NewService(mockRepo, &fakePayslipFetcher{issued: tt.issued}, &fakePeriodLocker{})
+
+			pending := &model.Overtime{ID: 1, UserID: 7, WorkDate: day("2025-01-18"), Status: model.StatusPending}
+			gomock.InOrder(
+				mockRepo.EXPECT().FindByID(int64(1)).Return(pending, nil),
+				mockRepo.EXPECT().Transition(int64(1), model.StatusPending, model.StatusApproved, int64(2), "", tt.expected).Return(true, nil),
+				mockRepo.EXPECT().FindByID(int64(1)).Return(&model.Overtime{ID: 1, UserID: 7, WorkDate: day("2025-01-18"), Status: model.StatusApproved, PayPeriod: tt.expected}, nil),
+			)
+
+			resp, err := svc.Approve(&dto.OvertimeReviewRequest{ID: 1, By: 2})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, resp.Data.PayPeriod)
+		})
+	}
+}
+
+func TestService_Review_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().FindByID(int64(1)).Return(&model.Overtime{ID: 1, UserID: 7, Status: model.StatusPending}, nil).Times(2)
+	mockRepo.EXPECT().FindByID(int64(2)).Return(&model.Overtime{ID: 2, UserID: 7, Status: model.StatusApproved}, nil)
+	mockRepo.EXPECT().FindByID(int64(3)).Return(nil, sql.ErrNoRows)
+	mockRepo.EXPECT().Transition(int64(1), model.StatusPending, model.StatusRejected, int64(2), "", "").Return(false, nil)
+
+	_, err := svc.Approve(&dto.OvertimeReviewRequest{ID: 1, By: 7})
+	assert.ErrorIs(t, err, ErrSelfReview)
+
+	// Another approver decided first.
+	_, err = svc.Reject(&dto.OvertimeReviewRequest{ID: 1, By: 2})
+	assert.ErrorIs(t, err, ErrNotPending)
+
+	_, err = svc.Reject(&dto.OvertimeReviewRequest{ID: 2, By: 2})
+	assert.ErrorIs(t, err, ErrNotPending)
+
+	_, err = svc.Approve(&dto.OvertimeReviewRequest{ID: 3, By: 2})
+	assert.ErrorIs(t, err, ErrOvertimeNotFound)
+}
+
+type fakePayableFetcher struct {
+	overtimes []*model.Overtime
+}
+
+func (f *fakePayableFetcher) FetchPayable(userID int64, period string) ([]*model.Overtime, error) {
+	return f.overtimes, nil
+}
+
+func TestPayslipContributor(t *testing.T) {
+	period, _ := payslipmodel.ParsePeriod("2025-01")
+	calc := &payslipmodel.Calculation{UserID: 7, Period: period, BaseSalary: decimal.NewFromInt(3460000)}
+	rates := Rates{Weekday: decimal.RequireFromString("1.5"), Weekend: decimal.NewFromInt(2), HourlyDivisor: decimal.NewFromInt(173)}
+
+	items, err := NewPayslipContributor(&fakePayableFetcher{}, rates).Contribute(calc)
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	overtimes := &fakePayableFetcher{overtimes: []*model.Overtime{
+		{WorkDate: day("2025-01-15"), Hours: decimal.NewFromInt(2)},
+		{WorkDate: day("2025-01-16"), Hours: decimal.RequireFromString("1.5")},
+		{WorkDate: day("2025-01-18"), Hours: decimal.NewFromInt(4)},
+	}}
+	items, err = NewPayslipContributor(overtimes, rates).Contribute(calc)
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	// The hourly wage is 3,460,000 / 173 = 20,000.
+	assert.Equal(t, "OVERTIME_WEEKDAY", items[0].Code)
+	assert.Equal(t, "105000.00", items[0].Amount.StringFixed(2))
+	assert.Equal(t, "OVERTIME_WEEKEND", items[1].Code)
+	assert.Equal(t, "160000.00", items[1].Amount.StringFixed(2))
+}
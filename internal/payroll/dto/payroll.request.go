@@ -0,0 +1,6 @@
+package dto
+
+type PeriodRequest struct {
+	Period string `json:"period" binding:"required" example:"2025-01"`
+	By     int64  `json:"by" swaggerignore:"true"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+type PeriodResponse struct {
+	Data PeriodData `json:"data"`
+}
+
+type PeriodListResponse struct {
+	Data []PeriodData `json:"data"`
+}
+
+type PeriodData struct {
+	ID             int64   `json:"id"`
+	Period         string  `json:"period" example:"2025-01"`
+	PeriodStart    string  `json:"period_start" example:"2025-01-01"`
+	PeriodEnd      string  `json:"period_end" example:"2025-01-31"`
+	Status         string  `json:"status" example:"open"`
+	ProcessedUsers int     `json:"processed_users"`
+	FailedUsers    int     `json:"failed_users"`
+	RunAt          *string `json:"run_at"`
+	ClosedAt       *string `json:"closed_at"`
+}
+
+type RunResponse struct {
+	Period   PeriodData   `json:"period"`
+	Issued   int          `json:"issued"`
+	Failures []RunFailure `json:"failures"`
+}
+
+type RunFailure struct {
+	UserID int64  `json:"user_id"`
+	Error  string `json:"error"`
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"github.com/dwilanang/psp/internal/auth/util"
+	"github.com/dwilanang/psp/internal/payroll"
+	"github.com/dwilanang/psp/internal/payroll/dto"
+	"github.com/dwilanang/psp/utils"
+	utilrequest "github.com/dwilanang/psp/utils/request"
+	"github.com/dwilanang/psp/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	Deps payroll.Dependencies
+}
+
+func NewHandler(deps payroll.Dependencies) *Handler {
+	return &Handler{
+		Deps: deps,
+	}
+}
+
+// GetAll godoc
+// @Security BearerAuth
+// @Summary      Get payroll periods
+// @Description  Get every payroll period, most recent first
+// @Tags         payroll
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.ApiResponse{data=[]dto.PeriodData}
+// @Failure      500  {object}  response.Problem
+// @Router       /payroll/periods/all [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	resp, err := h.Deps.Service.List()
+	if err != nil {
+		response.Error(c, err, "could not fetch payroll periods")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// Get godoc
+// @Security BearerAuth
+// @Summary      Get payroll period
+// @Description  Get a payroll period with the progress of its latest run
+// @Tags         payroll
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Payroll period ID"
+// @Success      200  {object}  response.ApiResponse{data=dto.PeriodData}
+// @Failure      404  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /payroll/periods/detail/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	resp, err := h.Deps.Service.Get(utils.ConvertStringToInt(c.Param("id")))
+	if err != nil {
+		response.Error(c, err, "could not fetch payroll period")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// Create godoc
+// @Security BearerAuth
+// @Summary      Create payroll period
+// @Description  Open a payroll period for a month
+// @Tags         payroll
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.PeriodRequest  true  "Payroll period payload"
+// @Success      201   {object}  response.ApiResponse{data=dto.PeriodData}
+// @Failure      400   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /payroll/periods/create [post]
+func (h *Handler) Create(c *gin.Context) {
+	var pr dto.PeriodRequest
+	if err := c.ShouldBindJSON(&pr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	pr.By = by
+
+	resp, err := h.Deps.Service.Create(&pr)
+	if err != nil {
+		response.Error(c, err, "could not create payroll period")
+		return
+	}
+	response.Created(c, resp.Data)
+}
+
+// Run godoc
+// @Security BearerAuth
+// @Summary      Run payroll
+// @Description  Issue the missing payslips of a payroll period; running it again resumes after failures
+// @Tags         payroll
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Payroll period ID"
+// @Success      200  {object}  response.ApiResponse{data=dto.RunResponse}
+// @Failure      404  {object}  response.Problem
+// @Failure      409  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /payroll/periods/run/{id} [post]
+func (h *Handler) Run(c *gin.Context) {
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+
+	resp, err := h.Deps.Service.Run(utils.ConvertStringToInt(c.Param("id")), by)
+	if err != nil {
+		response.Error(c, err, "could not run payroll")
+		return
+	}
+	response.OK(c, resp)
+}
+
+// Close godoc
+// @Security BearerAuth
+// @Summary      Close payroll period
+// @Description  Close a fully processed payroll period; its salaries and attendance can no longer change
+// @Tags         payroll
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Payroll period ID"
+// @Success      200  {object}  response.ApiResponse{data=dto.PeriodData}
+// @Failure      404  {object}  response.Problem
+// @Failure      409  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /payroll/periods/close/{id} [put]
+func (h *Handler) Close(c *gin.Context) {
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+
+	resp, err := h.Deps.Service.Close(utils.ConvertStringToInt(c.Param("id")), by)
+	if err != nil {
+		response.Error(c, err, "could not close payroll period")
+		return
+	}
+	response.OK(c, resp.Data)
+}
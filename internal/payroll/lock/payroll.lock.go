@@ -0,0 +1,40 @@
+package lock
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/internal/payroll/repository"
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+)
+
+// PeriodLocker reports whether a date range touches a closed payroll period.
+// It is satisfied by PeriodLock.
+type PeriodLocker interface {
+	IsLocked(from time.Time, to *time.Time) (bool, error)
+}
+
+// PayslipFetcher retrieves issued payslips, so a change can be refused once payroll has paid it out.
+// It is satisfied by the payslip repository.
+type PayslipFetcher interface {
+	Fetch(filter *payslipmodel.PayslipFilter) ([]*payslipmodel.Payslip, error)
+}
+
+// PeriodLock tells other modules whether a date falls in a closed payroll period.
+// It is kept apart from the payroll service so modules the service depends on, such as salary, can use it too.
+type PeriodLock struct {
+	repo repository.Repository
+}
+
+func NewPeriodLock(r repository.Repository) *PeriodLock {
+	return &PeriodLock{repo: r}
+}
+
+// IsLocked reports whether any day from `from` through `to` belongs to a closed payroll period.
+// A nil `to` means the range is open-ended.
+func (l *PeriodLock) IsLocked(from time.Time, to *time.Time) (bool, error) {
+	locked, err := l.repo.HasClosedPeriod(from, to)
+	if err != nil {
+		return false, err
+	}
+	return locked, nil
+}
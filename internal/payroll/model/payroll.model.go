@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Payroll period statuses. A period moves strictly open → processing → closed.
+const (
+	StatusOpen       = "open"
+	StatusProcessing = "processing"
+	StatusClosed     = "closed"
+)
+
+type PayrollPeriod struct {
+	ID             int64      `db:"id"`
+	Period         string     `db:"period"`
+	PeriodStart    time.Time  `db:"period_start"`
+	PeriodEnd      time.Time  `db:"period_end"`
+	Status         string     `db:"status"`
+	ProcessedUsers int        `db:"processed_users"`
+	FailedUsers    int        `db:"failed_users"`
+	CreatedBy      int64      `db:"created_by"`
+	CreatedAt      time.Time  `db:"created_at"`
+	RunBy          *int64     `db:"run_by"`
+	RunAt          *time.Time `db:"run_at"`
+	ClosedBy       *int64     `db:"closed_by"`
+	ClosedAt       *time.Time `db:"closed_at"`
+}
@@ -0,0 +1,11 @@
+package payroll
+
+import (
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/payroll/service"
+)
+
+type Dependencies struct {
+	DBPostgres *postgres.DB
+	Service    service.Service
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/internal/payroll/model"
+)
+
+//go:generate mockgen -source=payroll.repository.go -package=mocks -destination=mocks/mock_payroll_repository.go
+
+// Repository defines an interface for data operations related to payroll periods.
+type Repository interface {
+	// Fetch retrieves every payroll period, most recent first.
+	// Returns a slice of PayrollPeriod pointers and an error if the operation fails.
+	Fetch() ([]*model.PayrollPeriod, error)
+
+	// FindByID retrieves a payroll period by its ID.
+	// Param: id - the ID of the payroll period.
+	// Returns a pointer to the PayrollPeriod and an error if the operation fails or the period is not found.
+	FindByID(id int64) (*model.PayrollPeriod, error)
+
+	// Create inserts a new open payroll period.
+	// Param: period - a pointer to the PayrollPeriod entity to be created.
+	// Returns sql.ErrNoRows if the period already exists, or an error if the operation fails.
+	Create(period *model.PayrollPeriod) error
+
+	// Transition moves a payroll period from one status to another, recording who did it.
+	// Param: id - the ID of the payroll period; from, to - the expected and new status; by - the ID of the acting user.
+	// Returns false if the period was not in the expected status, and an error if the operation fails.
+	Transition(id int64, from string, to string, by int64) (bool, error)
+
+	// TryLock takes an exclusive lock on a payroll period for the duration of a run or close.
+	// The lock is released by calling release, or automatically if the connection is lost.
+	// Param: id - the ID of the payroll period.
+	// Returns the release function, false if another run holds the lock, and an error if the operation fails.
+	TryLock(id int64) (release func(), locked bool, err error)
+
+	// FetchPendingUsers retrieves the active users with a salary in effect at the end of the period
+	// who do not have a payslip for it yet.
+	// Param: period - a pointer to the PayrollPeriod.
+	// Returns the user IDs and an error if the operation fails.
+	FetchPendingUsers(period *model.PayrollPeriod) ([]int64, error)
+
+	// UpdateProgress stores the outcome of the latest run of a payroll period.
+	// Param: id - the ID of the payroll period; processed, failed - the number of users in each state.
+	// Returns an error if the operation fails.
+	UpdateProgress(id int64, processed int, failed int) error
+
+	// HasClosedPeriod reports whether any closed payroll period overlaps the date range.
+	// Param: from, to - the inclusive range, to is nil when open-ended.
+	// Returns true if a closed period overlaps and an error if the operation fails.
+	HasClosedPeriod(from time.Time, to *time.Time) (bool, error)
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/payroll/model"
+)
+
+// lockNamespace scopes payroll advisory locks so they cannot collide with locks taken by other features.
+const lockNamespace = 8008
+
+const selectPeriod = `
+		SELECT 
+			id, period, period_start, period_end, status, processed_users, failed_users,
+			COALESCE(created_by, 0) AS created_by,
+			created_at, run_by, run_at, closed_by, closed_at
+		FROM payroll_periods
+`
+
+type repository struct {
+	db *postgres.DB
+}
+
+func NewRepository(db *postgres.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Fetch() ([]*model.PayrollPeriod, error) {
+	periods := []*model.PayrollPeriod{}
+	query := selectPeriod + `
+		ORDER BY period DESC
+	`
+	err := r.db.Replica().Select(&periods, query)
+	if err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+func (r *repository) FindByID(id int64) (*model.PayrollPeriod, error) {
+	var period model.PayrollPeriod
+	query := selectPeriod + `
+		WHERE id = $1
+	`
+	err := r.db.Get(&period, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return &period, nil
+}
+
+func (r *repository) Create(period *model.PayrollPeriod) error {
+	query := `
+		INSERT INTO payroll_periods (period, period_start, period_end, status, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (period) DO NOTHING
+		RETURNING id, created_at
+	`
+	return r.db.QueryRowx(
+		query,
+		period.Period,
+		period.PeriodStart,
+		period.PeriodEnd,
+		period.Status,
+		period.CreatedBy,
+	).Scan(&period.ID, &period.CreatedAt)
+}
+
+func (r *repository) Transition(id int64, from string, to string, by int64) (bool, error) {
+	// lib/pq sends parameters untyped, so $3 is cast where it is compared with a text literal;
+	// otherwise PostgreSQL deduces both varchar and text for it and rejects the statement.
+	query := `
+		UPDATE payroll_periods SET
+			status = $3,
+			run_by = CASE WHEN $3::varchar = 'processing' THEN $4 ELSE run_by END,
+			run_at = CASE WHEN $3::varchar = 'processing' THEN NOW() ELSE run_at END,
+			closed_by = CASE WHEN $3::varchar = 'closed' THEN $4 ELSE closed_by END,
+			closed_at = CASE WHEN $3::varchar = 'closed' THEN NOW() ELSE closed_at END
+		WHERE id = $1 AND status = $2
+	`
+	result, err := r.db.Exec(query, id, from, to, by)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *repository) TryLock(id int64) (func(), bool, error) {
+	// A transaction-scoped advisory lock pins one pooled connection for as long as the lock is held.
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var locked bool
+	err = tx.Get(&locked, `SELECT pg_try_advisory_xact_lock($1, $2)`, lockNamespace, id)
+	if err != nil || !locked {
+		tx.Rollback()
+		return nil, false, err
+	}
+	return func() { tx.Rollback() }, true, nil
+}
+
+func (r *repository) FetchPendingUsers(period *model.PayrollPeriod) ([]int64, error) {
+	userIDs := []int64{}
+	query := `
+		SELECT u.id
+		FROM users u
+		INNER JOIN user_salaries us ON(us.user_id=u.id)
+		WHERE u.is_active AND u.deleted_at IS NULL
+		AND us.effective_from <= $1 AND (us.effective_to IS NULL OR us.effective_to >= $1)
+		AND NOT EXISTS (SELECT 1 FROM payslips p WHERE p.user_id = u.id AND p.period = $2)
+		ORDER BY u.id
+	`
+	err := r.db.Select(&userIDs, query, period.PeriodEnd, period.Period)
+	if err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+func (r *repository) UpdateProgress(id int64, processed int, failed int) error {
+	query := `
+		UPDATE payroll_periods SET processed_users = $2, failed_users = $3
+		WHERE id = $1
+	`
+	_, err := r.db.Exec(query, id, processed, failed)
+	return err
+}
+
+func (r *repository) HasClosedPeriod(from time.Time, to *time.Time) (bool, error) {
+	var closed bool
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM payroll_periods
+			WHERE status = 'closed' AND period_end >= $1 AND ($2::date IS NULL OR period_start <= $2)
+		)
+	`
+	err := r.db.Get(&closed, query, from, to)
+	return closed, err
+}
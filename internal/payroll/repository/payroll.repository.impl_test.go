@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*postgres.DB, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return postgres.NewDB(sqlx.NewDb(db, "postgres")), mock, func() { db.Close() }
+}
+
+func TestTransition(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectExec("UPDATE payroll_periods SET").
+		WithArgs(int64(3), "processing", "closed", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE payroll_periods SET").
+		WithArgs(int64(3), "processing", "closed", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ok, err := repo.Transition(3, "processing", "closed", 1)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = repo.Transition(3, "processing", "closed", 1)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestTransition_CastsStatusInConditions(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewRepository(postgres.NewDB(sqlx.NewDb(db, "postgres")))
+
+	mock.ExpectExec(`
+		UPDATE payroll_periods SET
+			status = $3,
+			run_by = CASE WHEN $3::varchar = 'processing' THEN $4 ELSE run_by END,
+			run_at = CASE WHEN $3::varchar = 'processing' THEN NOW() ELSE run_at END,
+			closed_by = CASE WHEN $3::varchar = 'closed' THEN $4 ELSE closed_by END,
+			closed_at = CASE WHEN $3::varchar = 'closed' THEN NOW() ELSE closed_at END
+		WHERE id = $1 AND status = $2
+	`).
+		WithArgs(int64(3), "open", "processing", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ok, err := repo.Transition(3, "open", "processing", 1)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTryLock(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock").
+		WithArgs(lockNamespace, int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	mock.ExpectRollback()
+
+	release, locked, err := repo.TryLock(3)
+	assert.NoError(t, err)
+	assert.True(t, locked)
+	release()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock").
+		WithArgs(lockNamespace, int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+	mock.ExpectRollback()
+
+	_, locked, err = repo.TryLock(3)
+	assert.NoError(t, err)
+	assert.False(t, locked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHasClosedPeriod(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	from := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("status = 'closed'").
+		WithArgs(from, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	closed, err := repo.HasClosedPeriod(from, nil)
+
+	assert.NoError(t, err)
+	assert.True(t, closed)
+}
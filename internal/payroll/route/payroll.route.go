@@ -0,0 +1,20 @@
+package route
+
+import (
+	"github.com/dwilanang/psp/internal/permission"
+	"github.com/dwilanang/psp/internal/registry"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, registry *registry.Registry) {
+	h := registry.NewPayrollHandler()
+
+	periodsGroup := rg.Group("/payroll/periods")
+	{
+		periodsGroup.GET("/all", registry.RequirePermission(permission.PayrollRead), h.GetAll)
+		periodsGroup.GET("/detail/:id", registry.RequirePermission(permission.PayrollRead), h.Get)
+		periodsGroup.POST("/create", registry.RequirePermission(permission.PayrollRun), h.Create)
+		periodsGroup.POST("/run/:id", registry.RequirePermission(permission.PayrollRun), h.Run)
+		periodsGroup.PUT("/close/:id", registry.RequirePermission(permission.PayrollClose), h.Close)
+	}
+}
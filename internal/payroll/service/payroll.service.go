@@ -0,0 +1,36 @@
+package service
+
+import (
+	"github.com/dwilanang/psp/internal/payroll/dto"
+)
+
+//go:generate mockgen -source=payroll.service.go -package=mocks -destination=mocks/mock_payroll_service.go
+
+// Service defines the interface for business logic related to payroll periods.
+// A period is created open, becomes processing on its first run and is closed once every payslip is issued.
+type Service interface {
+	// List retrieves every payroll period, most recent first.
+	// Returns a PeriodListResponse DTO and an error if the operation fails.
+	List() (dto.PeriodListResponse, error)
+
+	// Get retrieves a payroll period by ID.
+	// Param: id - the ID of the payroll period.
+	// Returns a PeriodResponse DTO and ErrPeriodNotFound if it does not exist.
+	Get(id int64) (dto.PeriodResponse, error)
+
+	// Create opens a payroll period for a month.
+	// Param: request - a pointer to PeriodRequest DTO.
+	// Returns the created period and ErrPeriodExists if the month already has one.
+	Create(request *dto.PeriodRequest) (dto.PeriodResponse, error)
+
+	// Run issues the payslips of every active user with a salary who does not have one for the period yet.
+	// Each payslip is stored in its own transaction, so a failed or interrupted run is resumed by running it again.
+	// Param: id - the ID of the payroll period; by - the ID of the user running payroll.
+	// Returns a RunResponse DTO, ErrPeriodClosed if the period is closed, or ErrRunInProgress if another run holds it.
+	Run(id int64, by int64) (dto.RunResponse, error)
+
+	// Close locks a processed payroll period so its salaries and attendance can no longer change.
+	// Param: id - the ID of the payroll period; by - the ID of the user closing payroll.
+	// Returns the closed period, ErrPeriodNotProcessed if it was never run, or ErrRunIncomplete if payslips are missing.
+	Close(id int64, by int64) (dto.PeriodResponse, error)
+}
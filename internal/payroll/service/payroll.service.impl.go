@@ -0,0 +1,228 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/dwilanang/psp/internal/payroll/dto"
+	"github.com/dwilanang/psp/internal/payroll/model"
+	"github.com/dwilanang/psp/internal/payroll/repository"
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+	payslipservice "github.com/dwilanang/psp/internal/payslip/service"
+	"github.com/dwilanang/psp/pkg/apperror"
+)
+
+var (
+	ErrPeriodNotFound     = apperror.NotFound("payroll period not found")
+	ErrPeriodExists       = apperror.Conflict("payroll period already exists")
+	ErrPeriodClosed       = apperror.Conflict("payroll period is closed")
+	ErrPeriodNotProcessed = apperror.Conflict("payroll has not been run for this period")
+	ErrRunInProgress      = apperror.Conflict("payroll run is already in progress for this period")
+	ErrRunIncomplete      = apperror.Conflict("payslips are still missing for this period")
+)
+
+// PayslipIssuer issues the payslip of a user for a pay period.
+// It is satisfied by the payslip service.
+type PayslipIssuer interface {
+	Issue(userID int64, period payslipmodel.Period, by int64) (*payslipmodel.Payslip, error)
+}
+
+type service struct {
+	repo     repository.Repository
+	payslips PayslipIssuer
+}
+
+func NewService(r repository.Repository, payslips PayslipIssuer) *service {
+	return &service{repo: r, payslips: payslips}
+}
+
+// List implements the Service interface.
+func (s *service) List() (dto.PeriodListResponse, error) {
+	periods, err := s.repo.Fetch()
+	if err != nil {
+		return dto.PeriodListResponse{}, err
+	}
+
+	data := make([]dto.PeriodData, 0, len(periods))
+	for _, period := range periods {
+		data = append(data, toData(period))
+	}
+	return dto.PeriodListResponse{Data: data}, nil
+}
+
+// Get implements the Service interface.
+func (s *service) Get(id int64) (dto.PeriodResponse, error) {
+	period, err := s.find(id)
+	if err != nil {
+		return dto.PeriodResponse{}, err
+	}
+	return dto.PeriodResponse{Data: toData(period)}, nil
+}
+
+// Create implements the Service interface.
+func (s *service) Create(request *dto.PeriodRequest) (dto.PeriodResponse, error) {
+	month, err := payslipmodel.ParsePeriod(request.Period)
+	if err != nil {
+		return dto.PeriodResponse{}, err
+	}
+
+	period := &model.PayrollPeriod{
+		Period:      month.Code,
+		PeriodStart: month.Start,
+		PeriodEnd:   month.End,
+		Status:      model.StatusOpen,
+		CreatedBy:   request.By,
+	}
+	err = s.repo.Create(period)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dto.PeriodResponse{}, ErrPeriodExists
+		}
+		return dto.PeriodResponse{}, err
+	}
+	return dto.PeriodResponse{Data: toData(period)}, nil
+}
+
+// Run implements the Service interface.
+func (s *service) Run(id int64, by int64) (dto.RunResponse, error) {
+	period, err := s.find(id)
+	if err != nil {
+		return dto.RunResponse{}, err
+	}
+	if period.Status == model.StatusClosed {
+		return dto.RunResponse{}, ErrPeriodClosed
+	}
+
+	release, err := s.lock(id)
+	if err != nil {
+		return dto.RunResponse{}, err
+	}
+	defer release()
+
+	// Re-read under the lock: a concurrent close may have finished in between.
+	period, err = s.find(id)
+	if err != nil {
+		return dto.RunResponse{}, err
+	}
+	switch period.Status {
+	case model.StatusClosed:
+		return dto.RunResponse{}, ErrPeriodClosed
+	case model.StatusOpen:
+		if _, err := s.repo.Transition(id, model.StatusOpen, model.StatusProcessing, by); err != nil {
+			return dto.RunResponse{}, err
+		}
+	}
+
+	userIDs, err := s.repo.FetchPendingUsers(period)
+	if err != nil {
+		return dto.RunResponse{}, err
+	}
+
+	month := payslipmodel.Period{Code: period.Period, Start: period.PeriodStart, End: period.PeriodEnd}
+	resp := dto.RunResponse{Failures: []dto.RunFailure{}}
+	for _, userID := range userIDs {
+		_, err := s.payslips.Issue(userID, month, by)
+		if err != nil && !errors.Is(err, payslipservice.ErrPayslipExists) {
+			resp.Failures = append(resp.Failures, dto.RunFailure{UserID: userID, Error: err.Error()})
+			continue
+		}
+		resp.Issued++
+	}
+
+	err = s.repo.UpdateProgress(id, period.ProcessedUsers+resp.Issued, len(resp.Failures))
+	if err != nil {
+		return dto.RunResponse{}, err
+	}
+
+	period, err = s.find(id)
+	if err != nil {
+		return dto.RunResponse{}, err
+	}
+	resp.Period = toData(period)
+	return resp, nil
+}
+
+// Close implements the Service interface.
+func (s *service) Close(id int64, by int64) (dto.PeriodResponse, error) {
+	release, err := s.lock(id)
+	if err != nil {
+		return dto.PeriodResponse{}, err
+	}
+	defer release()
+
+	period, err := s.find(id)
+	if err != nil {
+		return dto.PeriodResponse{}, err
+	}
+	switch period.Status {
+	case model.StatusClosed:
+		return dto.PeriodResponse{}, ErrPeriodClosed
+	case model.StatusOpen:
+		return dto.PeriodResponse{}, ErrPeriodNotProcessed
+	}
+
+	userIDs, err := s.repo.FetchPendingUsers(period)
+	if err != nil {
+		return dto.PeriodResponse{}, err
+	}
+	if len(userIDs) > 0 {
+		return dto.PeriodResponse{}, ErrRunIncomplete
+	}
+
+	ok, err := s.repo.Transition(id, model.StatusProcessing, model.StatusClosed, by)
+	if err != nil {
+		return dto.PeriodResponse{}, err
+	}
+	if !ok {
+		return dto.PeriodResponse{}, ErrPeriodClosed
+	}
+
+	period, err = s.find(id)
+	if err != nil {
+		return dto.PeriodResponse{}, err
+	}
+	return dto.PeriodResponse{Data: toData(period)}, nil
+}
+
+func (s *service) find(id int64) (*model.PayrollPeriod, error) {
+	period, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPeriodNotFound
+		}
+		return nil, err
+	}
+	return period, nil
+}
+
+func (s *service) lock(id int64) (func(), error) {
+	release, locked, err := s.repo.TryLock(id)
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, ErrRunInProgress
+	}
+	return release, nil
+}
+
+func toData(period *model.PayrollPeriod) dto.PeriodData {
+	data := dto.PeriodData{
+		ID:             period.ID,
+		Period:         period.Period,
+		PeriodStart:    period.PeriodStart.Format("2006-01-02"),
+		PeriodEnd:      period.PeriodEnd.Format("2006-01-02"),
+		Status:         period.Status,
+		ProcessedUsers: period.ProcessedUsers,
+		FailedUsers:    period.FailedUsers,
+	}
+	if period.RunAt != nil {
+		runAt := period.RunAt.Format("2006-01-02 15:04:05")
+		data.RunAt = &runAt
+	}
+	if period.ClosedAt != nil {
+		closedAt := period.ClosedAt.Format("2006-01-02 15:04:05")
+		data.ClosedAt = &closedAt
+	}
+	return data
+}
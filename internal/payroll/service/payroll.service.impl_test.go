@@ -0,0 +1,120 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mockrepo "github.com/dwilanang/psp/internal/payroll/repository/mocks"
+
+	"github.com/dwilanang/psp/internal/payroll/model"
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+	payslipservice "github.com/dwilanang/psp/internal/payslip/service"
+)
+
+type fakePayslipIssuer struct {
+	fail   map[int64]error
+	issued []int64
+}
+
+func (f *fakePayslipIssuer) Issue(userID int64, period payslipmodel.Period, by int64) (*payslipmodel.Payslip, error) {
+	if err := f.fail[userID]; err != nil {
+		return nil, err
+	}
+	f.issued = append(f.issued, userID)
+	return &payslipmodel.Payslip{UserID: userID, Period: period.Code}, nil
+}
+
+func newPeriod(status string) *model.PayrollPeriod {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &model.PayrollPeriod{ID: 3, Period: "2025-01", PeriodStart: start, PeriodEnd: start.AddDate(0, 1, -1), Status: status}
+}
+
+func TestService_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	payslips := &fakePayslipIssuer{fail: map[int64]error{
+		8: errors.New("boom"),
+		9: payslipservice.ErrPayslipExists,
+	}}
+	svc := NewService(mockRepo, payslips)
+
+	released := false
+	mockRepo.EXPECT().FindByID(int64(3)).Return(newPeriod(model.StatusOpen), nil).Times(2)
+	mockRepo.EXPECT().TryLock(int64(3)).Return(func() { released = true }, true, nil)
+	mockRepo.EXPECT().Transition(int64(3), model.StatusOpen, model.StatusProcessing, int64(1)).Return(true, nil)
+	mockRepo.EXPECT().FetchPendingUsers(gomock.Any()).Return([]int64{7, 8, 9}, nil)
+	mockRepo.EXPECT().UpdateProgress(int64(3), 2, 1).Return(nil)
+	mockRepo.EXPECT().FindByID(int64(3)).Return(newPeriod(model.StatusProcessing), nil)
+
+	resp, err := svc.Run(3, 1)
+
+	assert.NoError(t, err)
+	assert.True(t, released)
+	assert.Equal(t, []int64{7}, payslips.issued)
+	assert.Equal(t, 2, resp.Issued)
+	assert.Len(t, resp.Failures, 1)
+	assert.Equal(t, int64(8), resp.Failures[0].UserID)
+	assert.Equal(t, model.StatusProcessing, resp.Period.Status)
+}
+
+func TestService_Run_Closed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, &fakePayslipIssuer{})
+
+	mockRepo.EXPECT().FindByID(int64(3)).Return(newPeriod(model.StatusClosed), nil)
+
+	_, err := svc.Run(3, 1)
+	assert.ErrorIs(t, err, ErrPeriodClosed)
+}
+
+func TestService_Run_InProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, &fakePayslipIssuer{})
+
+	mockRepo.EXPECT().FindByID(int64(3)).Return(newPeriod(model.StatusProcessing), nil)
+	mockRepo.EXPECT().TryLock(int64(3)).Return(nil, false, nil)
+
+	_, err := svc.Run(3, 1)
+	assert.ErrorIs(t, err, ErrRunInProgress)
+}
+
+func TestService_Close(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, &fakePayslipIssuer{})
+
+	mockRepo.EXPECT().TryLock(int64(3)).Return(func() {}, true, nil).Times(3)
+
+	// Never run.
+	mockRepo.EXPECT().FindByID(int64(3)).Return(newPeriod(model.StatusOpen), nil)
+	_, err := svc.Close(3, 1)
+	assert.ErrorIs(t, err, ErrPeriodNotProcessed)
+
+	// Run, but a payslip is still missing.
+	mockRepo.EXPECT().FindByID(int64(3)).Return(newPeriod(model.StatusProcessing), nil)
+	mockRepo.EXPECT().FetchPendingUsers(gomock.Any()).Return([]int64{8}, nil)
+	_, err = svc.Close(3, 1)
+	assert.ErrorIs(t, err, ErrRunIncomplete)
+
+	mockRepo.EXPECT().FindByID(int64(3)).Return(newPeriod(model.StatusProcessing), nil)
+	mockRepo.EXPECT().FetchPendingUsers(gomock.Any()).Return([]int64{}, nil)
+	mockRepo.EXPECT().Transition(int64(3), model.StatusProcessing, model.StatusClosed, int64(1)).Return(true, nil)
+	mockRepo.EXPECT().FindByID(int64(3)).Return(newPeriod(model.StatusClosed), nil)
+	resp, err := svc.Close(3, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusClosed, resp.Data.Status)
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+type PayslipRequest struct {
+	UserID int64  `json:"user_id" binding:"required"`
+	Period string `json:"period" binding:"required" example:"2025-01"`
+	By     int64  `json:"by" swaggerignore:"true"`
+}
+
+type PayslipFilterRequest struct {
+	UserID int64  `form:"user_id"`
+	Period string `form:"period" example:"2025-01"`
+}
+
+type ComponentRequest struct {
+	ID       int64           `json:"id" swaggerignore:"true"`
+	Code     string          `json:"code" binding:"required" example:"BPJS_HEALTH"`
+	Name     string          `json:"name" binding:"required" example:"BPJS Health"`
+	Type     string          `json:"type" binding:"required,oneof=earning deduction" example:"deduction"`
+	Method   string          `json:"method" binding:"required,oneof=fixed percentage" example:"percentage"`
+	Value    decimal.Decimal `json:"value" binding:"required" swaggertype:"string" example:"1.00"`
+	Sequence int             `json:"sequence"`
+	IsActive *bool           `json:"is_active"`
+	By       int64           `json:"by" swaggerignore:"true"`
+}
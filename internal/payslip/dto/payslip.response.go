@@ -0,0 +1,52 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+type PayslipResponse struct {
+	Data PayslipData `json:"data"`
+}
+
+type PayslipListResponse struct {
+	Data []PayslipData `json:"data"`
+}
+
+type PayslipData struct {
+	ID              int64             `json:"id"`
+	UserID          int64             `json:"user_id"`
+	FullName        string            `json:"full_name"`
+	Period          string            `json:"period" example:"2025-01"`
+	PeriodStart     string            `json:"period_start" example:"2025-01-01"`
+	PeriodEnd       string            `json:"period_end" example:"2025-01-31"`
+	BaseSalary      decimal.Decimal   `json:"base_salary" swaggertype:"string" example:"5000000.00"`
+	GrossEarnings   decimal.Decimal   `json:"gross_earnings" swaggertype:"string" example:"5500000.00"`
+	TotalDeductions decimal.Decimal   `json:"total_deductions" swaggertype:"string" example:"50000.00"`
+	NetPay          decimal.Decimal   `json:"net_pay" swaggertype:"string" example:"5450000.00"`
+	CreatedAt       string            `json:"created_at"`
+	Items           []PayslipItemData `json:"items,omitempty"`
+}
+
+type PayslipItemData struct {
+	Code   string          `json:"code"`
+	Name   string          `json:"name"`
+	Type   string          `json:"type"`
+	Amount decimal.Decimal `json:"amount" swaggertype:"string" example:"500000.00"`
+}
+
+type ComponentResponse struct {
+	Data ComponentData `json:"data"`
+}
+
+type ComponentListResponse struct {
+	Data []ComponentData `json:"data"`
+}
+
+type ComponentData struct {
+	ID       int64           `json:"id"`
+	Code     string          `json:"code"`
+	Name     string          `json:"name"`
+	Type     string          `json:"type"`
+	Method   string          `json:"method"`
+	Value    decimal.Decimal `json:"value" swaggertype:"string" example:"1.00"`
+	Sequence int             `json:"sequence"`
+	IsActive bool            `json:"is_active"`
+}
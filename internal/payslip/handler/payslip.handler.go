@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dwilanang/psp/internal/auth/util"
+	"github.com/dwilanang/psp/internal/payslip"
+	"github.com/dwilanang/psp/internal/payslip/dto"
+	"github.com/dwilanang/psp/internal/permission"
+	"github.com/dwilanang/psp/utils"
+	utilrequest "github.com/dwilanang/psp/utils/request"
+	"github.com/dwilanang/psp/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	Deps payslip.Dependencies
+}
+
+func NewHandler(deps payslip.Dependencies) *Handler {
+	return &Handler{
+		Deps: deps,
+	}
+}
+
+// Generate godoc
+// @Security BearerAuth
+// @Summary      Generate payslip
+// @Description  Compute and store the payslip of a user for a pay period from the salary in effect at the end of the period. Closed payroll periods are rejected
+// @Tags         payslip
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.PayslipRequest  true  "Payslip payload"
+// @Success      201   {object}  response.ApiResponse{data=dto.PayslipData}
+// @Failure      400   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      422   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /payslips/generate [post]
+func (h *Handler) Generate(c *gin.Context) {
+	var pr dto.PayslipRequest
+	if err := c.ShouldBindJSON(&pr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	pr.By = by
+
+	resp, err := h.Deps.Service.Generate(&pr)
+	if err != nil {
+		response.Error(c, err, "could not generate payslip")
+		return
+	}
+	response.Created(c, resp.Data)
+}
+
+// GetAll godoc
+// @Security BearerAuth
+// @Summary      Get payslips
+// @Description  Get payslips without line items, optionally filtered by user and period
+// @Tags         payslip
+// @Accept       json
+// @Produce      json
+// @Param        user_id  query     int     false  "User ID"
+// @Param        period   query     string  false  "Period (YYYY-MM)"
+// @Success      200  {object}  response.ApiResponse{data=[]dto.PayslipData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /payslips/all [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	var fr dto.PayslipFilterRequest
+	if err := c.ShouldBindQuery(&fr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	resp, err := h.Deps.Service.List(&fr)
+	if err != nil {
+		response.Error(c, err, "could not fetch payslips")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// Get godoc
+// @Security BearerAuth
+// @Summary      Get payslip
+// @Description  Get a payslip with its line items
+// @Tags         payslip
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Payslip ID"
+// @Success      200  {object}  response.ApiResponse{data=dto.PayslipData}
+// @Failure      404  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /payslips/detail/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	resp, err := h.Deps.Service.Get(utils.ConvertStringToInt(c.Param("id")))
+	if err != nil {
+		response.Error(c, err, "could not fetch payslip")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// PDF godoc
+// @Security BearerAuth
+// @Summary      Download payslip PDF
+// @Description  Render a payslip as a PDF document. Employees may only download their own payslips unless they hold payslips:read
+// @Tags         payslip
+// @Produce      application/pdf
+// @Param        id        path      int     true   "Payslip ID"
+// @Param        template  query     string  false  "Template name (default from PAYSLIP_TEMPLATE)"
+// @Success      200  {file}    binary
+// @Failure      404  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /payslips/{id}/pdf [get]
+func (h *Handler) PDF(c *gin.Context) {
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	set, _ := c.Get("permissions")
+	permissions, _ := set.(permission.Set)
+
+	doc, name, err := h.Deps.Service.PDF(utils.ConvertStringToInt(c.Param("id")), by, permissions.Allows(permission.PayslipsRead), c.Query("template"))
+	if err != nil {
+		response.Error(c, err, "could not render payslip")
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	c.Data(http.StatusOK, "application/pdf", doc)
+}
+
+// GetComponents godoc
+// @Security BearerAuth
+// @Summary      Get payslip components
+// @Description  Get every configured earning and deduction component
+// @Tags         payslip
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.ApiResponse{data=[]dto.ComponentData}
+// @Failure      500  {object}  response.Problem
+// @Router       /payslips/components/all [get]
+func (h *Handler) GetComponents(c *gin.Context) {
+	resp, err := h.Deps.Service.Components()
+	if err != nil {
+		response.Error(c, err, "could not fetch payslip components")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// CreateComponent godoc
+// @Security BearerAuth
+// @Summary      Create payslip component
+// @Description  Add an earning or deduction, either a fixed amount or a percentage of the base salary
+// @Tags         payslip
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.ComponentRequest  true  "Payslip component payload"
+// @Success      201   {object}  response.ApiResponse{data=dto.ComponentData}
+// @Failure      400   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /payslips/components/create [post]
+func (h *Handler) CreateComponent(c *gin.Context) {
+	var cr dto.ComponentRequest
+	if err := c.ShouldBindJSON(&cr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	cr.By = by
+
+	resp, err := h.Deps.Service.CreateComponent(&cr)
+	if err != nil {
+		response.Error(c, err, "could not create payslip component")
+		return
+	}
+	response.Created(c, resp.Data)
+}
+
+// UpdateComponent godoc
+// @Security BearerAuth
+// @Summary      Update payslip component
+// @Description  Update a payslip component; payslips already generated are not affected
+// @Tags         payslip
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                   true  "Component ID"
+// @Param        body  body      dto.ComponentRequest  true  "Payslip component payload"
+// @Success      200   {object}  response.ApiResponse{data=dto.ComponentData}
+// @Failure      400   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /payslips/components/update/{id} [put]
+func (h *Handler) UpdateComponent(c *gin.Context) {
+	var cr dto.ComponentRequest
+	if err := c.ShouldBindJSON(&cr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	cr.ID = utils.ConvertStringToInt(c.Param("id"))
+	cr.By = by
+
+	resp, err := h.Deps.Service.UpdateComponent(&cr)
+	if err != nil {
+		response.Error(c, err, "could not update payslip component")
+		return
+	}
+	response.OK(c, resp.Data)
+}
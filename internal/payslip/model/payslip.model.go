@@ -0,0 +1,101 @@
+package model
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/shopspring/decimal"
+)
+
+// Item types and component calculation methods.
+const (
+	TypeEarning   = "earning"
+	TypeDeduction = "deduction"
+
+	MethodFixed      = "fixed"      // the component value is the amount
+	MethodPercentage = "percentage" // the component value is a percentage of the base salary
+)
+
+// PeriodLayout is the format of a pay period code, e.g. "2025-01".
+const PeriodLayout = "2006-01"
+
+var ErrInvalidPeriod = apperror.Validation("period must be formatted as YYYY-MM")
+
+// Period is a calendar month of pay, from Start through End inclusive.
+type Period struct {
+	Code  string
+	Start time.Time
+	End   time.Time
+}
+
+// ParsePeriod parses a period code formatted as YYYY-MM.
+func ParsePeriod(code string) (Period, error) {
+	start, err := time.Parse(PeriodLayout, code)
+	if err != nil {
+		return Period{}, ErrInvalidPeriod
+	}
+	return Period{Code: code, Start: start, End: start.AddDate(0, 1, -1)}, nil
+}
+
+type Payslip struct {
+	ID              int64           `db:"id"`
+	UserID          int64           `db:"user_id"`
+	FullName        string          `db:"full_name"`
+	Period          string          `db:"period"`
+	PeriodStart     time.Time       `db:"period_start"`
+	PeriodEnd       time.Time       `db:"period_end"`
+	BaseSalary      decimal.Decimal `db:"base_salary"`
+	GrossEarnings   decimal.Decimal `db:"gross_earnings"`
+	TotalDeductions decimal.Decimal `db:"total_deductions"`
+	NetPay          decimal.Decimal `db:"net_pay"`
+	CreatedBy       int64           `db:"created_by"`
+	CreatedAt       time.Time       `db:"created_at"`
+	Items           []*PayslipItem  `db:"-"`
+}
+
+type PayslipItem struct {
+	ID        int64           `db:"id"`
+	PayslipID int64           `db:"payslip_id"`
+	Code      string          `db:"code"`
+	Name      string          `db:"name"`
+	Type      string          `db:"type"`
+	Amount    decimal.Decimal `db:"amount"`
+	Sequence  int             `db:"sequence"`
+}
+
+type PayslipFilter struct {
+	UserID int64
+	Period string
+}
+
+// Component is a configurable earning or deduction applied to every payslip.
+type Component struct {
+	ID        int64           `db:"id"`
+	Code      string          `db:"code"`
+	Name      string          `db:"name"`
+	Type      string          `db:"type"`
+	Method    string          `db:"method"`
+	Value     decimal.Decimal `db:"value"`
+	Sequence  int             `db:"sequence"`
+	IsActive  bool            `db:"is_active"`
+	CreatedBy int64           `db:"created_by"`
+	UpdatedBy int64           `db:"updated_by"`
+}
+
+// Amount returns the component amount for a base salary, rounded to cents.
+func (c *Component) Amount(base decimal.Decimal) decimal.Decimal {
+	if c.Method == MethodPercentage {
+		return base.Mul(c.Value).Div(decimal.NewFromInt(100)).Round(2)
+	}
+	return c.Value.Round(2)
+}
+
+// Calculation is the state of a payslip being computed. It is handed to every contributor
+// so modules such as attendance or overtime can add their own line items.
+type Calculation struct {
+	UserID     int64
+	FullName   string
+	Period     Period
+	BaseSalary decimal.Decimal
+	Items      []*PayslipItem
+}
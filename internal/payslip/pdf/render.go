@@ -0,0 +1,169 @@
+package pdf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dwilanang/psp/internal/payslip/model"
+	"github.com/go-pdf/fpdf"
+	"github.com/shopspring/decimal"
+)
+
+// Renderer renders payslips as PDF documents with a pure-Go generator.
+type Renderer struct {
+	dir             string
+	defaultTemplate string
+	companyName     string
+}
+
+// NewRenderer creates a renderer reading templates from dir. Templates are read on every render,
+// so edits apply without a restart. companyName is used when a template does not set one.
+func NewRenderer(dir string, defaultTemplate string, companyName string) *Renderer {
+	return &Renderer{dir: dir, defaultTemplate: defaultTemplate, companyName: companyName}
+}
+
+// Render writes the payslip as a PDF to w using the named template, or the default one when name is empty.
+func (r *Renderer) Render(w io.Writer, payslip *model.Payslip, name string) error {
+	tmpl, err := r.template(name)
+	if err != nil {
+		return err
+	}
+
+	doc := fpdf.New(tmpl.Orientation, "mm", tmpl.PageSize, "")
+	doc.SetTitle(fmt.Sprintf("%s %s", tmpl.Title, payslip.Period), true)
+	doc.SetAuthor(tmpl.CompanyName, true)
+	doc.SetMargins(15, 15, 15)
+	doc.SetAutoPageBreak(true, 20)
+	doc.SetFooterFunc(func() {
+		doc.SetY(-15)
+		doc.SetFont(tmpl.FontFamily, "I", 8)
+		doc.SetTextColor(120, 120, 120)
+		doc.CellFormat(0, 5, tr(doc, tmpl.Footer), "", 0, "C", false, 0, "")
+	})
+	doc.AddPage()
+
+	width, _ := doc.GetPageSize()
+	left, _, right, _ := doc.GetMargins()
+	content := width - left - right
+	r0, g0, b0 := rgb(tmpl.AccentColor)
+
+	// Company header.
+	doc.SetTextColor(r0, g0, b0)
+	doc.SetFont(tmpl.FontFamily, "B", 16)
+	doc.CellFormat(content, 8, tr(doc, tmpl.CompanyName), "", 1, "L", false, 0, "")
+	if tmpl.CompanyAddress != "" {
+		doc.SetTextColor(90, 90, 90)
+		doc.SetFont(tmpl.FontFamily, "", 9)
+		doc.MultiCell(content, 4.5, tr(doc, tmpl.CompanyAddress), "", "L", false)
+	}
+	doc.SetDrawColor(r0, g0, b0)
+	doc.SetLineWidth(0.6)
+	doc.Line(left, doc.GetY()+2, width-right, doc.GetY()+2)
+	doc.Ln(6)
+
+	// Title and employee details.
+	doc.SetTextColor(0, 0, 0)
+	doc.SetFont(tmpl.FontFamily, "B", 13)
+	doc.CellFormat(content, 8, tr(doc, tmpl.Title), "", 1, "C", false, 0, "")
+	doc.Ln(2)
+	detail := func(label, value string) {
+		doc.SetFont(tmpl.FontFamily, "", 10)
+		doc.CellFormat(35, 6, tr(doc, label), "", 0, "L", false, 0, "")
+		doc.SetFont(tmpl.FontFamily, "B", 10)
+		doc.CellFormat(content-35, 6, tr(doc, value), "", 1, "L", false, 0, "")
+	}
+	detail("Employee", payslip.FullName)
+	detail("Period", fmt.Sprintf("%s (%s - %s)", payslip.Period,
+		payslip.PeriodStart.Format("02 Jan 2006"), payslip.PeriodEnd.Format("02 Jan 2006")))
+	detail("Payslip No.", fmt.Sprintf("%s-%06d", strings.ReplaceAll(payslip.Period, "-", ""), payslip.ID))
+	doc.Ln(4)
+
+	// Line items, earnings first.
+	r.section(doc, tmpl, content, "Earnings", payslip.Items, model.TypeEarning, payslip.GrossEarnings, "Gross Earnings")
+	doc.Ln(3)
+	r.section(doc, tmpl, content, "Deductions", payslip.Items, model.TypeDeduction, payslip.TotalDeductions, "Total Deductions")
+	doc.Ln(5)
+
+	// Net pay.
+	doc.SetFillColor(r0, g0, b0)
+	doc.SetTextColor(255, 255, 255)
+	doc.SetFont(tmpl.FontFamily, "B", 12)
+	doc.CellFormat(content*0.6, 10, "  NET PAY", "", 0, "L", true, 0, "")
+	doc.CellFormat(content*0.4, 10, tr(doc, money(tmpl, payslip.NetPay))+"  ", "", 1, "R", true, 0, "")
+
+	if err := doc.Error(); err != nil {
+		return err
+	}
+	return doc.Output(w)
+}
+
+func (r *Renderer) section(doc *fpdf.Fpdf, tmpl Template, content float64, title string,
+	items []*model.PayslipItem, itemType string, total decimal.Decimal, totalLabel string) {
+	r0, g0, b0 := rgb(tmpl.AccentColor)
+
+	doc.SetTextColor(r0, g0, b0)
+	doc.SetFont(tmpl.FontFamily, "B", 11)
+	doc.CellFormat(content, 7, tr(doc, title), "B", 1, "L", false, 0, "")
+
+	doc.SetTextColor(0, 0, 0)
+	doc.SetFont(tmpl.FontFamily, "", 10)
+	for _, item := range items {
+		if item.Type != itemType {
+			continue
+		}
+		name := item.Name
+		if tmpl.ShowItemCodes {
+			name = fmt.Sprintf("%s (%s)", item.Name, item.Code)
+		}
+		doc.CellFormat(content*0.6, 6, tr(doc, name), "", 0, "L", false, 0, "")
+		doc.CellFormat(content*0.4, 6, tr(doc, money(tmpl, item.Amount)), "", 1, "R", false, 0, "")
+	}
+
+	doc.SetFont(tmpl.FontFamily, "B", 10)
+	doc.CellFormat(content*0.6, 7, tr(doc, totalLabel), "T", 0, "L", false, 0, "")
+	doc.CellFormat(content*0.4, 7, tr(doc, money(tmpl, total)), "T", 1, "R", false, 0, "")
+}
+
+func (r *Renderer) template(name string) (Template, error) {
+	fallback := DefaultTemplate(r.companyName)
+	if name == "" {
+		name = r.defaultTemplate
+	}
+
+	tmpl, err := LoadTemplate(r.dir, name, fallback)
+	if errors.Is(err, ErrTemplateNotFound) && name == r.defaultTemplate {
+		return fallback, nil
+	}
+	return tmpl, err
+}
+
+// tr converts UTF-8 text to the code page of the core fonts.
+func tr(doc *fpdf.Fpdf, text string) string {
+	return doc.UnicodeTranslatorFromDescriptor("")(text)
+}
+
+// money formats an amount with thousands separators and two decimals, prefixed by the template currency.
+func money(tmpl Template, amount decimal.Decimal) string {
+	text := amount.StringFixed(2)
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(text, ".")
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	formatted := sign + grouped.String() + "." + fraction
+	if tmpl.Currency != "" {
+		formatted = tmpl.Currency + " " + formatted
+	}
+	return formatted
+}
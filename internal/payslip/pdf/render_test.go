@@ -0,0 +1,78 @@
+package pdf
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dwilanang/psp/internal/payslip/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPayslip() *model.Payslip {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &model.Payslip{
+		ID:              10,
+		UserID:          7,
+		FullName:        "José Doe",
+		Period:          "2025-01",
+		PeriodStart:     start,
+		PeriodEnd:       start.AddDate(0, 1, -1),
+		BaseSalary:      decimal.NewFromInt(5000000),
+		GrossEarnings:   decimal.NewFromInt(5300000),
+		TotalDeductions: decimal.NewFromInt(50000),
+		NetPay:          decimal.NewFromInt(5250000),
+		Items: []*model.PayslipItem{
+			{Code: "BASE_SALARY", Name: "Base Salary", Type: model.TypeEarning, Amount: decimal.NewFromInt(5000000)},
+			{Code: "ALLOWANCE", Name: "Transport Allowance", Type: model.TypeEarning, Amount: decimal.NewFromInt(300000)},
+			{Code: "BPJS", Name: "BPJS Health", Type: model.TypeDeduction, Amount: decimal.NewFromInt(50000)},
+		},
+	}
+}
+
+func TestRender_DefaultTemplateWithoutFile(t *testing.T) {
+	renderer := NewRenderer(t.TempDir(), "default", "payslip-service")
+
+	var buf bytes.Buffer
+	err := renderer.Render(&buf, testPayslip(), "")
+
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
+
+func TestRender_NamedTemplate(t *testing.T) {
+	dir := t.TempDir()
+	tmpl := `{"company_name": "ACME", "page_size": "Letter", "currency": "USD", "show_item_codes": true}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "acme.json"), []byte(tmpl), 0o600))
+
+	renderer := NewRenderer(dir, "default", "payslip-service")
+
+	var buf bytes.Buffer
+	require.NoError(t, renderer.Render(&buf, testPayslip(), "acme"))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+
+	assert.ErrorIs(t, renderer.Render(&buf, testPayslip(), "missing"), ErrTemplateNotFound)
+	assert.ErrorIs(t, renderer.Render(&buf, testPayslip(), "../acme"), ErrTemplateNotFound)
+}
+
+func TestLoadTemplate_KeepsFallbackFields(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "short.json"), []byte(`{"title": "SLIP GAJI"}`), 0o600))
+
+	tmpl, err := LoadTemplate(dir, "short", DefaultTemplate("ACME"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "SLIP GAJI", tmpl.Title)
+	assert.Equal(t, "ACME", tmpl.CompanyName)
+	assert.Equal(t, "A4", tmpl.PageSize)
+}
+
+func TestMoney(t *testing.T) {
+	assert.Equal(t, "IDR 5,250,000.00", money(Template{Currency: "IDR"}, decimal.NewFromInt(5250000)))
+	assert.Equal(t, "-125.50", money(Template{}, decimal.RequireFromString("-125.5")))
+	assert.Equal(t, "999.00", money(Template{}, decimal.NewFromInt(999)))
+}
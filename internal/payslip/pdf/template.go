@@ -0,0 +1,75 @@
+package pdf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/dwilanang/psp/pkg/apperror"
+)
+
+var ErrTemplateNotFound = apperror.NotFound("payslip template not found")
+
+var templateName = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// Template configures the layout of a rendered payslip. Templates are JSON files named <name>.json
+// in the template directory, so the document can be changed without rebuilding the service.
+type Template struct {
+	CompanyName    string `json:"company_name"`
+	CompanyAddress string `json:"company_address"`
+	Title          string `json:"title"`
+	Currency       string `json:"currency"`
+	PageSize       string `json:"page_size"`   // A4, Letter, ...
+	Orientation    string `json:"orientation"` // P or L
+	FontFamily     string `json:"font_family"` // a core font: Arial, Helvetica, Times or Courier
+	AccentColor    string `json:"accent_color"`
+	ShowItemCodes  bool   `json:"show_item_codes"`
+	Footer         string `json:"footer"`
+}
+
+// DefaultTemplate is used when the default template has no file of its own.
+func DefaultTemplate(companyName string) Template {
+	return Template{
+		CompanyName: companyName,
+		Title:       "PAYSLIP",
+		PageSize:    "A4",
+		Orientation: "P",
+		FontFamily:  "Helvetica",
+		AccentColor: "#1F3A93",
+		Footer:      "This payslip is generated electronically and does not require a signature.",
+	}
+}
+
+// LoadTemplate reads the template called name from dir. Fields left empty in the file
+// are taken from fallback.
+func LoadTemplate(dir string, name string, fallback Template) (Template, error) {
+	if !templateName.MatchString(name) {
+		return Template{}, ErrTemplateNotFound
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Template{}, ErrTemplateNotFound
+		}
+		return Template{}, err
+	}
+
+	tmpl := fallback
+	if err := json.Unmarshal(data, &tmpl); err != nil {
+		return Template{}, fmt.Errorf("parse payslip template %q: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// rgb parses a #RRGGBB color, falling back to black.
+func rgb(hex string) (int, int, int) {
+	var r, g, b int
+	if _, err := fmt.Sscanf(hex, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return 0, 0, 0
+	}
+	return r, g, b
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"github.com/dwilanang/psp/internal/payslip/model"
+)
+
+//go:generate mockgen -source=payslip.repository.go -package=mocks -destination=mocks/mock_payslip_repository.go
+
+// Repository defines an interface for data operations related to payslips and their components.
+// Payslips are insert-only; there is deliberately no method to update or delete one.
+type Repository interface {
+	// Fetch retrieves payslips matching the filter without their line items, most recent period first.
+	// Param: filter - a pointer to PayslipFilter; zero fields are ignored.
+	// Returns a slice of Payslip pointers and an error if the operation fails.
+	Fetch(filter *model.PayslipFilter) ([]*model.Payslip, error)
+
+	// FindByID retrieves a payslip and its line items by ID.
+	// Param: id - the ID of the payslip.
+	// Returns a pointer to the Payslip and an error if the operation fails or the payslip is not found.
+	FindByID(id int64) (*model.Payslip, error)
+
+	// Create inserts a payslip and its line items in a single transaction.
+	// Param: payslip - a pointer to the Payslip entity to be created.
+	// Returns sql.ErrNoRows if the user already has a payslip for the period, or an error if the operation fails.
+	Create(payslip *model.Payslip) error
+
+	// FetchComponents retrieves the configured payslip components ordered by sequence.
+	// Param: activeOnly - whether to skip inactive components.
+	// Returns a slice of Component pointers and an error if the operation fails.
+	FetchComponents(activeOnly bool) ([]*model.Component, error)
+
+	// CreateComponent inserts a new payslip component.
+	// Param: component - a pointer to the Component entity to be created.
+	// Returns an error if the operation fails.
+	CreateComponent(component *model.Component) error
+
+	// UpdateComponent modifies an existing payslip component.
+	// Param: component - a pointer to the Component entity with updated data.
+	// Returns sql.ErrNoRows if the component does not exist, or an error if the operation fails.
+	UpdateComponent(component *model.Component) error
+}
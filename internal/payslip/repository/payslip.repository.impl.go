@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/payslip/model"
+)
+
+const selectPayslip = `
+		SELECT 
+			id, user_id, full_name, period, period_start, period_end,
+			base_salary, gross_earnings, total_deductions, net_pay,
+			COALESCE(created_by, 0) AS created_by,
+			created_at
+		FROM payslips
+`
+
+const selectComponent = `
+		SELECT 
+			id, code, name, type, method, value, sequence, is_active,
+			COALESCE(created_by, 0) AS created_by,
+			COALESCE(updated_by, created_by, 0) AS updated_by
+		FROM payslip_components
+`
+
+type repository struct {
+	db *postgres.DB
+}
+
+func NewRepository(db *postgres.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Fetch(filter *model.PayslipFilter) ([]*model.Payslip, error) {
+	payslips := []*model.Payslip{}
+	query := selectPayslip + `
+		WHERE ($1 = 0 OR user_id = $1) AND ($2 = '' OR period = $2)
+		ORDER BY period DESC, full_name
+	`
+	err := r.db.Select(&payslips, query, filter.UserID, filter.Period)
+	if err != nil {
+		return nil, err
+	}
+	return payslips, nil
+}
+
+func (r *repository) FindByID(id int64) (*model.Payslip, error) {
+	var payslip model.Payslip
+	query := selectPayslip + `
+		WHERE id = $1
+	`
+	err := r.db.Get(&payslip, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	itemQuery := `
+		SELECT id, payslip_id, code, name, type, amount, sequence
+		FROM payslip_items
+		WHERE payslip_id = $1
+		ORDER BY sequence
+	`
+	err = r.db.Select(&payslip.Items, itemQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	return &payslip, nil
+}
+
+func (r *repository) Create(payslip *model.Payslip) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// ON CONFLICT makes a concurrent or repeated generation a no-op that surfaces as sql.ErrNoRows.
+	query := `
+		INSERT INTO payslips (
+			user_id, full_name, period, period_start, period_end,
+			base_salary, gross_earnings, total_deductions, net_pay, created_by, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		ON CONFLICT (user_id, period) DO NOTHING
+		RETURNING id, created_at
+	`
+	err = tx.QueryRowx(
+		query,
+		payslip.UserID,
+		payslip.FullName,
+		payslip.Period,
+		payslip.PeriodStart,
+		payslip.PeriodEnd,
+		payslip.BaseSalary,
+		payslip.GrossEarnings,
+		payslip.TotalDeductions,
+		payslip.NetPay,
+		payslip.CreatedBy,
+	).Scan(&payslip.ID, &payslip.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	itemQuery := `
+		INSERT INTO payslip_items (payslip_id, code, name, type, amount, sequence)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	for _, item := range payslip.Items {
+		item.PayslipID = payslip.ID
+		err = tx.QueryRowx(itemQuery, item.PayslipID, item.Code, item.Name, item.Type, item.Amount, item.Sequence).Scan(&item.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *repository) FetchComponents(activeOnly bool) ([]*model.Component, error) {
+	components := []*model.Component{}
+	query := selectComponent + `
+		WHERE (NOT $1 OR is_active)
+		ORDER BY sequence, id
+	`
+	err := r.db.Select(&components, query, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	return components, nil
+}
+
+func (r *repository) CreateComponent(component *model.Component) error {
+	query := `
+		INSERT INTO payslip_components (code, name, type, method, value, sequence, is_active, created_by, created_at, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), $8, NOW())
+		RETURNING id
+	`
+	return r.db.QueryRowx(
+		query,
+		component.Code,
+		component.Name,
+		component.Type,
+		component.Method,
+		component.Value,
+		component.Sequence,
+		component.IsActive,
+		component.CreatedBy,
+	).Scan(&component.ID)
+}
+
+func (r *repository) UpdateComponent(component *model.Component) error {
+	query := `
+		UPDATE payslip_components
+		SET code = $1, name = $2, type = $3, method = $4, value = $5, sequence = $6, is_active = $7, updated_by = $8, updated_at = NOW()
+		WHERE id = $9
+		RETURNING COALESCE(created_by, 0)
+	`
+	return r.db.QueryRowx(
+		query,
+		component.Code,
+		component.Name,
+		component.Type,
+		component.Method,
+		component.Value,
+		component.Sequence,
+		component.IsActive,
+		component.UpdatedBy,
+		component.ID,
+	).Scan(&component.CreatedBy)
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/payslip/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*postgres.DB, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return postgres.NewDB(sqlx.NewDb(db, "postgres")), mock, func() { db.Close() }
+}
+
+var payslipColumns = []string{"id", "user_id", "full_name", "period", "period_start", "period_end", "base_salary", "gross_earnings", "total_deductions", "net_pay", "created_by", "created_at"}
+
+func newPayslip() *model.Payslip {
+	period, _ := model.ParsePeriod("2025-01")
+	return &model.Payslip{
+		UserID:          7,
+		FullName:        "John Doe",
+		Period:          period.Code,
+		PeriodStart:     period.Start,
+		PeriodEnd:       period.End,
+		BaseSalary:      decimal.NewFromInt(5000000),
+		GrossEarnings:   decimal.NewFromInt(5000000),
+		TotalDeductions: decimal.NewFromInt(50000),
+		NetPay:          decimal.NewFromInt(4950000),
+		CreatedBy:       1,
+		Items: []*model.PayslipItem{
+			{Code: "BASE_SALARY", Name: "Base Salary", Type: model.TypeEarning, Amount: decimal.NewFromInt(5000000), Sequence: 1},
+			{Code: "BPJS", Name: "BPJS", Type: model.TypeDeduction, Amount: decimal.NewFromInt(50000), Sequence: 2},
+		},
+	}
+}
+
+func TestCreate_Success(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+	payslip := newPayslip()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO payslips").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(10, time.Now()))
+	mock.ExpectQuery("INSERT INTO payslip_items").
+		WithArgs(int64(10), "BASE_SALARY", "Base Salary", model.TypeEarning, payslip.Items[0].Amount, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("INSERT INTO payslip_items").
+		WithArgs(int64(10), "BPJS", "BPJS", model.TypeDeduction, payslip.Items[1].Amount, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectCommit()
+
+	err := repo.Create(payslip)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), payslip.ID)
+	assert.Equal(t, int64(10), payslip.Items[1].PayslipID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate_AlreadyExists(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("ON CONFLICT \\(user_id, period\\) DO NOTHING").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}))
+	mock.ExpectRollback()
+
+	err := repo.Create(newPayslip())
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindByID_WithItems(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("FROM payslips").
+		WithArgs(int64(10)).
+		WillReturnRows(sqlmock.NewRows(payslipColumns).
+			AddRow(10, 7, "John Doe", "2025-01", start, start.AddDate(0, 1, -1), "5000000.00", "5000000.00", "50000.00", "4950000.00", 1, time.Now()))
+	mock.ExpectQuery("FROM payslip_items").
+		WithArgs(int64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "payslip_id", "code", "name", "type", "amount", "sequence"}).
+			AddRow(1, 10, "BASE_SALARY", "Base Salary", "earning", "5000000.00", 1).
+			AddRow(2, 10, "BPJS", "BPJS", "deduction", "50000.00", 2))
+
+	payslip, err := repo.FindByID(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01", payslip.Period)
+	assert.Len(t, payslip.Items, 2)
+	assert.True(t, decimal.NewFromInt(4950000).Equal(payslip.NetPay))
+}
+
+func TestFetchComponents_ActiveOnly(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectQuery("FROM payslip_components").
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name", "type", "method", "value", "sequence", "is_active", "created_by", "updated_by"}).
+			AddRow(1, "BPJS", "BPJS", "deduction", "percentage", "1.0000", 1, true, 1, 1))
+
+	components, err := repo.FetchComponents(true)
+
+	assert.NoError(t, err)
+	assert.Len(t, components, 1)
+	assert.Equal(t, model.MethodPercentage, components[0].Method)
+}
@@ -0,0 +1,24 @@
+package route
+
+import (
+	"github.com/dwilanang/psp/internal/permission"
+	"github.com/dwilanang/psp/internal/registry"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, registry *registry.Registry) {
+	h := registry.NewPayslipHandler()
+
+	payslipsGroup := rg.Group("/payslips")
+	{
+		payslipsGroup.POST("/generate", registry.RequirePermission(permission.PayslipsGenerate), h.Generate)
+		payslipsGroup.GET("/all", registry.RequirePermission(permission.PayslipsRead), h.GetAll)
+		payslipsGroup.GET("/detail/:id", registry.RequirePermission(permission.PayslipsRead), h.Get)
+		// Any authenticated user may download their own payslip; RequirePermission without arguments only
+		// resolves the caller's permissions so the handler can tell whether they may read other payslips.
+		payslipsGroup.GET("/:id/pdf", registry.RequirePermission(), h.PDF)
+		payslipsGroup.GET("/components/all", registry.RequirePermission(permission.PayslipsRead), h.GetComponents)
+		payslipsGroup.POST("/components/create", registry.RequirePermission(permission.PayslipsConfigure), h.CreateComponent)
+		payslipsGroup.PUT("/components/update/:id", registry.RequirePermission(permission.PayslipsConfigure), h.UpdateComponent)
+	}
+}
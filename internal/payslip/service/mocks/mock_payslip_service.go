@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: payslip.service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	dto "github.com/dwilanang/psp/internal/payslip/dto"
+	model "github.com/dwilanang/psp/internal/payslip/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Components mocks base method.
+func (m *MockService) Components() (dto.ComponentListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Components")
+	ret0, _ := ret[0].(dto.ComponentListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Components indicates an expected call of Components.
+func (mr *MockServiceMockRecorder) Components() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Components", reflect.TypeOf((*MockService)(nil).Components))
+}
+
+// CreateComponent mocks base method.
+func (m *MockService) CreateComponent(request *dto.ComponentRequest) (dto.ComponentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComponent", request)
+	ret0, _ := ret[0].(dto.ComponentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComponent indicates an expected call of CreateComponent.
+func (mr *MockServiceMockRecorder) CreateComponent(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComponent", reflect.TypeOf((*MockService)(nil).CreateComponent), request)
+}
+
+// Generate mocks base method.
+func (m *MockService) Generate(request *dto.PayslipRequest) (dto.PayslipResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", request)
+	ret0, _ := ret[0].(dto.PayslipResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockServiceMockRecorder) Generate(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockService)(nil).Generate), request)
+}
+
+// Get mocks base method.
+func (m *MockService) Get(id int64) (dto.PayslipResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(dto.PayslipResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockServiceMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), id)
+}
+
+// Issue mocks base method.
+func (m *MockService) Issue(userID int64, period model.Period, by int64) (*model.Payslip, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", userID, period, by)
+	ret0, _ := ret[0].(*model.Payslip)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockServiceMockRecorder) Issue(userID, period, by interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockService)(nil).Issue), userID, period, by)
+}
+
+// List mocks base method.
+func (m *MockService) List(request *dto.PayslipFilterRequest) (dto.PayslipListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", request)
+	ret0, _ := ret[0].(dto.PayslipListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), request)
+}
+
+// UpdateComponent mocks base method.
+func (m *MockService) UpdateComponent(request *dto.ComponentRequest) (dto.ComponentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComponent", request)
+	ret0, _ := ret[0].(dto.ComponentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComponent indicates an expected call of UpdateComponent.
+func (mr *MockServiceMockRecorder) UpdateComponent(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComponent", reflect.TypeOf((*MockService)(nil).UpdateComponent), request)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"github.com/dwilanang/psp/internal/payslip/dto"
+	"github.com/dwilanang/psp/internal/payslip/model"
+)
+
+//go:generate mockgen -source=payslip.service.go -package=mocks -destination=mocks/mock_payslip_service.go
+
+// Service defines the interface for business logic related to payslips.
+// A payslip is computed once per user and period and never changes afterwards.
+type Service interface {
+	// Generate computes and stores the payslip of a user for a pay period.
+	// Param: request - a pointer to PayslipRequest DTO.
+	// Returns the generated payslip, ErrPayslipExists if one was already issued, ErrNoSalary if the user has no salary,
+	// or ErrPeriodLocked if the period is a closed payroll period.
+	Generate(request *dto.PayslipRequest) (dto.PayslipResponse, error)
+
+	// Issue computes and stores the payslip of a user for a pay period, for use by other modules such as payroll.
+	// Param: userID - the ID of the user; period - the pay period; by - the ID of the user issuing the payslip.
+	// Returns the stored Payslip model and the same errors as Generate, except that closed periods are not checked.
+	Issue(userID int64, period model.Period, by int64) (*model.Payslip, error)
+
+	// PDF renders a payslip as a PDF document. Viewers without readAll may only render their own payslips;
+	// other payslips are reported as not found so their existence is not disclosed.
+	// Param: id - the ID of the payslip; viewerID - the ID of the requesting user;
+	// readAll - whether the viewer may read every payslip; template - the template name, or empty for the default.
+	// Returns the document, its file name, and ErrPayslipNotFound or ErrTemplateNotFound.
+	PDF(id int64, viewerID int64, readAll bool, template string) ([]byte, string, error)
+
+	// List retrieves payslips without their line items, optionally filtered by user and period.
+	// Param: request - a pointer to PayslipFilterRequest DTO.
+	// Returns a PayslipListResponse DTO and an error if the operation fails.
+	List(request *dto.PayslipFilterRequest) (dto.PayslipListResponse, error)
+
+	// Get retrieves a payslip with its line items.
+	// Param: id - the ID of the payslip.
+	// Returns a PayslipResponse DTO and ErrPayslipNotFound if it does not exist.
+	Get(id int64) (dto.PayslipResponse, error)
+
+	// Components retrieves every configured earning and deduction component.
+	// Returns a ComponentListResponse DTO and an error if the operation fails.
+	Components() (dto.ComponentListResponse, error)
+
+	// CreateComponent adds an earning or deduction component applied to payslips generated from now on.
+	// Param: request - a pointer to ComponentRequest DTO.
+	// Returns the created component and ErrInvalidComponent if its value is invalid.
+	CreateComponent(request *dto.ComponentRequest) (dto.ComponentResponse, error)
+
+	// UpdateComponent modifies a component; payslips already issued are not affected.
+	// Param: request - a pointer to ComponentRequest DTO.
+	// Returns the updated component and ErrComponentNotFound if it does not exist.
+	UpdateComponent(request *dto.ComponentRequest) (dto.ComponentResponse, error)
+}
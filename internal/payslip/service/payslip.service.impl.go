@@ -0,0 +1,333 @@
+package service
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dwilanang/psp/internal/payroll/lock"
+	"github.com/dwilanang/psp/internal/payslip/dto"
+	"github.com/dwilanang/psp/internal/payslip/model"
+	"github.com/dwilanang/psp/internal/payslip/pdf"
+	"github.com/dwilanang/psp/internal/payslip/repository"
+	salarymodel "github.com/dwilanang/psp/internal/salary/model"
+	salaryservice "github.com/dwilanang/psp/internal/salary/service"
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/shopspring/decimal"
+)
+
+// BaseSalaryCode is the code of the line item carrying the user's salary.
+const BaseSalaryCode = "BASE_SALARY"
+
+var (
+	ErrPayslipNotFound   = apperror.NotFound("payslip not found")
+	ErrPayslipExists     = apperror.Conflict("payslip already generated for this period")
+	ErrNoSalary          = apperror.Unprocessable("user has no salary in effect at the end of the period")
+	ErrComponentNotFound = apperror.NotFound("payslip component not found")
+	ErrInvalidComponent  = apperror.Validation("component value must not be negative and percentages must not exceed 100")
+	ErrInvalidItem       = errors.New("payslip item must be an earning or a deduction")
+	ErrTemplateNotFound  = pdf.ErrTemplateNotFound
+	ErrPeriodLocked      = apperror.Conflict("payslip period is a closed payroll period")
+)
+
+// SalaryResolver resolves the salary in effect for a user on a given day.
+// It is satisfied by the salary service.
+type SalaryResolver interface {
+	Resolve(userID int64, date time.Time) (*salarymodel.Salary, error)
+}
+
+// Renderer writes a payslip as a document using a named template.
+// It is satisfied by the PDF renderer.
+type Renderer interface {
+	Render(w io.Writer, payslip *model.Payslip, template string) error
+}
+
+// Contributor adds line items to a payslip being computed, e.g. overtime pay or unpaid leave.
+// Contributors run in registration order and see the items added before them.
+type Contributor interface {
+	Contribute(calc *model.Calculation) ([]*model.PayslipItem, error)
+}
+
+type service struct {
+	repo         repository.Repository
+	salaries     SalaryResolver
+	documents    Renderer
+	periods      lock.PeriodLocker
+	contributors []Contributor
+}
+
+// NewService creates the payslip service. documents may be nil when payslips are not rendered, and periods
+// when they are not generated one at a time, e.g. by payroll runs.
+func NewService(r repository.Repository, salaries SalaryResolver, documents Renderer, periods lock.PeriodLocker, contributors ...Contributor) *service {
+	return &service{
+		repo:         r,
+		salaries:     salaries,
+		documents:    documents,
+		periods:      periods,
+		contributors: contributors,
+	}
+}
+
+// Generate implements the Service interface.
+func (s *service) Generate(request *dto.PayslipRequest) (dto.PayslipResponse, error) {
+	period, err := model.ParsePeriod(request.Period)
+	if err != nil {
+		return dto.PayslipResponse{}, err
+	}
+
+	// Payroll runs check the period themselves; a closed period must not gain payslips afterwards.
+	locked, err := s.periods.IsLocked(period.Start, &period.End)
+	if err != nil {
+		return dto.PayslipResponse{}, err
+	}
+	if locked {
+		return dto.PayslipResponse{}, ErrPeriodLocked
+	}
+
+	payslip, err := s.Issue(request.UserID, period, request.By)
+	if err != nil {
+		return dto.PayslipResponse{}, err
+	}
+	return dto.PayslipResponse{Data: toData(payslip)}, nil
+}
+
+// Issue implements the Service interface.
+func (s *service) Issue(userID int64, period model.Period, by int64) (*model.Payslip, error) {
+	// The salary in effect on the last day of the period is the one paid for it.
+	salary, err := s.salaries.Resolve(userID, period.End)
+	if err != nil {
+		if errors.Is(err, salaryservice.ErrSalaryNotFound) {
+			return nil, ErrNoSalary
+		}
+		return nil, err
+	}
+
+	components, err := s.repo.FetchComponents(true)
+	if err != nil {
+		return nil, err
+	}
+
+	calc := &model.Calculation{
+		UserID:     userID,
+		FullName:   salary.FullName,
+		Period:     period,
+		BaseSalary: salary.Amount.Round(2),
+	}
+	calc.Items = append(calc.Items, &model.PayslipItem{
+		Code:   BaseSalaryCode,
+		Name:   "Base Salary",
+		Type:   model.TypeEarning,
+		Amount: calc.BaseSalary,
+	})
+	for _, component := range components {
+		calc.Items = append(calc.Items, &model.PayslipItem{
+			Code:   component.Code,
+			Name:   component.Name,
+			Type:   component.Type,
+			Amount: component.Amount(calc.BaseSalary),
+		})
+	}
+	for _, contributor := range s.contributors {
+		items, err := contributor.Contribute(calc)
+		if err != nil {
+			return nil, err
+		}
+		calc.Items = append(calc.Items, items...)
+	}
+
+	payslip := &model.Payslip{
+		UserID:      userID,
+		FullName:    calc.FullName,
+		Period:      period.Code,
+		PeriodStart: period.Start,
+		PeriodEnd:   period.End,
+		BaseSalary:  calc.BaseSalary,
+		CreatedBy:   by,
+		Items:       calc.Items,
+	}
+	for i, item := range payslip.Items {
+		item.Amount = item.Amount.Round(2)
+		item.Sequence = i + 1
+		switch item.Type {
+		case model.TypeEarning:
+			payslip.GrossEarnings = payslip.GrossEarnings.Add(item.Amount)
+		case model.TypeDeduction:
+			payslip.TotalDeductions = payslip.TotalDeductions.Add(item.Amount)
+		default:
+			return nil, ErrInvalidItem
+		}
+	}
+	payslip.NetPay = payslip.GrossEarnings.Sub(payslip.TotalDeductions)
+
+	err = s.repo.Create(payslip)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPayslipExists
+		}
+		return nil, err
+	}
+	return payslip, nil
+}
+
+// List implements the Service interface.
+func (s *service) List(request *dto.PayslipFilterRequest) (dto.PayslipListResponse, error) {
+	if request.Period != "" {
+		if _, err := model.ParsePeriod(request.Period); err != nil {
+			return dto.PayslipListResponse{}, err
+		}
+	}
+
+	payslips, err := s.repo.Fetch(&model.PayslipFilter{UserID: request.UserID, Period: request.Period})
+	if err != nil {
+		return dto.PayslipListResponse{}, err
+	}
+
+	data := make([]dto.PayslipData, 0, len(payslips))
+	for _, payslip := range payslips {
+		data = append(data, toData(payslip))
+	}
+	return dto.PayslipListResponse{Data: data}, nil
+}
+
+// Get implements the Service interface.
+func (s *service) Get(id int64) (dto.PayslipResponse, error) {
+	payslip, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dto.PayslipResponse{}, ErrPayslipNotFound
+		}
+		return dto.PayslipResponse{}, err
+	}
+	return dto.PayslipResponse{Data: toData(payslip)}, nil
+}
+
+// PDF implements the Service interface.
+func (s *service) PDF(id int64, viewerID int64, readAll bool, template string) ([]byte, string, error) {
+	payslip, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", ErrPayslipNotFound
+		}
+		return nil, "", err
+	}
+	if !readAll && payslip.UserID != viewerID {
+		return nil, "", ErrPayslipNotFound
+	}
+
+	var buf bytes.Buffer
+	err = s.documents.Render(&buf, payslip, template)
+	if err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), fmt.Sprintf("payslip-%s-%d.pdf", payslip.Period, payslip.ID), nil
+}
+
+// Components implements the Service interface.
+func (s *service) Components() (dto.ComponentListResponse, error) {
+	components, err := s.repo.FetchComponents(false)
+	if err != nil {
+		return dto.ComponentListResponse{}, err
+	}
+
+	data := make([]dto.ComponentData, 0, len(components))
+	for _, component := range components {
+		data = append(data, toComponentData(component))
+	}
+	return dto.ComponentListResponse{Data: data}, nil
+}
+
+// CreateComponent implements the Service interface.
+func (s *service) CreateComponent(request *dto.ComponentRequest) (dto.ComponentResponse, error) {
+	component, err := toComponent(request)
+	if err != nil {
+		return dto.ComponentResponse{}, err
+	}
+	component.CreatedBy = request.By
+
+	err = s.repo.CreateComponent(component)
+	if err != nil {
+		return dto.ComponentResponse{}, err
+	}
+	return dto.ComponentResponse{Data: toComponentData(component)}, nil
+}
+
+// UpdateComponent implements the Service interface.
+func (s *service) UpdateComponent(request *dto.ComponentRequest) (dto.ComponentResponse, error) {
+	component, err := toComponent(request)
+	if err != nil {
+		return dto.ComponentResponse{}, err
+	}
+	component.ID = request.ID
+	component.UpdatedBy = request.By
+
+	err = s.repo.UpdateComponent(component)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dto.ComponentResponse{}, ErrComponentNotFound
+		}
+		return dto.ComponentResponse{}, err
+	}
+	return dto.ComponentResponse{Data: toComponentData(component)}, nil
+}
+
+func toComponent(request *dto.ComponentRequest) (*model.Component, error) {
+	if request.Value.IsNegative() ||
+		(request.Method == model.MethodPercentage && request.Value.GreaterThan(decimal.NewFromInt(100))) {
+		return nil, ErrInvalidComponent
+	}
+
+	active := true
+	if request.IsActive != nil {
+		active = *request.IsActive
+	}
+	return &model.Component{
+		Code:     request.Code,
+		Name:     request.Name,
+		Type:     request.Type,
+		Method:   request.Method,
+		Value:    request.Value,
+		Sequence: request.Sequence,
+		IsActive: active,
+	}, nil
+}
+
+func toComponentData(component *model.Component) dto.ComponentData {
+	return dto.ComponentData{
+		ID:       component.ID,
+		Code:     component.Code,
+		Name:     component.Name,
+		Type:     component.Type,
+		Method:   component.Method,
+		Value:    component.Value,
+		Sequence: component.Sequence,
+		IsActive: component.IsActive,
+	}
+}
+
+func toData(payslip *model.Payslip) dto.PayslipData {
+	data := dto.PayslipData{
+		ID:              payslip.ID,
+		UserID:          payslip.UserID,
+		FullName:        payslip.FullName,
+		Period:          payslip.Period,
+		PeriodStart:     payslip.PeriodStart.Format(salaryservice.DateLayout),
+		PeriodEnd:       payslip.PeriodEnd.Format(salaryservice.DateLayout),
+		BaseSalary:      payslip.BaseSalary,
+		GrossEarnings:   payslip.GrossEarnings,
+		TotalDeductions: payslip.TotalDeductions,
+		NetPay:          payslip.NetPay,
+		CreatedAt:       payslip.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for _, item := range payslip.Items {
+		data.Items = append(data.Items, dto.PayslipItemData{
+			Code:   item.Code,
+			Name:   item.Name,
+			Type:   item.Type,
+			Amount: item.Amount,
+		})
+	}
+	return data
+}
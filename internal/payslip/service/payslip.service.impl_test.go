@@ -0,0 +1,175 @@
+package service
+
+import (
+	"database/sql"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	mockrepo "github.com/dwilanang/psp/internal/payslip/repository/mocks"
+
+	"github.com/dwilanang/psp/internal/payslip/dto"
+	"github.com/dwilanang/psp/internal/payslip/model"
+	salarymodel "github.com/dwilanang/psp/internal/salary/model"
+	salaryservice "github.com/dwilanang/psp/internal/salary/service"
+)
+
+type fakeSalaryResolver struct {
+	salary *salarymodel.Salary
+	date   time.Time
+}
+
+func (f *fakeSalaryResolver) Resolve(userID int64, date time.Time) (*salarymodel.Salary, error) {
+	f.date = date
+	if f.salary == nil {
+		return nil, salaryservice.ErrSalaryNotFound
+	}
+	return f.salary, nil
+}
+
+type fakeContributor struct {
+	items []*model.PayslipItem
+	seen  int
+}
+
+func (f *fakeContributor) Contribute(calc *model.Calculation) ([]*model.PayslipItem, error) {
+	f.seen = len(calc.Items)
+	return f.items, nil
+}
+
+type fakePeriodLocker struct {
+	locked bool
+}
+
+func (f *fakePeriodLocker) IsLocked(from time.Time, to *time.Time) (bool, error) {
+	return f.locked, nil
+}
+
+func TestService_Generate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	salaries := &fakeSalaryResolver{salary: &salarymodel.Salary{UserID: 7, FullName: "John Doe", Amount: decimal.RequireFromString("5000000.00")}}
+	overtime := &fakeContributor{items: []*model.PayslipItem{
+		{Code: "OVERTIME", Name: "Overtime", Type: model.TypeEarning, Amount: decimal.RequireFromString("125000.333")},
+	}}
+	svc := NewService(mockRepo, salaries, nil, &fakePeriodLocker{}, overtime)
+
+	mockRepo.EXPECT().FetchComponents(true).Return([]*model.Component{
+		{Code: "ALLOWANCE", Name: "Transport Allowance", Type: model.TypeEarning, Method: model.MethodFixed, Value: decimal.NewFromInt(300000)},
+		{Code: "BPJS", Name: "BPJS Health", Type: model.TypeDeduction, Method: model.MethodPercentage, Value: decimal.RequireFromString("1.5")},
+	}, nil)
+	mockRepo.
+		EXPECT().
+		Create(gomock.AssignableToTypeOf(&model.Payslip{})).
+		DoAndReturn(func(payslip *model.Payslip) error {
+			assert.Len(t, payslip.Items, 4)
+			assert.Equal(t, 4, payslip.Items[3].Sequence)
+			payslip.ID = 10
+			return nil
+		})
+
+	resp, err := svc.Generate(&dto.PayslipRequest{UserID: 7, Period: "2025-02", By: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), salaries.date)
+	assert.Equal(t, 3, overtime.seen)
+	assert.Equal(t, "2025-02-01", resp.Data.PeriodStart)
+	assert.Equal(t, "2025-02-28", resp.Data.PeriodEnd)
+	assert.Equal(t, "5425000.33", resp.Data.GrossEarnings.StringFixed(2))
+	assert.Equal(t, "75000.00", resp.Data.TotalDeductions.StringFixed(2))
+	assert.Equal(t, "5350000.33", resp.Data.NetPay.StringFixed(2))
+	assert.Equal(t, "John Doe", resp.Data.FullName)
+}
+
+func TestService_Generate_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+
+	_, err := NewService(mockRepo, &fakeSalaryResolver{}, nil, &fakePeriodLocker{}).Generate(&dto.PayslipRequest{UserID: 7, Period: "2025-13"})
+	assert.ErrorIs(t, err, model.ErrInvalidPeriod)
+
+	_, err = NewService(mockRepo, &fakeSalaryResolver{}, nil, &fakePeriodLocker{}).Generate(&dto.PayslipRequest{UserID: 7, Period: "2025-01"})
+	assert.ErrorIs(t, err, ErrNoSalary)
+
+	salaries := &fakeSalaryResolver{salary: &salarymodel.Salary{UserID: 7, Amount: decimal.NewFromInt(100)}}
+	mockRepo.EXPECT().FetchComponents(true).Return(nil, nil)
+	mockRepo.EXPECT().Create(gomock.Any()).Return(sql.ErrNoRows)
+
+	_, err = NewService(mockRepo, salaries, nil, &fakePeriodLocker{}).Generate(&dto.PayslipRequest{UserID: 7, Period: "2025-01"})
+	assert.ErrorIs(t, err, ErrPayslipExists)
+}
+
+func TestService_Generate_PeriodLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Neither the salary nor the repository is touched for a closed period.
+	svc := NewService(mockrepo.NewMockRepository(ctrl), nil, nil, &fakePeriodLocker{locked: true})
+
+	_, err := svc.Generate(&dto.PayslipRequest{UserID: 7, Period: "2025-01"})
+	assert.ErrorIs(t, err, ErrPeriodLocked)
+}
+
+func TestService_Get_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, nil, nil, nil)
+
+	mockRepo.EXPECT().FindByID(int64(10)).Return(nil, sql.ErrNoRows)
+
+	_, err := svc.Get(10)
+	assert.ErrorIs(t, err, ErrPayslipNotFound)
+}
+
+func TestService_CreateComponent_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := NewService(mockrepo.NewMockRepository(ctrl), nil, nil, nil)
+
+	_, err := svc.CreateComponent(&dto.ComponentRequest{Code: "TAX", Name: "Tax", Type: model.TypeDeduction, Method: model.MethodPercentage, Value: decimal.NewFromInt(120)})
+	assert.ErrorIs(t, err, ErrInvalidComponent)
+}
+
+type fakeRenderer struct {
+	template string
+}
+
+func (f *fakeRenderer) Render(w io.Writer, payslip *model.Payslip, template string) error {
+	f.template = template
+	_, err := w.Write([]byte("%PDF"))
+	return err
+}
+
+func TestService_PDF_Ownership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	documents := &fakeRenderer{}
+	svc := NewService(mockRepo, nil, documents, nil)
+
+	mockRepo.EXPECT().FindByID(int64(10)).Return(&model.Payslip{ID: 10, UserID: 7, Period: "2025-01"}, nil).Times(3)
+
+	doc, name, err := svc.PDF(10, 7, false, "compact")
+	assert.NoError(t, err)
+	assert.Equal(t, "%PDF", string(doc))
+	assert.Equal(t, "payslip-2025-01-10.pdf", name)
+	assert.Equal(t, "compact", documents.template)
+
+	_, _, err = svc.PDF(10, 8, false, "")
+	assert.ErrorIs(t, err, ErrPayslipNotFound)
+
+	_, _, err = svc.PDF(10, 8, true, "")
+	assert.NoError(t, err)
+}
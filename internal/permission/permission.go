@@ -0,0 +1,107 @@
+package permission
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Wildcard grants every permission when used on its own, or every action of a resource as "<resource>:*".
+const Wildcard = "*"
+
+// Permissions checked by the routes. A role's privilege lists the ones it grants, e.g. "users:create, roles:*".
+const (
+	RolesRead             = "roles:read"
+	RolesCreate           = "roles:create"
+	RolesUpdate           = "roles:update"
+	RolesDelete           = "roles:delete"
+	UsersCreate           = "users:create"
+	UsersRead             = "users:read"
+	UsersUpdate           = "users:update"
+	UsersChangeRole       = "users:change_role"
+	UsersDeactivate       = "users:deactivate"
+	UsersDelete           = "users:delete"
+	SessionsRevoke        = "sessions:revoke"
+	SalariesCreate        = "salaries:create"
+	SalariesRead          = "salaries:read"
+	SalariesUpdate        = "salaries:update"
+	PayslipsGenerate      = "payslips:generate"
+	PayslipsRead          = "payslips:read"
+	PayslipsConfigure     = "payslips:configure"
+	PayrollRead           = "payroll:read"
+	PayrollRun            = "payroll:run"
+	PayrollClose          = "payroll:close"
+	AttendanceRead        = "attendance:read"
+	AttendanceCorrect     = "attendance:correct"
+	OvertimeRead          = "overtime:read"
+	OvertimeApprove       = "overtime:approve"
+	ReimbursementsRead    = "reimbursements:read"
+	ReimbursementsApprove = "reimbursements:approve"
+	LeaveRead             = "leave:read"
+	LeaveApprove          = "leave:approve"
+	LeaveConfigure        = "leave:configure"
+	AuditRead             = "audit:read"
+)
+
+var pattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*:([a-z][a-z0-9_-]*|\*)$`)
+
+// Set is the parsed set of permissions granted to a role.
+type Set map[string]struct{}
+
+// Parse parses a role privilege into a Set.
+// Permissions are "<resource>:<action>" strings separated by commas or whitespace, and are case-insensitive.
+// Returns an error naming the first entry that is not a valid permission.
+func Parse(privilege string) (Set, error) {
+	set := Set{}
+
+	fields := strings.FieldsFunc(privilege, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+	for _, field := range fields {
+		p := strings.ToLower(field)
+		if p != Wildcard && !pattern.MatchString(p) {
+			return nil, fmt.Errorf("invalid permission %q", field)
+		}
+		set[p] = struct{}{}
+	}
+
+	return set, nil
+}
+
+// Allows reports whether the set grants the required permission, directly or through a wildcard.
+func (s Set) Allows(required string) bool {
+	if _, ok := s[Wildcard]; ok {
+		return true
+	}
+	if _, ok := s[required]; ok {
+		return true
+	}
+
+	resource, _, found := strings.Cut(required, ":")
+	if !found {
+		return false
+	}
+	_, ok := s[resource+":"+Wildcard]
+	return ok
+}
+
+// AllowsAll reports whether the set grants every one of the required permissions.
+func (s Set) AllowsAll(required ...string) bool {
+	for _, r := range required {
+		if !s.Allows(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// List returns the permissions in the set in a stable order.
+func (s Set) List() []string {
+	list := make([]string, 0, len(s))
+	for p := range s {
+		list = append(list, p)
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,35 @@
+package permission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	set, err := Parse("users:create, Roles:*\nleave:approve")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"leave:approve", "roles:*", "users:create"}, set.List())
+
+	set, err = Parse("")
+	assert.NoError(t, err)
+	assert.Empty(t, set)
+
+	_, err = Parse("users:create, everything")
+	assert.Error(t, err)
+}
+
+func TestSet_Allows(t *testing.T) {
+	set, err := Parse("users:create, roles:*")
+	assert.NoError(t, err)
+
+	assert.True(t, set.Allows(UsersCreate))
+	assert.True(t, set.Allows(RolesDelete))
+	assert.False(t, set.Allows("users:delete"))
+	assert.True(t, set.AllowsAll(UsersCreate, RolesRead))
+	assert.False(t, set.AllowsAll(UsersCreate, SessionsRevoke))
+
+	all, err := Parse("*")
+	assert.NoError(t, err)
+	assert.True(t, all.Allows(SessionsRevoke))
+}
@@ -0,0 +1,82 @@
+package permission
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dwilanang/psp/internal/role/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// Resolver resolves the permissions granted to a role name.
+type Resolver interface {
+	// Permissions returns the permissions of the role. Unknown roles resolve to an empty Set.
+	Permissions(role string) (Set, error)
+
+	// Invalidate drops every cached role so the next lookup reads the current privileges.
+	Invalidate()
+}
+
+type cached struct {
+	set   Set
+	until time.Time
+}
+
+type resolver struct {
+	repo repository.Repository
+	ttl  time.Duration
+
+	mu    sync.RWMutex
+	roles map[string]cached
+}
+
+// NewResolver creates a Resolver that reads role privileges through the role repository and caches them.
+// Role updates on this instance invalidate the cache immediately; ttl bounds how long
+// a change made by another replica can go unnoticed.
+func NewResolver(repo repository.Repository, ttl time.Duration) *resolver {
+	return &resolver{
+		repo:  repo,
+		ttl:   ttl,
+		roles: map[string]cached{},
+	}
+}
+
+// Permissions implements the Resolver interface.
+func (r *resolver) Permissions(role string) (Set, error) {
+	now := time.Now()
+
+	r.mu.RLock()
+	c, ok := r.roles[role]
+	r.mu.RUnlock()
+	if ok && now.Before(c.until) {
+		return c.set, nil
+	}
+
+	set := Set{}
+	found, err := r.repo.FindByName(role)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if found != nil {
+		set, err = Parse(found.Privilege)
+		if err != nil {
+			// A malformed privilege must never widen access, so the role gets nothing until it is fixed.
+			logrus.WithError(err).WithField("role", role).Error("malformed role privilege")
+			set = Set{}
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.roles[role] = cached{set: set, until: now.Add(r.ttl)}
+	return set, nil
+}
+
+// Invalidate implements the Resolver interface.
+func (r *resolver) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.roles = map[string]cached{}
+}
@@ -0,0 +1,55 @@
+package permission
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dwilanang/psp/internal/role/model"
+	mockrepo "github.com/dwilanang/psp/internal/role/repository/mocks"
+)
+
+func TestResolver_CachesUntilInvalidated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	resolver := NewResolver(mockRepo, time.Minute)
+
+	mockRepo.EXPECT().FindByName("ADMIN").Return(&model.Role{Name: "ADMIN", Privilege: "users:create"}, nil).Times(1)
+
+	for i := 0; i < 2; i++ {
+		set, err := resolver.Permissions("ADMIN")
+		assert.NoError(t, err)
+		assert.True(t, set.Allows(UsersCreate))
+		assert.False(t, set.Allows(RolesUpdate))
+	}
+
+	resolver.Invalidate()
+	mockRepo.EXPECT().FindByName("ADMIN").Return(&model.Role{Name: "ADMIN", Privilege: "users:create, roles:update"}, nil)
+
+	set, err := resolver.Permissions("ADMIN")
+	assert.NoError(t, err)
+	assert.True(t, set.Allows(RolesUpdate))
+}
+
+func TestResolver_UnknownOrMalformedRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	resolver := NewResolver(mockRepo, time.Minute)
+
+	mockRepo.EXPECT().FindByName("GHOST").Return(nil, sql.ErrNoRows)
+	set, err := resolver.Permissions("GHOST")
+	assert.NoError(t, err)
+	assert.Empty(t, set)
+
+	mockRepo.EXPECT().FindByName("LEGACY").Return(&model.Role{Name: "LEGACY", Privilege: "all"}, nil)
+	set, err = resolver.Permissions("LEGACY")
+	assert.NoError(t, err)
+	assert.Empty(t, set)
+}
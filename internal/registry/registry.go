@@ -1,63 +1,359 @@
 package registry
 
 import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/dwilanang/psp/config"
+	"github.com/dwilanang/psp/db/migrations"
+	"github.com/dwilanang/psp/infrastructure/db/migrate"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/attendance"
+	attendancehandler "github.com/dwilanang/psp/internal/attendance/handler"
+	attendancerepository "github.com/dwilanang/psp/internal/attendance/repository"
+	attendanceservice "github.com/dwilanang/psp/internal/attendance/service"
+	"github.com/dwilanang/psp/internal/audit"
+	audithandler "github.com/dwilanang/psp/internal/audit/handler"
+	auditrepository "github.com/dwilanang/psp/internal/audit/repository"
+	auditservice "github.com/dwilanang/psp/internal/audit/service"
 	authhandler "github.com/dwilanang/psp/internal/auth/handler"
+	"github.com/dwilanang/psp/internal/auth/keys"
+	authrepository "github.com/dwilanang/psp/internal/auth/repository"
+	"github.com/dwilanang/psp/internal/auth/revocation"
 	authservice "github.com/dwilanang/psp/internal/auth/service"
+	"github.com/dwilanang/psp/internal/health"
+	healthhandler "github.com/dwilanang/psp/internal/health/handler"
+	"github.com/dwilanang/psp/internal/leave"
+	leavehandler "github.com/dwilanang/psp/internal/leave/handler"
+	leaverepository "github.com/dwilanang/psp/internal/leave/repository"
+	leaveservice "github.com/dwilanang/psp/internal/leave/service"
+	"github.com/dwilanang/psp/internal/middleware"
+	"github.com/dwilanang/psp/internal/overtime"
+	overtimehandler "github.com/dwilanang/psp/internal/overtime/handler"
+	overtimerepository "github.com/dwilanang/psp/internal/overtime/repository"
+	overtimeservice "github.com/dwilanang/psp/internal/overtime/service"
+	"github.com/dwilanang/psp/internal/payroll"
+	payrollhandler "github.com/dwilanang/psp/internal/payroll/handler"
+	payrolllock "github.com/dwilanang/psp/internal/payroll/lock"
+	payrollrepository "github.com/dwilanang/psp/internal/payroll/repository"
+	payrollservice "github.com/dwilanang/psp/internal/payroll/service"
+	"github.com/dwilanang/psp/internal/payslip"
+	paysliphandler "github.com/dwilanang/psp/internal/payslip/handler"
+	"github.com/dwilanang/psp/internal/payslip/pdf"
+	paysliprepository "github.com/dwilanang/psp/internal/payslip/repository"
+	payslipservice "github.com/dwilanang/psp/internal/payslip/service"
+	"github.com/dwilanang/psp/internal/permission"
+	"github.com/dwilanang/psp/internal/reimbursement"
+	reimbursementhandler "github.com/dwilanang/psp/internal/reimbursement/handler"
+	reimbursementrepository "github.com/dwilanang/psp/internal/reimbursement/repository"
+	reimbursementservice "github.com/dwilanang/psp/internal/reimbursement/service"
+	"github.com/dwilanang/psp/internal/reimbursement/storage"
 	"github.com/dwilanang/psp/internal/role"
 	rolehandler "github.com/dwilanang/psp/internal/role/handler"
 	rolerepository "github.com/dwilanang/psp/internal/role/repository"
 	roleservice "github.com/dwilanang/psp/internal/role/service"
+	"github.com/dwilanang/psp/internal/salary"
+	salaryhandler "github.com/dwilanang/psp/internal/salary/handler"
+	salaryrepository "github.com/dwilanang/psp/internal/salary/repository"
+	salaryservice "github.com/dwilanang/psp/internal/salary/service"
 	"github.com/dwilanang/psp/internal/user"
 	userhandler "github.com/dwilanang/psp/internal/user/handler"
 	userrepository "github.com/dwilanang/psp/internal/user/repository"
 	userservice "github.com/dwilanang/psp/internal/user/service"
+	"github.com/dwilanang/psp/utils"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 )
 
 // Registry acts as a centralized dependency container.
 // It provides factory methods to construct application components (handlers/services/repos)
 // with their required dependencies injected, such as database connections and configuration settings.
 type Registry struct {
-	db  *sqlx.DB       // db represents a PostgreSQL database connection managed via sqlx.
+	db  *postgres.DB   // db writes to the PostgreSQL master and lets repositories read from its replicas.
 	cfg *config.Config // cfg holds global configuration values used across services (e.g., JWT secret, environment settings).
+
+	keys        *keys.KeySet        // keys signs issued tokens and verifies incoming ones.
+	revocations revocation.Store    // revocations is shared by every consumer so its in-memory cache stays consistent.
+	permissions permission.Resolver // permissions caches role privileges and is invalidated by the role service.
+	health      *health.Checker     // health backs the readiness probe and is told when the server shuts down.
 }
 
 // NewRegistry creates a new instance of the Registry.
 // This function should be called once during application startup, providing it with
-// the configuration and database connection to be used throughout the application.
-func NewRegistry(cfg *config.Config, db *sqlx.DB) *Registry {
-	return &Registry{db: db, cfg: cfg}
+// the configuration, database connection and JWT key set to be used throughout the application.
+func NewRegistry(cfg *config.Config, db *postgres.DB, keys *keys.KeySet) *Registry {
+	revocationTTL := time.Duration(utils.ConvertStringToInt(cfg.JWTRevocationCacheTTL)) * time.Second
+	permissionTTL := time.Duration(utils.ConvertStringToInt(cfg.PermissionCacheTTL)) * time.Second
+	healthTimeout := time.Duration(utils.ConvertStringToInt(cfg.HealthCheckTimeout)) * time.Second
+	migrator, err := migrate.New(db.DB, migrations.FS)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	return &Registry{
+		db:          db,
+		cfg:         cfg,
+		keys:        keys,
+		revocations: revocation.NewStore(authrepository.NewRepository(db), revocationTTL),
+		permissions: permission.NewResolver(rolerepository.NewRepository(db), permissionTTL),
+		health:      health.NewChecker(postgres.Ping, migrator, healthTimeout),
+	}
+}
+
+// NewHealthHandler returns the handler of the liveness and readiness probes.
+func (r *Registry) NewHealthHandler() *healthhandler.Handler {
+	return healthhandler.NewHandler(r.health)
+}
+
+// SetShuttingDown makes the readiness probe fail from now on, so no new traffic is routed to this instance.
+func (r *Registry) SetShuttingDown() {
+	r.health.SetShuttingDown()
+}
+
+// NewAuthMiddleware returns the JWT authentication middleware.
+// It validates the bearer token with the verification key named by its kid and rejects tokens found in the revocation list.
+func (r *Registry) NewAuthMiddleware() gin.HandlerFunc {
+	return middleware.JWTAuthMiddleware(r.keys.Keyfunc, r.revocations)
 }
 
 // NewAuthHandler returns a fully-initialized AuthHandler.
-// It sets up the user repository and authentication service (which may handle login, JWT generation, etc.),
-// and injects them into the auth handler.
+// It sets up the authentication service (which may handle login, JWT generation, refresh token rotation, etc.),
+// and injects it into the auth handler.
 func (r *Registry) NewAuthHandler() *authhandler.Handler {
-	repo := userrepository.NewRepository(r.db)     // Repository to interact with user-related DB operations
-	authSvc := authservice.NewService(r.cfg, repo) // Service encapsulating authentication logic
-	return authhandler.NewHandler(authSvc)         // HTTP handler for auth-related routes
+	return authhandler.NewHandler(r.newAuthService()) // HTTP handler for auth-related routes
+}
+
+// newAuthService builds the authentication service from the auth and user repositories.
+// It is shared by the auth handler and by services that need to revoke sessions.
+func (r *Registry) newAuthService() authservice.Service {
+	repo := authrepository.NewRepository(r.db)                                  // Repository to persist refresh tokens
+	userRepo := userrepository.NewRepository(r.db)                              // Repository to interact with user-related DB operations
+	return authservice.NewService(r.cfg, r.keys, repo, userRepo, r.revocations) // Service encapsulating authentication logic
+}
+
+// RequirePermission returns a middleware that only lets through callers whose role grants every required permission.
+// It must run after the middleware returned by NewAuthMiddleware.
+func (r *Registry) RequirePermission(required ...string) gin.HandlerFunc {
+	return middleware.RequirePermission(r.permissions, required...)
 }
 
 // NewRoleHandler returns a fully-initialized RoleHandler.
 // It builds the role repository and service, and constructs the handler to manage role-related routes.
 func (r *Registry) NewRoleHandler() *rolehandler.Handler {
 	repo := rolerepository.NewRepository(r.db) // Repository to manage roles in the database
-	svc := roleservice.NewService(repo)        // Business logic for managing roles
+	// Business logic for managing roles, auditing every change and invalidating cached permissions on change
+	svc := roleservice.NewService(r.db, repo, auditrepository.NewRepository(r.db), r.permissions)
 	return rolehandler.NewHandler(role.Dependencies{
 		DBPostgres: r.db,
 		Service:    svc,
 	})
 }
 
+// NewAuditHandler returns a fully-initialized AuditHandler.
+// This handler lists the audit log of changes made to roles, users and salaries.
+func (r *Registry) NewAuditHandler() *audithandler.Handler {
+	repo := auditrepository.NewRepository(r.db) // Append-only audit log, written by the services whose changes it records
+	return audithandler.NewHandler(audit.Dependencies{
+		DBPostgres: r.db,
+		Service:    auditservice.NewService(repo),
+	})
+}
+
 // NewUserHandler returns a fully-initialized UserHandler.
 // This handler manages routes related to user management such as listing, creating, or updating users.
 func (r *Registry) NewUserHandler() *userhandler.Handler {
-	repo := userrepository.NewRepository(r.db) // Reusable user repository
-	svc := userservice.NewService(repo)        // Service to handle user-related business logic
+	repo := userrepository.NewRepository(r.db)     // Reusable user repository
+	roleRepo := rolerepository.NewRepository(r.db) // Roles are checked in the same transaction as the user writes
+	// Service to handle user-related business logic, auditing every change, revoking sessions on status changes
+	// and rejecting initial salaries in closed payroll periods
+	svc := userservice.NewService(r.db, repo, roleRepo, auditrepository.NewRepository(r.db), r.newAuthService(), r.newPeriodLock())
 	return userhandler.NewHandler(user.Dependencies{
 		DBPostgres: r.db,
 		Service:    svc,
 	})
 }
+
+// NewSalaryHandler returns a fully-initialized SalaryHandler.
+// This handler manages routes related to a user's salary history such as setting, listing and correcting salaries.
+func (r *Registry) NewSalaryHandler() *salaryhandler.Handler {
+	return salaryhandler.NewHandler(salary.Dependencies{
+		DBPostgres: r.db,
+		Service:    r.newSalaryService(),
+	})
+}
+
+// newSalaryService builds the salary service, which enforces non-overlapping effective periods,
+// rejects changes to closed payroll periods and audits every change.
+// It is shared by the salary handler and by the payslip service resolving effective salaries.
+func (r *Registry) newSalaryService() salaryservice.Service {
+	return salaryservice.NewService(r.db, salaryrepository.NewRepository(r.db), auditrepository.NewRepository(r.db), r.newPeriodLock())
+}
+
+// newPeriodLock returns the check used by modules whose changes must not touch closed payroll periods.
+func (r *Registry) newPeriodLock() *payrolllock.PeriodLock {
+	return payrolllock.NewPeriodLock(payrollrepository.NewRepository(r.db))
+}
+
+// NewPayslipHandler returns a fully-initialized PayslipHandler.
+// This handler manages routes related to payslips such as generating and fetching them, and their components.
+func (r *Registry) NewPayslipHandler() *paysliphandler.Handler {
+	return paysliphandler.NewHandler(payslip.Dependencies{
+		DBPostgres: r.db,
+		Service:    r.newPayslipService(),
+	})
+}
+
+// newPayslipService builds the payslip service from the payslip repository, the salary service
+// and the PDF renderer configured by PAYSLIP_TEMPLATE_DIR and PAYSLIP_TEMPLATE.
+// Modules that add line items to payslips register their contributors here.
+func (r *Registry) newPayslipService() payslipservice.Service {
+	repo := paysliprepository.NewRepository(r.db)                                                // Repository to store payslips and their components
+	documents := pdf.NewRenderer(r.cfg.PayslipTemplateDir, r.cfg.PayslipTemplate, r.cfg.AppName) // Renders payslips with configurable templates
+	return payslipservice.NewService(repo, r.newSalaryService(), documents, r.newPeriodLock(),
+		r.newAttendanceContributor(),
+		r.newOvertimeContributor(),
+		reimbursementservice.NewPayslipContributor(reimbursementrepository.NewRepository(r.db)),
+		leaveservice.NewPayslipContributor(leaverepository.NewRepository(r.db)),
+	)
+}
+
+// NewPayrollHandler returns a fully-initialized PayrollHandler.
+// This handler manages routes related to payroll periods such as running payroll and closing a period.
+func (r *Registry) NewPayrollHandler() *payrollhandler.Handler {
+	repo := payrollrepository.NewRepository(r.db)                 // Repository to manage payroll periods and their locks
+	svc := payrollservice.NewService(repo, r.newPayslipService()) // Business logic issuing payslips for a whole period
+	return payrollhandler.NewHandler(payroll.Dependencies{
+		DBPostgres: r.db,
+		Service:    svc,
+	})
+}
+
+// NewAttendanceHandler returns a fully-initialized AttendanceHandler.
+// This handler manages routes related to daily attendance such as check-in, check-out and admin corrections.
+func (r *Registry) NewAttendanceHandler() *attendancehandler.Handler {
+	return attendancehandler.NewHandler(attendance.Dependencies{
+		DBPostgres: r.db,
+		Service:    r.newAttendanceService(),
+	})
+}
+
+// newAttendanceService builds the attendance service on the schedule configured by the ATTENDANCE_* settings.
+// An invalid schedule is a configuration error and stops the application at startup.
+func (r *Registry) newAttendanceService() attendanceservice.Service {
+	grace := utils.ConvertStringToInt(r.cfg.AttendanceLateGrace)
+	schedule, err := attendanceservice.NewSchedule(r.cfg.AttendanceTimezone, r.cfg.AttendanceWorkStart, grace)
+	if err != nil {
+		log.Fatalf("invalid attendance schedule: %v", err)
+	}
+	repo := attendancerepository.NewRepository(r.db) // Repository to store daily attendance records
+	return attendanceservice.NewService(repo, schedule, r.newPeriodLock(), r.newLeaveService())
+}
+
+// newAttendanceContributor returns the payslip contributor deducting late minutes and absences.
+func (r *Registry) newAttendanceContributor() payslipservice.Contributor {
+	penalty, err := decimal.NewFromString(r.cfg.AttendanceLatePenalty)
+	if err != nil {
+		log.Fatalf("invalid ATTENDANCE_LATE_PENALTY: %v", err)
+	}
+	deductAbsent, _ := strconv.ParseBool(r.cfg.DeductAbsentDays)
+	return attendanceservice.NewPayslipContributor(r.newAttendanceService(), penalty, deductAbsent)
+}
+
+// NewOvertimeHandler returns a fully-initialized OvertimeHandler.
+// This handler manages routes related to overtime requests such as submitting, approving and rejecting them.
+func (r *Registry) NewOvertimeHandler() *overtimehandler.Handler {
+	repo := overtimerepository.NewRepository(r.db)                       // Repository to store overtime requests and their transitions
+	payslips := paysliprepository.NewRepository(r.db)                    // Issued payslips, to pay overtime approved late on a later one
+	svc := overtimeservice.NewService(repo, payslips, r.newPeriodLock()) // Business logic for the approval workflow, rejecting changes to closed payroll periods
+	return overtimehandler.NewHandler(overtime.Dependencies{
+		DBPostgres: r.db,
+		Service:    svc,
+	})
+}
+
+// newOvertimeContributor returns the payslip contributor paying approved overtime at the OVERTIME_* rates.
+// Invalid rates are a configuration error and stop the application at startup.
+func (r *Registry) newOvertimeContributor() payslipservice.Contributor {
+	rates := overtimeservice.Rates{}
+	for _, setting := range []struct {
+		name  string
+		value string
+		rate  *decimal.Decimal
+	}{
+		{"OVERTIME_WEEKDAY_RATE", r.cfg.OvertimeWeekdayRate, &rates.Weekday},
+		{"OVERTIME_WEEKEND_RATE", r.cfg.OvertimeWeekendRate, &rates.Weekend},
+		{"OVERTIME_HOURLY_DIVISOR", r.cfg.OvertimeHourlyDivisor, &rates.HourlyDivisor},
+	} {
+		rate, err := decimal.NewFromString(setting.value)
+		if err != nil {
+			log.Fatalf("invalid %s: %v", setting.name, err)
+		}
+		*setting.rate = rate
+	}
+	return overtimeservice.NewPayslipContributor(overtimerepository.NewRepository(r.db), rates)
+}
+
+// NewReimbursementHandler returns a fully-initialized ReimbursementHandler.
+// This handler manages routes related to reimbursement claims such as submitting them with a receipt and reviewing them.
+func (r *Registry) NewReimbursementHandler() *reimbursementhandler.Handler {
+	policy := reimbursementservice.Policy{MaxReceiptSize: utils.ConvertStringToInt(r.cfg.ReimbursementMaxReceiptSize) << 20}
+	for _, category := range strings.Split(r.cfg.ReimbursementCategories, ",") {
+		if category = strings.ToLower(strings.TrimSpace(category)); category != "" {
+			policy.Categories = append(policy.Categories, category)
+		}
+	}
+
+	repo := reimbursementrepository.NewRepository(r.db) // Repository to store claims and their transitions
+	payslips := paysliprepository.NewRepository(r.db)   // Issued payslips, so approved claims are scheduled on a payslip not issued yet
+	svc := reimbursementservice.NewService(repo, r.newReceiptStorage(), payslips, r.newPeriodLock(), policy)
+	return reimbursementhandler.NewHandler(reimbursement.Dependencies{
+		DBPostgres: r.db,
+		Service:    svc,
+	})
+}
+
+// newReceiptStorage returns the receipt storage backend selected by REIMBURSEMENT_STORAGE.
+// An unknown backend or an invalid S3 configuration stops the application at startup.
+func (r *Registry) newReceiptStorage() storage.Storage {
+	switch r.cfg.ReimbursementStorage {
+	case storage.BackendLocal:
+		return storage.NewLocal(r.cfg.ReimbursementStorageDir)
+	case storage.BackendS3:
+		pathStyle, _ := strconv.ParseBool(r.cfg.ReimbursementS3PathStyle)
+		s3, err := storage.NewS3(storage.S3Config{
+			Endpoint:  r.cfg.ReimbursementS3Endpoint,
+			Region:    r.cfg.ReimbursementS3Region,
+			Bucket:    r.cfg.ReimbursementS3Bucket,
+			AccessKey: r.cfg.ReimbursementS3AccessKey,
+			SecretKey: r.cfg.ReimbursementS3SecretKey,
+			PathStyle: pathStyle,
+		})
+		if err != nil {
+			log.Fatalf("invalid reimbursement S3 storage: %v", err)
+		}
+		return s3
+	default:
+		log.Fatalf("invalid REIMBURSEMENT_STORAGE %q: must be %q or %q", r.cfg.ReimbursementStorage, storage.BackendLocal, storage.BackendS3)
+		return nil
+	}
+}
+
+// NewLeaveHandler returns a fully-initialized LeaveHandler.
+// This handler manages routes related to leave such as leave types, balances and the request workflow.
+func (r *Registry) NewLeaveHandler() *leavehandler.Handler {
+	return leavehandler.NewHandler(leave.Dependencies{
+		DBPostgres: r.db,
+		Service:    r.newLeaveService(),
+	})
+}
+
+// newLeaveService builds the leave service, rejecting leave changes in closed payroll periods
+// and approvals of leave in months whose payslips were already issued.
+// Attendance uses it to excuse approved leave from absences.
+func (r *Registry) newLeaveService() leaveservice.Service {
+	repo := leaverepository.NewRepository(r.db)       // Repository to store leave types, balances and requests
+	payslips := paysliprepository.NewRepository(r.db) // Issued payslips, which leave approved late would not reach
+	return leaveservice.NewService(repo, payslips, r.newPeriodLock())
+}
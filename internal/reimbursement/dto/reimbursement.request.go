@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"io"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReimbursementRequest is bound from a multipart form; the receipt file is read by the handler.
+type ReimbursementRequest struct {
+	Category    string          `form:"category" binding:"required"`
+	Amount      decimal.Decimal `form:"amount" binding:"required"`
+	ExpenseDate string          `form:"expense_date" binding:"required"`
+	Description string          `form:"description"`
+	ReceiptName string          `form:"-"`
+	Receipt     io.Reader       `form:"-"`
+	By          int64           `form:"-"`
+}
+
+type ReimbursementFilterRequest struct {
+	UserID   int64  `form:"user_id"`
+	Status   string `form:"status" binding:"omitempty,oneof=pending approved rejected cancelled"`
+	Category string `form:"category"`
+	Period   string `form:"period" example:"2025-01"`
+}
+
+type ReimbursementReviewRequest struct {
+	ID   int64  `json:"id" swaggerignore:"true"`
+	Note string `json:"note"`
+	By   int64  `json:"by" swaggerignore:"true"`
+}
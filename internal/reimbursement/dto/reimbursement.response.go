@@ -0,0 +1,37 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+type ReimbursementResponse struct {
+	Data ReimbursementData `json:"data"`
+}
+
+type ReimbursementListResponse struct {
+	Data []ReimbursementData `json:"data"`
+}
+
+type ReimbursementData struct {
+	ID          int64            `json:"id"`
+	UserID      int64            `json:"user_id"`
+	FullName    string           `json:"full_name"`
+	Category    string           `json:"category" example:"transport"`
+	Amount      decimal.Decimal  `json:"amount" swaggertype:"string" example:"150000.00"`
+	ExpenseDate string           `json:"expense_date" example:"2025-01-15"`
+	Description string           `json:"description"`
+	ReceiptName string           `json:"receipt_name" example:"taxi.pdf"`
+	ReceiptType string           `json:"receipt_type" example:"application/pdf"`
+	ReceiptSize int64            `json:"receipt_size"`
+	Status      string           `json:"status" example:"pending"`
+	ReviewNote  string           `json:"review_note"`
+	PayPeriod   string           `json:"pay_period" example:"2025-01"`
+	Transitions []TransitionData `json:"transitions,omitempty"`
+}
+
+type TransitionData struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Note       string `json:"note"`
+	By         int64  `json:"by"`
+	ByName     string `json:"by_name"`
+	At         string `json:"at"`
+}
@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dwilanang/psp/internal/auth/util"
+	"github.com/dwilanang/psp/internal/permission"
+	"github.com/dwilanang/psp/internal/reimbursement"
+	"github.com/dwilanang/psp/internal/reimbursement/dto"
+	"github.com/dwilanang/psp/internal/reimbursement/service"
+	"github.com/dwilanang/psp/utils"
+	utilrequest "github.com/dwilanang/psp/utils/request"
+	"github.com/dwilanang/psp/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	Deps reimbursement.Dependencies
+}
+
+func NewHandler(deps reimbursement.Dependencies) *Handler {
+	return &Handler{
+		Deps: deps,
+	}
+}
+
+// Submit godoc
+// @Security BearerAuth
+// @Summary      Submit reimbursement
+// @Description  Submit a pending reimbursement claim with its receipt for the authenticated user
+// @Tags         reimbursement
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        category      formData  string  true   "Category, one of REIMBURSEMENT_CATEGORIES"
+// @Param        amount        formData  string  true   "Amount"
+// @Param        expense_date  formData  string  true   "Expense date (YYYY-MM-DD)"
+// @Param        description   formData  string  false  "Description"
+// @Param        receipt       formData  file    true   "Receipt (JPEG, PNG or PDF)"
+// @Success      201  {object}  response.ApiResponse{data=dto.ReimbursementData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /reimbursements/submit [post]
+func (h *Handler) Submit(c *gin.Context) {
+	var rr dto.ReimbursementRequest
+	if err := c.ShouldBind(&rr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	header, err := c.FormFile("receipt")
+	if err != nil {
+		response.Error(c, service.ErrInvalidReceipt, "")
+		return
+	}
+	receipt, err := header.Open()
+	if err != nil {
+		response.Error(c, service.ErrInvalidReceipt, "")
+		return
+	}
+	defer receipt.Close()
+
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	rr.By = by
+	rr.Receipt = receipt
+	rr.ReceiptName = header.Filename
+
+	resp, err := h.Deps.Service.Submit(&rr)
+	if err != nil {
+		response.Error(c, err, "could not submit reimbursement")
+		return
+	}
+	response.Created(c, resp.Data)
+}
+
+// Mine godoc
+// @Security BearerAuth
+// @Summary      Get my reimbursements
+// @Description  Get the reimbursement claims of the authenticated user
+// @Tags         reimbursement
+// @Accept       json
+// @Produce      json
+// @Param        status    query     string  false  "Status (pending, approved, rejected or cancelled)"
+// @Param        category  query     string  false  "Category"
+// @Param        period    query     string  false  "Expense period (YYYY-MM)"
+// @Success      200  {object}  response.ApiResponse{data=[]dto.ReimbursementData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /reimbursements/me [get]
+func (h *Handler) Mine(c *gin.Context) {
+	var fr dto.ReimbursementFilterRequest
+	if err := c.ShouldBindQuery(&fr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	id, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	fr.UserID = id
+
+	h.list(c, &fr)
+}
+
+// GetAll godoc
+// @Security BearerAuth
+// @Summary      Get reimbursements
+// @Description  Get the reimbursement claims of every user, optionally filtered by user, status, category and period
+// @Tags         reimbursement
+// @Accept       json
+// @Produce      json
+// @Param        user_id   query     int     false  "User ID"
+// @Param        status    query     string  false  "Status (pending, approved, rejected or cancelled)"
+// @Param        category  query     string  false  "Category"
+// @Param        period    query     string  false  "Expense period (YYYY-MM)"
+// @Success      200  {object}  response.ApiResponse{data=[]dto.ReimbursementData}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /reimbursements/all [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	var fr dto.ReimbursementFilterRequest
+	if err := c.ShouldBindQuery(&fr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+	h.list(c, &fr)
+}
+
+// Get godoc
+// @Security BearerAuth
+// @Summary      Get reimbursement
+// @Description  Get a reimbursement claim with the history of its status transitions
+// @Tags         reimbursement
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Reimbursement ID"
+// @Success      200  {object}  response.ApiResponse{data=dto.ReimbursementData}
+// @Failure      404  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /reimbursements/detail/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	resp, err := h.Deps.Service.Get(utils.ConvertStringToInt(c.Param("id")))
+	if err != nil {
+		response.Error(c, err, "could not fetch reimbursement")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// Receipt godoc
+// @Security BearerAuth
+// @Summary      Download receipt
+// @Description  Download the receipt of a reimbursement claim. Employees may only download their own receipts unless they hold reimbursements:read
+// @Tags         reimbursement
+// @Produce      application/octet-stream
+// @Param        id   path      int  true  "Reimbursement ID"
+// @Success      200  {file}    binary
+// @Failure      404  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /reimbursements/{id}/receipt [get]
+func (h *Handler) Receipt(c *gin.Context) {
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	set, _ := c.Get("permissions")
+	permissions, _ := set.(permission.Set)
+
+	data, name, contentType, err := h.Deps.Service.Receipt(utils.ConvertStringToInt(c.Param("id")), by, permissions.Allows(permission.ReimbursementsRead))
+	if err != nil {
+		response.Error(c, err, "could not read receipt")
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// Approve godoc
+// @Security BearerAuth
+// @Summary      Approve reimbursement
+// @Description  Approve a pending reimbursement claim so it is paid on the next payslip
+// @Tags         reimbursement
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                             true  "Reimbursement ID"
+// @Param        body  body      dto.ReimbursementReviewRequest  true  "Review payload"
+// @Success      200   {object}  response.ApiResponse{data=dto.ReimbursementData}
+// @Failure      400   {object}  response.Problem
+// @Failure      403   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /reimbursements/approve/{id} [put]
+func (h *Handler) Approve(c *gin.Context) {
+	h.transition(c, h.Deps.Service.Approve, "could not approve reimbursement")
+}
+
+// Reject godoc
+// @Security BearerAuth
+// @Summary      Reject reimbursement
+// @Description  Reject a pending reimbursement claim
+// @Tags         reimbursement
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                             true  "Reimbursement ID"
+// @Param        body  body      dto.ReimbursementReviewRequest  true  "Review payload"
+// @Success      200   {object}  response.ApiResponse{data=dto.ReimbursementData}
+// @Failure      400   {object}  response.Problem
+// @Failure      403   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /reimbursements/reject/{id} [put]
+func (h *Handler) Reject(c *gin.Context) {
+	h.transition(c, h.Deps.Service.Reject, "could not reject reimbursement")
+}
+
+// Cancel godoc
+// @Security BearerAuth
+// @Summary      Cancel reimbursement
+// @Description  Withdraw a pending reimbursement claim of the authenticated user
+// @Tags         reimbursement
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                             true  "Reimbursement ID"
+// @Param        body  body      dto.ReimbursementReviewRequest  true  "Cancellation payload"
+// @Success      200   {object}  response.ApiResponse{data=dto.ReimbursementData}
+// @Failure      400   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /reimbursements/cancel/{id} [put]
+func (h *Handler) Cancel(c *gin.Context) {
+	h.transition(c, h.Deps.Service.Cancel, "could not cancel reimbursement")
+}
+
+func (h *Handler) list(c *gin.Context, request *dto.ReimbursementFilterRequest) {
+	resp, err := h.Deps.Service.List(request)
+	if err != nil {
+		response.Error(c, err, "could not fetch reimbursements")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+func (h *Handler) transition(c *gin.Context, decide func(*dto.ReimbursementReviewRequest) (dto.ReimbursementResponse, error), message string) {
+	var rr dto.ReimbursementReviewRequest
+	if err := c.ShouldBindJSON(&rr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	by, err := util.GetClaimsID(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	rr.ID = utils.ConvertStringToInt(c.Param("id"))
+	rr.By = by
+
+	resp, err := decide(&rr)
+	if err != nil {
+		response.Error(c, err, message)
+		return
+	}
+	response.OK(c, resp.Data)
+}
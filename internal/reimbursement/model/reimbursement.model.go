@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Claim statuses. A claim is submitted pending; approvers approve or reject it and its owner may cancel it
+// while it is still pending. An approved claim is paid on the payslip of its pay period.
+const (
+	StatusPending   = "pending"
+	StatusApproved  = "approved"
+	StatusRejected  = "rejected"
+	StatusCancelled = "cancelled"
+)
+
+type Reimbursement struct {
+	ID          int64           `db:"id"`
+	UserID      int64           `db:"user_id"`
+	FullName    string          `db:"full_name"`
+	Category    string          `db:"category"`
+	Amount      decimal.Decimal `db:"amount"`
+	ExpenseDate time.Time       `db:"expense_date"`
+	Description string          `db:"description"`
+	ReceiptKey  string          `db:"receipt_key"`
+	ReceiptName string          `db:"receipt_name"`
+	ReceiptType string          `db:"receipt_type"`
+	ReceiptSize int64           `db:"receipt_size"`
+	Status      string          `db:"status"`
+	ReviewNote  string          `db:"review_note"`
+	PayPeriod   string          `db:"pay_period"`
+	CreatedBy   int64           `db:"created_by"`
+	CreatedAt   time.Time       `db:"created_at"`
+	UpdatedBy   int64           `db:"updated_by"`
+	UpdatedAt   time.Time       `db:"updated_at"`
+	Transitions []*Transition   `db:"-"`
+}
+
+// Transition records who moved a claim between two statuses and when.
+// FromStatus is empty for the submission.
+type Transition struct {
+	ID              int64     `db:"id"`
+	ReimbursementID int64     `db:"reimbursement_id"`
+	FromStatus      string    `db:"from_status"`
+	ToStatus        string    `db:"to_status"`
+	Note            string    `db:"note"`
+	CreatedBy       int64     `db:"created_by"`
+	CreatedByName   string    `db:"created_by_name"`
+	CreatedAt       time.Time `db:"created_at"`
+}
+
+type ReimbursementFilter struct {
+	UserID   int64
+	Status   string
+	Category string
+	From     *time.Time
+	To       *time.Time
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"github.com/dwilanang/psp/internal/reimbursement/model"
+)
+
+//go:generate mockgen -source=reimbursement.repository.go -package=mocks -destination=mocks/mock_reimbursement_repository.go
+
+// Repository defines an interface for data operations related to reimbursement claims and their transitions.
+type Repository interface {
+	// Fetch retrieves claims matching the filter, most recent expense first.
+	// Param: filter - a pointer to ReimbursementFilter; zero fields are ignored.
+	// Returns a slice of Reimbursement pointers and an error if the operation fails.
+	Fetch(filter *model.ReimbursementFilter) ([]*model.Reimbursement, error)
+
+	// FindByID retrieves a claim and its transitions by ID.
+	// Param: id - the ID of the claim.
+	// Returns a pointer to the Reimbursement and an error if the operation fails or the claim is not found.
+	FindByID(id int64) (*model.Reimbursement, error)
+
+	// Create inserts a pending claim and its submission transition in a single transaction.
+	// Param: reimbursement - a pointer to the Reimbursement entity to be created.
+	// Returns an error if the operation fails.
+	Create(reimbursement *model.Reimbursement) error
+
+	// Transition moves a claim from one status to another and records the transition.
+	// Param: id - the ID of the claim; from, to - the expected and new status; by - the ID of the acting user;
+	// note - an optional note explaining the decision; payPeriod - the pay period of an approved claim, or empty.
+	// Returns false if the claim was not in the expected status, and an error if the operation fails.
+	Transition(id int64, from string, to string, by int64, note string, payPeriod string) (bool, error)
+
+	// FetchPayable retrieves the approved claims of a user paid in a pay period.
+	// Param: userID - the ID of the user; period - the pay period formatted as YYYY-MM.
+	// Returns a slice of Reimbursement pointers and an error if the operation fails.
+	FetchPayable(userID int64, period string) ([]*model.Reimbursement, error)
+}
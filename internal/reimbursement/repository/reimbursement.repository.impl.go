@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/reimbursement/model"
+	"github.com/jmoiron/sqlx"
+)
+
+const selectReimbursement = `
+		SELECT 
+			r.id, r.user_id,
+			COALESCE(u.full_name, '') AS full_name,
+			r.category, r.amount, r.expense_date,
+			COALESCE(r.description, '') AS description,
+			r.receipt_key, r.receipt_name, r.receipt_type, r.receipt_size, r.status,
+			COALESCE(r.review_note, '') AS review_note,
+			COALESCE(r.pay_period, '') AS pay_period,
+			COALESCE(r.created_by, 0) AS created_by,
+			r.created_at,
+			COALESCE(r.updated_by, r.created_by, 0) AS updated_by,
+			COALESCE(r.updated_at, r.created_at) AS updated_at
+		FROM reimbursements r
+		INNER JOIN users u ON(u.id=r.user_id)
+`
+
+type repository struct {
+	db *postgres.DB
+}
+
+func NewRepository(db *postgres.DB) *repository {
+	return &repository{db}
+}
+
+func (r *repository) Fetch(filter *model.ReimbursementFilter) ([]*model.Reimbursement, error) {
+	reimbursements := []*model.Reimbursement{}
+	query := selectReimbursement + `
+		WHERE ($1 = 0 OR r.user_id = $1)
+		AND ($2 = '' OR r.status = $2)
+		AND ($3 = '' OR r.category = $3)
+		AND ($4::date IS NULL OR r.expense_date >= $4)
+		AND ($5::date IS NULL OR r.expense_date <= $5)
+		ORDER BY r.expense_date DESC, r.id DESC
+	`
+	err := r.db.Replica().Select(&reimbursements, query, filter.UserID, filter.Status, filter.Category, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+	return reimbursements, nil
+}
+
+func (r *repository) FindByID(id int64) (*model.Reimbursement, error) {
+	var reimbursement model.Reimbursement
+	query := selectReimbursement + `
+		WHERE r.id = $1
+	`
+	err := r.db.Get(&reimbursement, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	transitionQuery := `
+		SELECT 
+			t.id, t.reimbursement_id,
+			COALESCE(t.from_status, '') AS from_status,
+			t.to_status,
+			COALESCE(t.note, '') AS note,
+			t.created_by,
+			COALESCE(u.full_name, '') AS created_by_name,
+			t.created_at
+		FROM reimbursement_transitions t
+		INNER JOIN users u ON(u.id=t.created_by)
+		WHERE t.reimbursement_id = $1
+		ORDER BY t.id
+	`
+	err = r.db.Select(&reimbursement.Transitions, transitionQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	return &reimbursement, nil
+}
+
+func (r *repository) Create(reimbursement *model.Reimbursement) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO reimbursements (user_id, category, amount, expense_date, description,
+			receipt_key, receipt_name, receipt_type, receipt_size, status,
+			created_by, created_at, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9, $10, $11, NOW(), $11, NOW())
+		RETURNING id, created_at
+	`
+	err = tx.QueryRowx(
+		query,
+		reimbursement.UserID,
+		reimbursement.Category,
+		reimbursement.Amount,
+		reimbursement.ExpenseDate,
+		reimbursement.Description,
+		reimbursement.ReceiptKey,
+		reimbursement.ReceiptName,
+		reimbursement.ReceiptType,
+		reimbursement.ReceiptSize,
+		reimbursement.Status,
+		reimbursement.CreatedBy,
+	).Scan(&reimbursement.ID, &reimbursement.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	err = insertTransition(tx, reimbursement.ID, "", reimbursement.Status, reimbursement.CreatedBy, "")
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *repository) Transition(id int64, from string, to string, by int64, note string, payPeriod string) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE reimbursements SET status = $3, review_note = NULLIF($4, ''), pay_period = NULLIF($5, ''),
+			updated_by = $6, updated_at = NOW()
+		WHERE id = $1 AND status = $2
+	`
+	result, err := tx.Exec(query, id, from, to, note, payPeriod, by)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+
+	err = insertTransition(tx, id, from, to, by, note)
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+func (r *repository) FetchPayable(userID int64, period string) ([]*model.Reimbursement, error) {
+	reimbursements := []*model.Reimbursement{}
+	query := selectReimbursement + `
+		WHERE r.user_id = $1 AND r.status = 'approved' AND r.pay_period = $2
+		ORDER BY r.category, r.expense_date, r.id
+	`
+	err := r.db.Select(&reimbursements, query, userID, period)
+	if err != nil {
+		return nil, err
+	}
+	return reimbursements, nil
+}
+
+func insertTransition(tx *sqlx.Tx, reimbursementID int64, from string, to string, by int64, note string) error {
+	query := `
+		INSERT INTO reimbursement_transitions (reimbursement_id, from_status, to_status, note, created_by, created_at)
+		VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''), $5, NOW())
+	`
+	_, err := tx.Exec(query, reimbursementID, from, to, note, by)
+	return err
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/reimbursement/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*postgres.DB, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return postgres.NewDB(sqlx.NewDb(db, "postgres")), mock, func() { db.Close() }
+}
+
+func TestCreate_RecordsSubmission(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO reimbursements").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+	mock.ExpectExec("INSERT INTO reimbursement_transitions").
+		WithArgs(int64(1), "", model.StatusPending, "", int64(7)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	reimbursement := &model.Reimbursement{UserID: 7, Category: "transport", Amount: decimal.NewFromInt(150000), Status: model.StatusPending, CreatedBy: 7}
+	err := repo.Create(reimbursement)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), reimbursement.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransition_SetsPayPeriod(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE reimbursements SET status").
+		WithArgs(int64(1), model.StatusPending, model.StatusApproved, "", "2025-01", int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO reimbursement_transitions").
+		WithArgs(int64(1), model.StatusPending, model.StatusApproved, "", int64(2)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE reimbursements SET status").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	ok, err := repo.Transition(1, model.StatusPending, model.StatusApproved, 2, "", "2025-01")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = repo.Transition(1, model.StatusPending, model.StatusApproved, 2, "", "2025-01")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchPayable(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "full_name", "category", "amount", "expense_date", "description",
+		"receipt_key", "receipt_name", "receipt_type", "receipt_size", "status", "review_note", "pay_period",
+		"created_by", "created_at", "updated_by", "updated_at"}).
+		AddRow(1, 7, "John Doe", "transport", "150000.00", day, "", "receipts/7/a.pdf", "taxi.pdf", "application/pdf", 1024,
+			"approved", "", "2025-01", 7, day, 2, day)
+
+	mock.ExpectQuery("r.status = 'approved' AND r.pay_period = \\$2").
+		WithArgs(int64(7), "2025-01").
+		WillReturnRows(rows)
+
+	reimbursements, err := repo.FetchPayable(7, "2025-01")
+
+	assert.NoError(t, err)
+	assert.Len(t, reimbursements, 1)
+	assert.Equal(t, "150000", reimbursements[0].Amount.String())
+}
@@ -0,0 +1,27 @@
+package route
+
+import (
+	"github.com/dwilanang/psp/internal/permission"
+	"github.com/dwilanang/psp/internal/registry"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, registry *registry.Registry) {
+	h := registry.NewReimbursementHandler()
+
+	reimbursementsGroup := rg.Group("/reimbursements")
+	{
+		// Every authenticated user submits, reads and cancels their own claims.
+		reimbursementsGroup.POST("/submit", h.Submit)
+		reimbursementsGroup.GET("/me", h.Mine)
+		reimbursementsGroup.PUT("/cancel/:id", h.Cancel)
+		// RequirePermission without arguments only resolves the caller's permissions
+		// so the handler can tell whether they may read other users' receipts.
+		reimbursementsGroup.GET("/:id/receipt", registry.RequirePermission(), h.Receipt)
+
+		reimbursementsGroup.GET("/all", registry.RequirePermission(permission.ReimbursementsRead), h.GetAll)
+		reimbursementsGroup.GET("/detail/:id", registry.RequirePermission(permission.ReimbursementsRead), h.Get)
+		reimbursementsGroup.PUT("/approve/:id", registry.RequirePermission(permission.ReimbursementsApprove), h.Approve)
+		reimbursementsGroup.PUT("/reject/:id", registry.RequirePermission(permission.ReimbursementsApprove), h.Reject)
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+	"github.com/dwilanang/psp/internal/reimbursement/model"
+	"github.com/shopspring/decimal"
+)
+
+// PayableFetcher retrieves the approved claims of a user paid in a pay period.
+// It is satisfied by the reimbursement repository.
+type PayableFetcher interface {
+	FetchPayable(userID int64, period string) ([]*model.Reimbursement, error)
+}
+
+// PayslipContributor pays approved claims as payslip earnings, one line per category.
+type PayslipContributor struct {
+	reimbursements PayableFetcher
+}
+
+func NewPayslipContributor(reimbursements PayableFetcher) *PayslipContributor {
+	return &PayslipContributor{reimbursements: reimbursements}
+}
+
+// Contribute implements the payslip Contributor interface.
+func (p *PayslipContributor) Contribute(calc *payslipmodel.Calculation) ([]*payslipmodel.PayslipItem, error) {
+	reimbursements, err := p.reimbursements.FetchPayable(calc.UserID, calc.Period.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claims arrive ordered by category, so each category is a contiguous run.
+	var items []*payslipmodel.PayslipItem
+	for start := 0; start < len(reimbursements); {
+		category := reimbursements[start].Category
+		total, end := decimal.Zero, start
+		for ; end < len(reimbursements) && reimbursements[end].Category == category; end++ {
+			total = total.Add(reimbursements[end].Amount)
+		}
+		claims := "claims"
+		if end-start == 1 {
+			claims = "claim"
+		}
+		items = append(items, &payslipmodel.PayslipItem{
+			Code:   "REIMBURSEMENT_" + strings.ToUpper(category),
+			Name:   fmt.Sprintf("Reimbursement %s (%d %s)", strings.ToUpper(category[:1])+category[1:], end-start, claims),
+			Type:   payslipmodel.TypeEarning,
+			Amount: total.Round(2),
+		})
+		start = end
+	}
+	return items, nil
+}
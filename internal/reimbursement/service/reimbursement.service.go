@@ -0,0 +1,48 @@
+package service
+
+import (
+	"github.com/dwilanang/psp/internal/reimbursement/dto"
+)
+
+//go:generate mockgen -source=reimbursement.service.go -package=mocks -destination=mocks/mock_reimbursement_service.go
+
+// Service defines the interface for business logic related to reimbursement claims.
+// Employees submit claims with a receipt for themselves; approvers approve or reject them once,
+// and approved claims are paid as earnings on the next payslip that has not been issued yet.
+type Service interface {
+	// Submit stores the receipt and files a pending claim for the authenticated user.
+	// Param: request - a pointer to ReimbursementRequest DTO carrying the receipt file.
+	// Returns the created claim, ErrInvalidCategory, ErrInvalidAmount, ErrInvalidDate or ErrInvalidReceipt.
+	Submit(request *dto.ReimbursementRequest) (dto.ReimbursementResponse, error)
+
+	// List retrieves claims matching the filter, most recent expense first.
+	// Param: request - a pointer to ReimbursementFilterRequest DTO.
+	// Returns a ReimbursementListResponse DTO and an error if the operation fails.
+	List(request *dto.ReimbursementFilterRequest) (dto.ReimbursementListResponse, error)
+
+	// Get retrieves a claim with its transitions.
+	// Param: id - the ID of the claim.
+	// Returns a ReimbursementResponse DTO and ErrReimbursementNotFound if it does not exist.
+	Get(id int64) (dto.ReimbursementResponse, error)
+
+	// Receipt reads the receipt file of a claim. Viewers without readAll may only read their own receipts;
+	// other claims are reported as not found so their existence is not disclosed.
+	// Param: id - the ID of the claim; viewerID - the ID of the requesting user; readAll - whether the viewer may read every claim.
+	// Returns the file, its name, its content type and ErrReimbursementNotFound.
+	Receipt(id int64, viewerID int64, readAll bool) ([]byte, string, string, error)
+
+	// Approve approves a pending claim and assigns the pay period whose payslip pays it.
+	// Param: request - a pointer to ReimbursementReviewRequest DTO.
+	// Returns the updated claim, ErrNotPending, or ErrSelfReview if approvers review their own claim.
+	Approve(request *dto.ReimbursementReviewRequest) (dto.ReimbursementResponse, error)
+
+	// Reject rejects a pending claim.
+	// Param: request - a pointer to ReimbursementReviewRequest DTO.
+	// Returns the updated claim and the same errors as Approve.
+	Reject(request *dto.ReimbursementReviewRequest) (dto.ReimbursementResponse, error)
+
+	// Cancel withdraws a pending claim on behalf of its owner.
+	// Param: request - a pointer to ReimbursementReviewRequest DTO.
+	// Returns the updated claim, ErrNotPending, or ErrReimbursementNotFound if the claim belongs to someone else.
+	Cancel(request *dto.ReimbursementReviewRequest) (dto.ReimbursementResponse, error)
+}
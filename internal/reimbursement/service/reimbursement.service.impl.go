@@ -0,0 +1,325 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dwilanang/psp/internal/payroll/lock"
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+	"github.com/dwilanang/psp/internal/reimbursement/dto"
+	"github.com/dwilanang/psp/internal/reimbursement/model"
+	"github.com/dwilanang/psp/internal/reimbursement/repository"
+	"github.com/dwilanang/psp/internal/reimbursement/storage"
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const dateLayout = "2006-01-02"
+
+// maxDeferredPeriods bounds how far ahead an approved claim may be scheduled when payslips are already issued.
+const maxDeferredPeriods = 12
+
+var (
+	ErrReimbursementNotFound = apperror.NotFound("reimbursement claim not found")
+	ErrInvalidCategory       = apperror.Validation("category is not a configured reimbursement category")
+	ErrInvalidAmount         = apperror.Validation("amount must be greater than 0 with at most 2 decimal places")
+	ErrInvalidDate           = apperror.Validation("expense_date must be formatted as YYYY-MM-DD and not be in the future")
+	ErrInvalidReceipt        = apperror.Validation("receipt must be a JPEG, PNG or PDF file within the size limit")
+	ErrNotPending            = apperror.Conflict("reimbursement claim has already been reviewed")
+	ErrSelfReview            = apperror.Forbidden("you cannot review your own reimbursement claim")
+	ErrNoPayPeriod           = apperror.Conflict("no upcoming pay period is open for this claim")
+)
+
+// receiptTypes maps the accepted receipt content types to the extension they are stored with.
+var receiptTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+// Policy configures which claims are accepted.
+type Policy struct {
+	Categories     []string
+	MaxReceiptSize int64 // bytes
+}
+
+type service struct {
+	repo     repository.Repository
+	receipts storage.Storage
+	payslips lock.PayslipFetcher
+	periods  lock.PeriodLocker
+	policy   Policy
+	now      func() time.Time
+}
+
+// NewService creates the reimbursement service. payslips and periods may be nil, in which case
+// approved claims are paid in the month they are approved.
+func NewService(r repository.Repository, receipts storage.Storage, payslips lock.PayslipFetcher, periods lock.PeriodLocker, policy Policy) *service {
+	return &service{repo: r, receipts: receipts, payslips: payslips, periods: periods, policy: policy, now: time.Now}
+}
+
+// Submit implements the Service interface.
+func (s *service) Submit(request *dto.ReimbursementRequest) (dto.ReimbursementResponse, error) {
+	category := strings.ToLower(strings.TrimSpace(request.Category))
+	if !s.allowsCategory(category) {
+		return dto.ReimbursementResponse{}, ErrInvalidCategory
+	}
+	if !request.Amount.IsPositive() || !request.Amount.Equal(request.Amount.Round(2)) {
+		return dto.ReimbursementResponse{}, ErrInvalidAmount
+	}
+	day, err := time.Parse(dateLayout, request.ExpenseDate)
+	if err != nil || day.After(s.now()) {
+		return dto.ReimbursementResponse{}, ErrInvalidDate
+	}
+	if request.Receipt == nil {
+		return dto.ReimbursementResponse{}, ErrInvalidReceipt
+	}
+	// Read one byte past the limit so an oversized receipt is detected without buffering all of it.
+	receipt, err := io.ReadAll(io.LimitReader(request.Receipt, s.policy.MaxReceiptSize+1))
+	if err != nil {
+		return dto.ReimbursementResponse{}, err
+	}
+	contentType := http.DetectContentType(receipt)
+	extension, ok := receiptTypes[contentType]
+	if !ok || len(receipt) == 0 || int64(len(receipt)) > s.policy.MaxReceiptSize {
+		return dto.ReimbursementResponse{}, ErrInvalidReceipt
+	}
+
+	reimbursement := &model.Reimbursement{
+		UserID:      request.By,
+		Category:    category,
+		Amount:      request.Amount,
+		ExpenseDate: day,
+		Description: request.Description,
+		ReceiptKey:  fmt.Sprintf("receipts/%d/%s%s", request.By, uuid.NewString(), extension),
+		ReceiptName: receiptName(request.ReceiptName, extension),
+		ReceiptType: contentType,
+		ReceiptSize: int64(len(receipt)),
+		Status:      model.StatusPending,
+		CreatedBy:   request.By,
+		UpdatedBy:   request.By,
+	}
+	err = s.receipts.Put(reimbursement.ReceiptKey, receipt, contentType)
+	if err != nil {
+		return dto.ReimbursementResponse{}, err
+	}
+
+	err = s.repo.Create(reimbursement)
+	if err != nil {
+		// The claim was not recorded, so its receipt would never be read.
+		if err := s.receipts.Delete(reimbursement.ReceiptKey); err != nil {
+			logrus.WithError(err).WithField("receipt_key", reimbursement.ReceiptKey).Warn("failed to delete the receipt of an unrecorded reimbursement")
+		}
+		return dto.ReimbursementResponse{}, err
+	}
+	return dto.ReimbursementResponse{Data: toData(reimbursement)}, nil
+}
+
+// List implements the Service interface.
+func (s *service) List(request *dto.ReimbursementFilterRequest) (dto.ReimbursementListResponse, error) {
+	filter := &model.ReimbursementFilter{
+		UserID:   request.UserID,
+		Status:   request.Status,
+		Category: strings.ToLower(request.Category),
+	}
+	if request.Period != "" {
+		month, err := payslipmodel.ParsePeriod(request.Period)
+		if err != nil {
+			return dto.ReimbursementListResponse{}, err
+		}
+		filter.From, filter.To = &month.Start, &month.End
+	}
+
+	reimbursements, err := s.repo.Fetch(filter)
+	if err != nil {
+		return dto.ReimbursementListResponse{}, err
+	}
+
+	data := make([]dto.ReimbursementData, 0, len(reimbursements))
+	for _, reimbursement := range reimbursements {
+		data = append(data, toData(reimbursement))
+	}
+	return dto.ReimbursementListResponse{Data: data}, nil
+}
+
+// Get implements the Service interface.
+func (s *service) Get(id int64) (dto.ReimbursementResponse, error) {
+	reimbursement, err := s.find(id)
+	if err != nil {
+		return dto.ReimbursementResponse{}, err
+	}
+	return dto.ReimbursementResponse{Data: toData(reimbursement)}, nil
+}
+
+// Receipt implements the Service interface.
+func (s *service) Receipt(id int64, viewerID int64, readAll bool) ([]byte, string, string, error) {
+	reimbursement, err := s.find(id)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if !readAll && reimbursement.UserID != viewerID {
+		return nil, "", "", ErrReimbursementNotFound
+	}
+
+	data, err := s.receipts.Get(reimbursement.ReceiptKey)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return data, reimbursement.ReceiptName, reimbursement.ReceiptType, nil
+}
+
+// Approve implements the Service interface.
+func (s *service) Approve(request *dto.ReimbursementReviewRequest) (dto.ReimbursementResponse, error) {
+	return s.review(request, model.StatusApproved)
+}
+
+// Reject implements the Service interface.
+func (s *service) Reject(request *dto.ReimbursementReviewRequest) (dto.ReimbursementResponse, error) {
+	return s.review(request, model.StatusRejected)
+}
+
+// Cancel implements the Service interface.
+func (s *service) Cancel(request *dto.ReimbursementReviewRequest) (dto.ReimbursementResponse, error) {
+	reimbursement, err := s.find(request.ID)
+	if err != nil {
+		return dto.ReimbursementResponse{}, err
+	}
+	if reimbursement.UserID != request.By {
+		return dto.ReimbursementResponse{}, ErrReimbursementNotFound
+	}
+	return s.transition(reimbursement, model.StatusCancelled, request, "")
+}
+
+func (s *service) review(request *dto.ReimbursementReviewRequest, status string) (dto.ReimbursementResponse, error) {
+	reimbursement, err := s.find(request.ID)
+	if err != nil {
+		return dto.ReimbursementResponse{}, err
+	}
+	if reimbursement.UserID == request.By {
+		return dto.ReimbursementResponse{}, ErrSelfReview
+	}
+
+	payPeriod := ""
+	if status == model.StatusApproved && reimbursement.Status == model.StatusPending {
+		if payPeriod, err = s.payPeriod(reimbursement.UserID); err != nil {
+			return dto.ReimbursementResponse{}, err
+		}
+	}
+	return s.transition(reimbursement, status, request, payPeriod)
+}
+
+func (s *service) transition(reimbursement *model.Reimbursement, status string, request *dto.ReimbursementReviewRequest, payPeriod string) (dto.ReimbursementResponse, error) {
+	if reimbursement.Status != model.StatusPending {
+		return dto.ReimbursementResponse{}, ErrNotPending
+	}
+
+	ok, err := s.repo.Transition(reimbursement.ID, model.StatusPending, status, request.By, request.Note, payPeriod)
+	if err != nil {
+		return dto.ReimbursementResponse{}, err
+	}
+	if !ok {
+		return dto.ReimbursementResponse{}, ErrNotPending
+	}
+
+	reimbursement, err = s.find(reimbursement.ID)
+	if err != nil {
+		return dto.ReimbursementResponse{}, err
+	}
+	return dto.ReimbursementResponse{Data: toData(reimbursement)}, nil
+}
+
+// payPeriod returns the first period, starting with the current month, that is not closed
+// and for which the user has no payslip yet, so the claim is paid exactly once.
+func (s *service) payPeriod(userID int64) (string, error) {
+	now := s.now()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxDeferredPeriods; i++ {
+		period, _ := payslipmodel.ParsePeriod(month.AddDate(0, i, 0).Format(payslipmodel.PeriodLayout))
+
+		if s.periods != nil {
+			locked, err := s.periods.IsLocked(period.Start, &period.End)
+			if err != nil {
+				return "", err
+			}
+			if locked {
+				continue
+			}
+		}
+		if s.payslips != nil {
+			payslips, err := s.payslips.Fetch(&payslipmodel.PayslipFilter{UserID: userID, Period: period.Code})
+			if err != nil {
+				return "", err
+			}
+			if len(payslips) > 0 {
+				continue
+			}
+		}
+		return period.Code, nil
+	}
+	return "", ErrNoPayPeriod
+}
+
+func (s *service) find(id int64) (*model.Reimbursement, error) {
+	reimbursement, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReimbursementNotFound
+		}
+		return nil, err
+	}
+	return reimbursement, nil
+}
+
+func (s *service) allowsCategory(category string) bool {
+	for _, allowed := range s.policy.Categories {
+		if category == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// receiptName keeps the base name of the uploaded file, with the extension matching its detected type.
+func receiptName(name string, extension string) string {
+	base := strings.TrimSuffix(filepath.Base(filepath.Clean("/"+name)), filepath.Ext(name))
+	if base == "" || base == "/" || base == "." {
+		base = "receipt"
+	}
+	return base + extension
+}
+
+func toData(reimbursement *model.Reimbursement) dto.ReimbursementData {
+	data := dto.ReimbursementData{
+		ID:          reimbursement.ID,
+		UserID:      reimbursement.UserID,
+		FullName:    reimbursement.FullName,
+		Category:    reimbursement.Category,
+		Amount:      reimbursement.Amount,
+		ExpenseDate: reimbursement.ExpenseDate.Format(dateLayout),
+		Description: reimbursement.Description,
+		ReceiptName: reimbursement.ReceiptName,
+		ReceiptType: reimbursement.ReceiptType,
+		ReceiptSize: reimbursement.ReceiptSize,
+		Status:      reimbursement.Status,
+		ReviewNote:  reimbursement.ReviewNote,
+		PayPeriod:   reimbursement.PayPeriod,
+	}
+	for _, transition := range reimbursement.Transitions {
+		data.Transitions = append(data.Transitions, dto.TransitionData{
+			FromStatus: transition.FromStatus,
+			ToStatus:   transition.ToStatus,
+			Note:       transition.Note,
+			By:         transition.CreatedBy,
+			ByName:     transition.CreatedByName,
+			At:         transition.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return data
+}
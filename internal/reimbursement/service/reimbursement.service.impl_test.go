@@ -0,0 +1,263 @@
+package service
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	mockrepo "github.com/dwilanang/psp/internal/reimbursement/repository/mocks"
+
+	payslipmodel "github.com/dwilanang/psp/internal/payslip/model"
+	"github.com/dwilanang/psp/internal/reimbursement/dto"
+	"github.com/dwilanang/psp/internal/reimbursement/model"
+	"github.com/dwilanang/psp/internal/reimbursement/storage"
+)
+
+var testPolicy = Policy{Categories: []string{"transport", "meal"}, MaxReceiptSize: 1 << 10}
+
+var pdfReceipt = []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n")
+
+type fakeStorage struct {
+	objects map[string][]byte
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{objects: map[string][]byte{}}
+}
+
+func (f *fakeStorage) Put(key string, data []byte, contentType string) error {
+	f.objects[key] = data
+	return nil
+}
+
+func (f *fakeStorage) Get(key string) ([]byte, error) {
+	data, ok := f.objects[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return data, nil
+}
+
+func (f *fakeStorage) Delete(key string) error {
+	delete(f.objects, key)
+	return nil
+}
+
+type fakePeriodLocker struct {
+	locked map[string]bool
+}
+
+func (f *fakePeriodLocker) IsLocked(from time.Time, to *time.Time) (bool, error) {
+	return f.locked[from.Format(payslipmodel.PeriodLayout)], nil
+}
+
+type fakePayslipFetcher struct {
+	issued map[string]bool
+}
+
+func (f *fakePayslipFetcher) Fetch(filter *payslipmodel.PayslipFilter) ([]*payslipmodel.Payslip, error) {
+	if f.issued[filter.Period] {
+		return []*payslipmodel.Payslip{{UserID: filter.UserID, Period: filter.Period}}, nil
+	}
+	return nil, nil
+}
+
+func submission(receipt []byte) *dto.ReimbursementRequest {
+	return &dto.ReimbursementRequest{
+		Category:    "Transport",
+		Amount:      decimal.RequireFromString("150000.50"),
+		ExpenseDate: "2025-01-15",
+		ReceiptName: "../taxi.png",
+		Receipt:     bytes.NewReader(receipt),
+		By:          7,
+	}
+}
+
+func TestService_Submit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	receipts := newFakeStorage()
+	svc := NewService(mockRepo, receipts, nil, nil, testPolicy)
+
+	mockRepo.
+		EXPECT().
+		Create(gomock.AssignableToTypeOf(&model.Reimbursement{})).
+		DoAndReturn(func(reimbursement *model.Reimbursement) error {
+			assert.Equal(t, "transport", reimbursement.Category)
+			assert.True(t, strings.HasPrefix(reimbursement.ReceiptKey, "receipts/7/"))
+			assert.Equal(t, "application/pdf", reimbursement.ReceiptType)
+			assert.Contains(t, receipts.objects, reimbursement.ReceiptKey)
+			reimbursement.ID = 1
+			return nil
+		})
+
+	resp, err := svc.Submit(submission(pdfReceipt))
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusPending, resp.Data.Status)
+	// The extension follows the detected content type, not the uploaded name.
+	assert.Equal(t, "taxi.pdf", resp.Data.ReceiptName)
+}
+
+func TestService_Submit_RemovesReceiptWhenNotRecorded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	receipts := newFakeStorage()
+	svc := NewService(mockRepo, receipts, nil, nil, testPolicy)
+
+	mockRepo.EXPECT().Create(gomock.Any()).Return(errors.New("connection reset"))
+
+	_, err := svc.Submit(submission(pdfReceipt))
+
+	assert.Error(t, err)
+	assert.Empty(t, receipts.objects)
+}
+
+func TestService_Submit_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := NewService(mockrepo.NewMockRepository(ctrl), newFakeStorage(), nil, nil, testPolicy)
+
+	request := submission(pdfReceipt)
+	request.Category = "gym"
+	_, err := svc.Submit(request)
+	assert.ErrorIs(t, err, ErrInvalidCategory)
+
+	request = submission(pdfReceipt)
+	request.Amount = decimal.RequireFromString("10.005")
+	_, err = svc.Submit(request)
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+
+	request = submission(pdfReceipt)
+	request.ExpenseDate = time.Now().AddDate(0, 0, 2).Format(dateLayout)
+	_, err = svc.Submit(request)
+	assert.ErrorIs(t, err, ErrInvalidDate)
+
+	_, err = svc.Submit(submission([]byte("plain text is not a receipt")))
+	assert.ErrorIs(t, err, ErrInvalidReceipt)
+
+	_, err = svc.Submit(submission(append(pdfReceipt, make([]byte, 1<<10)...)))
+	assert.ErrorIs(t, err, ErrInvalidReceipt)
+}
+
+func TestService_Approve_SchedulesNextOpenPayslip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	// January is closed and the February payslip of the user is already issued, so the claim is paid in March.
+	periods := &fakePeriodLocker{locked: map[string]bool{"2025-01": true}}
+	payslips := &fakePayslipFetcher{issued: map[string]bool{"2025-02": true}}
+	svc := NewService(mockRepo, newFakeStorage(), payslips, periods, testPolicy)
+	svc.now = func() time.Time { return time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC) }
+
+	gomock.InOrder(
+		mockRepo.EXPECT().FindByID(int64(1)).Return(&model.Reimbursement{ID: 1, UserID: 7, Status: model.StatusPending}, nil),
+		mockRepo.EXPECT().Transition(int64(1), model.StatusPending, model.StatusApproved, int64(2), "ok", "2025-03").Return(true, nil),
+		mockRepo.EXPECT().FindByID(int64(1)).Return(&model.Reimbursement{ID: 1, UserID: 7, Status: model.StatusApproved, PayPeriod: "2025-03"}, nil),
+	)
+
+	resp, err := svc.Approve(&dto.ReimbursementReviewRequest{ID: 1, Note: "ok", By: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-03", resp.Data.PayPeriod)
+}
+
+func TestService_Review_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, newFakeStorage(), nil, nil, testPolicy)
+
+	mockRepo.EXPECT().FindByID(int64(1)).Return(&model.Reimbursement{ID: 1, UserID: 7, Status: model.StatusPending}, nil).Times(3)
+	mockRepo.EXPECT().FindByID(int64(2)).Return(&model.Reimbursement{ID: 2, UserID: 7, Status: model.StatusCancelled}, nil)
+	mockRepo.EXPECT().FindByID(int64(3)).Return(nil, sql.ErrNoRows)
+
+	_, err := svc.Approve(&dto.ReimbursementReviewRequest{ID: 1, By: 7})
+	assert.ErrorIs(t, err, ErrSelfReview)
+
+	// Only the owner may cancel, and someone else's claim is not disclosed.
+	_, err = svc.Cancel(&dto.ReimbursementReviewRequest{ID: 1, By: 8})
+	assert.ErrorIs(t, err, ErrReimbursementNotFound)
+
+	mockRepo.EXPECT().Transition(int64(1), model.StatusPending, model.StatusCancelled, int64(7), "", "").Return(true, nil)
+	mockRepo.EXPECT().FindByID(int64(1)).Return(&model.Reimbursement{ID: 1, UserID: 7, Status: model.StatusCancelled}, nil)
+	resp, err := svc.Cancel(&dto.ReimbursementReviewRequest{ID: 1, By: 7})
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusCancelled, resp.Data.Status)
+
+	_, err = svc.Reject(&dto.ReimbursementReviewRequest{ID: 2, By: 2})
+	assert.ErrorIs(t, err, ErrNotPending)
+
+	_, err = svc.Reject(&dto.ReimbursementReviewRequest{ID: 3, By: 2})
+	assert.ErrorIs(t, err, ErrReimbursementNotFound)
+}
+
+func TestService_Receipt_OwnerOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	receipts := newFakeStorage()
+	receipts.objects["receipts/7/a.pdf"] = pdfReceipt
+	svc := NewService(mockRepo, receipts, nil, nil, testPolicy)
+
+	mockRepo.EXPECT().FindByID(int64(1)).Return(&model.Reimbursement{ID: 1, UserID: 7, ReceiptKey: "receipts/7/a.pdf", ReceiptName: "taxi.pdf", ReceiptType: "application/pdf"}, nil).Times(3)
+
+	data, name, contentType, err := svc.Receipt(1, 7, false)
+	assert.NoError(t, err)
+	assert.Equal(t, pdfReceipt, data)
+	assert.Equal(t, "taxi.pdf", name)
+	assert.Equal(t, "application/pdf", contentType)
+
+	_, _, _, err = svc.Receipt(1, 8, false)
+	assert.ErrorIs(t, err, ErrReimbursementNotFound)
+
+	_, _, _, err = svc.Receipt(1, 8, true)
+	assert.NoError(t, err)
+}
+
+type fakePayableFetcher struct {
+	reimbursements []*model.Reimbursement
+}
+
+func (f *fakePayableFetcher) FetchPayable(userID int64, period string) ([]*model.Reimbursement, error) {
+	return f.reimbursements, nil
+}
+
+func TestPayslipContributor(t *testing.T) {
+	period, _ := payslipmodel.ParsePeriod("2025-01")
+	calc := &payslipmodel.Calculation{UserID: 7, Period: period, BaseSalary: decimal.NewFromInt(5000000)}
+
+	items, err := NewPayslipContributor(&fakePayableFetcher{}).Contribute(calc)
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	reimbursements := &fakePayableFetcher{reimbursements: []*model.Reimbursement{
+		{Category: "meal", Amount: decimal.NewFromInt(75000)},
+		{Category: "transport", Amount: decimal.RequireFromString("150000.50")},
+		{Category: "transport", Amount: decimal.NewFromInt(50000)},
+	}}
+	items, err = NewPayslipContributor(reimbursements).Contribute(calc)
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, "REIMBURSEMENT_MEAL", items[0].Code)
+	assert.Equal(t, "75000.00", items[0].Amount.StringFixed(2))
+	assert.Equal(t, "REIMBURSEMENT_TRANSPORT", items[1].Code)
+	assert.Equal(t, "Reimbursement Transport (2 claims)", items[1].Name)
+	assert.Equal(t, "200000.50", items[1].Amount.StringFixed(2))
+	assert.Equal(t, payslipmodel.TypeEarning, items[1].Type)
+}
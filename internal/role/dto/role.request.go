@@ -3,6 +3,6 @@ package dto
 type RoleRequest struct {
 	ID        int64  `json:"id" swaggerignore:"true"`
 	Name      string `json:"name" binding:"required"`
-	Privilege string `json:"privilege" binding:"required"`
+	Privilege string `json:"privilege" binding:"required" example:"users:create, roles:*"`
 	By        int64  `json:"by" swaggerignore:"true"`
 }
@@ -1,6 +1,11 @@
 package dto
 
 type RoleResponse struct {
-	Message string `json:"message"`
-	Data    any    `json:"data"`
+	Data RoleData `json:"data"`
+}
+
+type RoleData struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Privilege string `json:"privilege" example:"users:create, roles:*"`
 }
@@ -1,13 +1,13 @@
 package handler
 
 import (
-	"net/http"
-
 	"github.com/dwilanang/psp/internal/auth/util"
 	"github.com/dwilanang/psp/internal/role"
 	"github.com/dwilanang/psp/internal/role/dto"
 	"github.com/dwilanang/psp/utils"
+	"github.com/dwilanang/psp/utils/query"
 	utilrequest "github.com/dwilanang/psp/utils/request"
+	"github.com/dwilanang/psp/utils/response"
 	"github.com/gin-gonic/gin"
 )
 
@@ -24,21 +24,34 @@ func NewHandler(deps role.Dependencies) *Handler {
 // GetAll godoc
 // @Security BearerAuth
 // @Summary Get all roles
-// @Description Get list of all roles
+// @Description Get a page of roles. Pages are numbered with page, or pass meta.next_cursor as after to page by cursor.
 // @Tags roles
 // @Accept json
 // @Produce json
-// @Success 200 {array} dto.RoleResponse
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Page size (default 20, max 100)"
+// @Param sort query string false "Comma separated fields to sort on, prefixed with - for descending: id, name, created_at" example(-created_at,name)
+// @Param filter[id] query int false "Role ID"
+// @Param filter[name] query string false "Name contains"
+// @Param after query string false "Cursor of the next page, from meta.next_cursor"
+// @Success 200 {object} response.ApiResponse{data=[]model.Role,meta=response.Meta}
+// @Failure 400 {object} response.Problem
+// @Failure 401 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Router /roles/all [get]
 func (h *Handler) GetAll(c *gin.Context) {
-	result, err := h.Deps.Service.GetAll()
+	params, err := query.Parse(c)
+	if err != nil {
+		response.Error(c, err, "invalid list parameters")
+		return
+	}
+
+	result, err := h.Deps.Service.GetAll(params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not fetch roles"})
+		response.Error(c, err, "could not fetch roles")
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	response.Page(c, result.Rows, query.Meta(c, params, result))
 }
 
 // Create godoc
@@ -49,30 +62,32 @@ func (h *Handler) GetAll(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        body  body      dto.RoleRequest  true  "Roles create payload"
-// @Success      201   {object}  dto.RoleResponse
-// @Failure      400   {object}  map[string]string
-// @Failure      500   {object}  map[string]string
+// @Success      201   {object}  response.ApiResponse{data=dto.RoleData}
+// @Failure      400   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
 // @Router       /roles/create [post]
 func (h *Handler) Create(c *gin.Context) {
 	var rr dto.RoleRequest
 	if err := c.ShouldBindJSON(&rr); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": utilrequest.ValidateRequest(err)})
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
 		return
 	}
 
-	by, err := util.GetClaimsID(c)
+	actor, err := util.GetActor(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid claims"})
+		response.Error(c, err, "invalid claims")
 		return
 	}
-	rr.By = by
+	rr.By = actor.UserID
 
-	if err := h.Deps.Service.Create(&rr); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not create role"})
+	resp, err := h.Deps.Service.Create(&rr, actor)
+	if err != nil {
+		response.Error(c, err, "could not create role")
 		return
 	}
 
-	c.JSON(http.StatusCreated, rr)
+	response.Created(c, resp.Data)
 }
 
 // Update godoc
@@ -84,34 +99,37 @@ func (h *Handler) Create(c *gin.Context) {
 // @Produce      json
 // @Param        id    path      int             true  "Role ID"
 // @Param        body  body      dto.RoleRequest  true  "Roles update payload"
-// @Success      201   {object}  dto.RoleResponse
-// @Failure      400   {object}  map[string]string
-// @Failure      500   {object}  map[string]string
+// @Success      200   {object}  response.ApiResponse{data=dto.RoleData}
+// @Failure      400   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
 // @Router       /roles/update/{id} [put]
 func (h *Handler) Update(c *gin.Context) {
 	id := c.Param("id")
 
 	var rr dto.RoleRequest
 	if err := c.ShouldBindJSON(&rr); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": utilrequest.ValidateRequest(err)})
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
 		return
 	}
 
-	by, err := util.GetClaimsID(c)
+	actor, err := util.GetActor(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid claims"})
+		response.Error(c, err, "invalid claims")
 		return
 	}
-	rr.By = by
+	rr.By = actor.UserID
 
 	idInt := utils.ConvertStringToInt(id)
 	rr.ID = idInt
 
-	if err := h.Deps.Service.Update(&rr); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not update role"})
+	resp, err := h.Deps.Service.Update(&rr, actor)
+	if err != nil {
+		response.Error(c, err, "could not update role")
 		return
 	}
-	c.JSON(http.StatusOK, rr)
+	response.OK(c, resp.Data)
 }
 
 // Delete godoc
@@ -122,21 +140,25 @@ func (h *Handler) Update(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id    path      int             true  "Role ID"
-// @Success      201   {object}  dto.RoleResponse
-// @Failure      400   {object}  map[string]string
-// @Failure      500   {object}  map[string]string
+// @Success      200   {object}  response.ApiResponse
+// @Failure      404   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
 // @Router       /roles/delete/{id} [delete]
 func (h *Handler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
-	rr := dto.RoleResponse{}
-
 	idInt := utils.ConvertStringToInt(id)
 
-	if err := h.Deps.Service.Delete(idInt); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not delete role"})
+	actor, err := util.GetActor(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+
+	if err := h.Deps.Service.Delete(idInt, actor); err != nil {
+		response.Error(c, err, "could not delete role")
 		return
 	}
-	rr.Message = "Roles has been deleted."
-	c.JSON(http.StatusOK, rr)
+	response.Message(c, "Roles has been deleted.")
 }
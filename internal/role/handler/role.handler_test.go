@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	authmodel "github.com/dwilanang/psp/internal/auth/model"
+	"github.com/dwilanang/psp/internal/role"
+	"github.com/dwilanang/psp/internal/role/dto"
+	"github.com/dwilanang/psp/internal/role/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveJSON(handler gin.HandlerFunc, method string, body string, params ...gin.Param) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	c.Set("user", &authmodel.TokenClaims{ID: 1})
+
+	handler(c)
+	return w
+}
+
+func TestHandler_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockService(ctrl)
+	svc.EXPECT().
+		Create(&dto.RoleRequest{Name: "Manager", Privilege: "users:create", By: 1}, gomock.Any()).
+		Return(dto.RoleResponse{Data: dto.RoleData{ID: 5, Name: "Manager", Privilege: "users:create"}}, nil)
+
+	w := serveJSON(NewHandler(role.Dependencies{Service: svc}).Create, http.MethodPost, `{"name": "Manager", "privilege": "users:create"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{
+		"status": true,
+		"message": "",
+		"data": {"id": 5, "name": "Manager", "privilege": "users:create"}
+	}`, w.Body.String())
+}
+
+func TestHandler_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockService(ctrl)
+	svc.EXPECT().
+		Update(&dto.RoleRequest{ID: 5, Name: "Manager", Privilege: "roles:*", By: 1}, gomock.Any()).
+		Return(dto.RoleResponse{Data: dto.RoleData{ID: 5, Name: "Manager", Privilege: "roles:*"}}, nil)
+
+	w := serveJSON(NewHandler(role.Dependencies{Service: svc}).Update, http.MethodPut, `{"name": "Manager", "privilege": "roles:*"}`,
+		gin.Param{Key: "id", Value: "5"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"status": true,
+		"message": "",
+		"data": {"id": 5, "name": "Manager", "privilege": "roles:*"}
+	}`, w.Body.String())
+}
@@ -7,7 +7,10 @@ package mocks
 import (
 	reflect "reflect"
 
+	postgres "github.com/dwilanang/psp/infrastructure/db/postgres"
 	model "github.com/dwilanang/psp/internal/role/model"
+	repository "github.com/dwilanang/psp/internal/role/repository"
+	query "github.com/dwilanang/psp/utils/query"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -63,18 +66,18 @@ func (mr *MockRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
 }
 
 // Fetch mocks base method.
-func (m *MockRepository) Fetch() ([]*model.Role, error) {
+func (m *MockRepository) Fetch(params query.Params) (query.Result[*model.Role], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Fetch")
-	ret0, _ := ret[0].([]*model.Role)
+	ret := m.ctrl.Call(m, "Fetch", params)
+	ret0, _ := ret[0].(query.Result[*model.Role])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Fetch indicates an expected call of Fetch.
-func (mr *MockRepositoryMockRecorder) Fetch() *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Fetch(params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockRepository)(nil).Fetch))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockRepository)(nil).Fetch), params)
 }
 
 // FindByID mocks base method.
@@ -92,6 +95,21 @@ func (mr *MockRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), id)
 }
 
+// FindByName mocks base method.
+func (m *MockRepository) FindByName(name string) (*model.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", name)
+	ret0, _ := ret[0].(*model.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockRepositoryMockRecorder) FindByName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockRepository)(nil).FindByName), name)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(role *model.Role) error {
 	m.ctrl.T.Helper()
@@ -105,3 +123,17 @@ func (mr *MockRepositoryMockRecorder) Update(role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), role)
 }
+
+// WithTx mocks base method.
+func (m *MockRepository) WithTx(tx postgres.Executor) repository.Repository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.Repository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockRepositoryMockRecorder) WithTx(tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockRepository)(nil).WithTx), tx)
+}
@@ -1,15 +1,26 @@
 package repository
 
-import "github.com/dwilanang/psp/internal/role/model"
+import (
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/role/model"
+	"github.com/dwilanang/psp/utils/query"
+)
 
 //go:generate mockgen -source=role.repository.go -package=mocks -destination=mocks/mock_role_repository.go
 
 // Repository defines an interface for data operations related to the Role entity.
 // It provides methods for basic CRUD operations and fetching by ID.
 type Repository interface {
-	// Fetch retrieves all available role records from the data store.
-	// Returns a slice of Role pointers and an error if the operation fails.
-	Fetch() ([]*model.Role, error)
+	// WithTx returns a repository running its statements in tx, e.g. one begun by postgres.Transactor.Within.
+	// Param: tx - the transaction to bind to.
+	// Returns a Repository bound to tx; the receiver is left unchanged.
+	WithTx(tx postgres.Executor) Repository
+
+	// Fetch retrieves a page of role records from the data store, sorted and filtered as params ask.
+	// Param: params - the page, sort and filters, checked against the fields roles may be listed by.
+	// Returns the page with the total number of matching roles, a validation error if params sort or
+	// filter on another field, or an error if the operation fails.
+	Fetch(params query.Params) (query.Result[*model.Role], error)
 
 	// Create inserts a new role record into the data store.
 	// Param: role - a pointer to the Role entity to be created.
@@ -30,4 +41,9 @@ type Repository interface {
 	// Param: id - the ID of the Role to retrieve.
 	// Returns a pointer to the Role and an error if the operation fails or the record is not found.
 	FindByID(id int64) (*model.Role, error)
+
+	// FindByName retrieves a role record by its unique name.
+	// Param: name - the name of the Role to retrieve.
+	// Returns a pointer to the Role and an error if the operation fails or the record is not found.
+	FindByName(name string) (*model.Role, error)
 }
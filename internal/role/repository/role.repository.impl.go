@@ -1,42 +1,86 @@
 package repository
 
 import (
-	"fmt"
+	"database/sql"
 
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
 	"github.com/dwilanang/psp/internal/role/model"
-	"github.com/jmoiron/sqlx"
+	"github.com/dwilanang/psp/utils/query"
 )
 
 type repository struct {
-	db *sqlx.DB
+	db *postgres.DB
+	q  postgres.Executor // q is the master, or the transaction the repository is bound to by WithTx.
 }
 
-func NewRepository(db *sqlx.DB) *repository {
-	return &repository{db}
+func NewRepository(db *postgres.DB) *repository {
+	return &repository{db: db, q: db.DB}
 }
 
-func (r *repository) Fetch() ([]*model.Role, error) {
-	var roles []*model.Role
-	query := `
+func (r *repository) WithTx(tx postgres.Executor) Repository {
+	return &repository{db: r.db, q: tx}
+}
+
+// roleQuery whitelists what role listings may be sorted and filtered on.
+var roleQuery = query.Schema[*model.Role]{
+	Key: "id",
+	Fields: map[string]query.Field[*model.Role]{
+		"id": {
+			Column: "rs.id", Sortable: true, Filter: query.Equal,
+			Value: func(role *model.Role) any { return role.ID },
+		},
+		"name": {
+			Column: "rs.name", Sortable: true, Filter: query.Contains,
+			Value: func(role *model.Role) any { return role.Name },
+		},
+		"created_at": {
+			Column: "date_trunc('second', rs.created_at)", Sortable: true,
+			Value: func(role *model.Role) any { return role.CreatedAt },
+		},
+	},
+}
+
+func (r *repository) Fetch(params query.Params) (query.Result[*model.Role], error) {
+	q, err := roleQuery.SQL(params)
+	if err != nil {
+		return query.Result[*model.Role]{}, err
+	}
+
+	// Count and page on the same replica so the total matches the page.
+	replica := r.db.Reader(r.q)
+
+	var total int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM roles rs
+		WHERE ` + q.Filter
+	if err := replica.Get(&total, countQuery, q.FilterArgs...); err != nil {
+		return query.Result[*model.Role]{}, postgres.WrapError(err, "role")
+	}
+
+	// Roles seeded at install time have no creator, so the users are joined optionally.
+	roles := []*model.Role{}
+	listQuery := `
 		SELECT 
 			rs.id, rs.name, 
 			COALESCE(rs.privilege, '') AS privilege,
-			rs.created_by,
-			us1.full_name as created_by_name,
-			rs.updated_by,
-			us2.full_name as updated_by_name,
+			COALESCE(rs.created_by, 0) AS created_by,
+			COALESCE(us1.full_name, '') AS created_by_name,
+			COALESCE(rs.updated_by, 0) AS updated_by,
+			COALESCE(us2.full_name, '') AS updated_by_name,
 			TO_CHAR(rs.created_at, 'YYYY-MM-DD HH24:MI:SS') AS created_at,
 			TO_CHAR(rs.created_at, 'YYYY-MM-DD HH24:MI:SS') AS updated_at
 		FROM roles rs
-		INNER JOIN users us1 ON(us1.id=rs.created_by)
-		INNER JOIN users us2 ON(us2.id=rs.updated_by)
-	`
-	err := r.db.Select(&roles, query)
+		LEFT JOIN users us1 ON(us1.id=rs.created_by)
+		LEFT JOIN users us2 ON(us2.id=rs.updated_by)
+		WHERE ` + q.Where + `
+		` + q.OrderBy + `
+		` + q.Limit
+	err = replica.Select(&roles, listQuery, q.Args...)
 	if err != nil {
-		fmt.Println("Fetch: ", err)
-		return nil, err
+		return query.Result[*model.Role]{}, postgres.WrapError(err, "role")
 	}
-	return roles, nil
+	return roleQuery.Result(params, roles, total)
 }
 
 func (r *repository) FindByID(id int64) (*model.Role, error) {
@@ -45,20 +89,34 @@ func (r *repository) FindByID(id int64) (*model.Role, error) {
 		SELECT 
 			rs.id, rs.name, 
 			COALESCE(rs.privilege, '') AS privilege,
-			rs.created_by,
-			us1.full_name as created_by_name,
-			rs.updated_by,
-			us2.full_name as updated_by_name,
+			COALESCE(rs.created_by, 0) AS created_by,
+			COALESCE(us1.full_name, '') AS created_by_name,
+			COALESCE(rs.updated_by, 0) AS updated_by,
+			COALESCE(us2.full_name, '') AS updated_by_name,
 			TO_CHAR(rs.created_at, 'YYYY-MM-DD HH24:MI:SS') AS created_at,
 			TO_CHAR(rs.created_at, 'YYYY-MM-DD HH24:MI:SS') AS updated_at
 		FROM roles rs
-		INNER JOIN users us1 ON(us1.id=rs.created_by)
-		INNER JOIN users us2 ON(us2.id=rs.updated_by)
+		LEFT JOIN users us1 ON(us1.id=rs.created_by)
+		LEFT JOIN users us2 ON(us2.id=rs.updated_by)
 		WHERE rs.id = $1
 	`
-	err := r.db.Get(&role, query, id)
+	err := r.q.Get(&role, query, id)
 	if err != nil {
-		return nil, err
+		return nil, postgres.WrapError(err, "role")
+	}
+	return &role, nil
+}
+
+func (r *repository) FindByName(name string) (*model.Role, error) {
+	var role model.Role
+	query := `
+		SELECT id, name, COALESCE(privilege, '') AS privilege
+		FROM roles
+		WHERE name = $1
+	`
+	err := r.q.Get(&role, query, name)
+	if err != nil {
+		return nil, postgres.WrapError(err, "role")
 	}
 	return &role, nil
 }
@@ -69,14 +127,14 @@ func (r *repository) Create(role *model.Role) error {
 		RETURNING id, created_at
 	`
 
-	err := r.db.QueryRowx(
+	err := r.q.QueryRowx(
 		query,
 		role.Name,
 		role.Privilege,
 		role.CreatedBy,
 	).Scan(&role.ID, &role.CreatedAt)
 
-	return err
+	return postgres.WrapError(err, "role")
 }
 
 func (r *repository) Update(role *model.Role) error {
@@ -84,23 +142,35 @@ func (r *repository) Update(role *model.Role) error {
 		UPDATE roles SET name = $1, privilege = $2, updated_by = $3, updated_at = NOW() WHERE id = $4
 		RETURNING id, created_at
 	`
-	return r.db.QueryRowx(
+	err := r.q.QueryRowx(
 		query,
 		role.Name,
 		role.Privilege,
 		role.UpdatedBy,
 		role.ID,
 	).Scan(&role.ID, &role.CreatedAt)
+
+	return postgres.WrapError(err, "role")
 }
 
 func (r *repository) Delete(id int64) error {
 	query := `
 		DELETE FROM roles WHERE id = $1
 	`
-	_, err := r.db.Exec(
+	result, err := r.q.Exec(
 		query,
 		id,
 	)
+	if err != nil {
+		return postgres.WrapError(err, "role")
+	}
 
-	return err
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return postgres.WrapError(sql.ErrNoRows, "role")
+	}
+	return nil
 }
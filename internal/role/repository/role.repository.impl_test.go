@@ -6,49 +6,100 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
 	"github.com/dwilanang/psp/internal/role/model"
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/dwilanang/psp/utils/query"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 )
 
-func setupDBMock(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+func setupDBMock(t *testing.T) (*postgres.DB, sqlmock.Sqlmock) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
-	return sqlx.NewDb(db, "postgres"), mock
+	return postgres.NewDB(sqlx.NewDb(db, "postgres")), mock
 }
 
 func TestRepository_Fetch(t *testing.T) {
 	db, mock := setupDBMock(t)
 	defer db.Close()
 
-	query := regexp.QuoteMeta(`
+	countQuery := regexp.QuoteMeta(`
+		SELECT COUNT(*)
+		FROM roles rs
+		WHERE rs.name ILIKE $1`)
+	listQuery := regexp.QuoteMeta(`
 		SELECT 
 			rs.id, rs.name, 
 			COALESCE(rs.privilege, '') AS privilege,
-			rs.created_by,
-			us1.full_name as created_by_name,
-			rs.updated_by,
-			us2.full_name as updated_by_name,
+			COALESCE(rs.created_by, 0) AS created_by,
+			COALESCE(us1.full_name, '') AS created_by_name,
+			COALESCE(rs.updated_by, 0) AS updated_by,
+			COALESCE(us2.full_name, '') AS updated_by_name,
 			TO_CHAR(rs.created_at, 'YYYY-MM-DD HH24:MI:SS') AS created_at,
 			TO_CHAR(rs.created_at, 'YYYY-MM-DD HH24:MI:SS') AS updated_at
 		FROM roles rs
-		INNER JOIN users us1 ON(us1.id=rs.created_by)
-		INNER JOIN users us2 ON(us2.id=rs.updated_by)
-	`)
+		LEFT JOIN users us1 ON(us1.id=rs.created_by)
+		LEFT JOIN users us2 ON(us2.id=rs.updated_by)
+		WHERE rs.name ILIKE $1
+		ORDER BY rs.name DESC, rs.id
+		LIMIT $2 OFFSET $3`)
 
 	rows := sqlmock.NewRows([]string{
 		"id", "name", "privilege", "created_by", "created_by_name",
 		"updated_by", "updated_by_name", "created_at", "updated_at",
-	}).AddRow(1, "Admin", "all", 1, "Super Admin", 1, "Super Admin", "2024-01-01 00:00:00", "2024-01-01 00:00:00")
+	}).
+		AddRow(2, "Admin", "all", 1, "Super Admin", 1, "Super Admin", "2024-01-01 00:00:00", "2024-01-01 00:00:00").
+		AddRow(1, "Admin", "all", 1, "Super Admin", 1, "Super Admin", "2024-01-01 00:00:00", "2024-01-01 00:00:00")
 
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectQuery(countQuery).WithArgs("%adm%").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(listQuery).WithArgs("%adm%", 2, 0).WillReturnRows(rows)
 
 	repo := NewRepository(db)
-	result, err := repo.Fetch()
+	result, err := repo.Fetch(query.Params{
+		Page:    1,
+		PerPage: 1,
+		Sort:    []query.Sort{{Field: "name", Desc: true}},
+		Filters: []query.Filter{{Field: "name", Value: "adm"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Rows, 1)
+	assert.Equal(t, "Admin", result.Rows[0].Name)
+	assert.Equal(t, int64(3), result.Total)
+	assert.NotEmpty(t, result.Next)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_Fetch_RejectsUnknownSort(t *testing.T) {
+	db, mock := setupDBMock(t)
+	defer db.Close()
+
+	repo := NewRepository(db)
+	_, err := repo.Fetch(query.Params{Page: 1, PerPage: 20, Sort: []query.Sort{{Field: "privilege"}}})
+
+	assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_Fetch_ReadsFromReplica(t *testing.T) {
+	master, masterMock := setupDBMock(t)
+	defer master.Close()
+	replica, replicaMock := setupDBMock(t)
+	defer replica.Close()
+
+	replicaMock.ExpectQuery("SELECT COUNT").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	replicaMock.ExpectQuery("FROM roles rs").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Admin"))
+
+	repo := NewRepository(postgres.NewDB(master.DB, replica.DB))
+	result, err := repo.Fetch(query.Params{Page: 1, PerPage: 20})
 
 	assert.NoError(t, err)
-	assert.Len(t, result, 1)
-	assert.Equal(t, "Admin", result[0].Name)
+	assert.Len(t, result.Rows, 1)
+	assert.NoError(t, replicaMock.ExpectationsWereMet())
+	assert.NoError(t, masterMock.ExpectationsWereMet())
 }
 
 func TestRepository_FindByID(t *testing.T) {
@@ -59,15 +110,15 @@ func TestRepository_FindByID(t *testing.T) {
 		SELECT 
 			rs.id, rs.name, 
 			COALESCE(rs.privilege, '') AS privilege,
-			rs.created_by,
-			us1.full_name as created_by_name,
-			rs.updated_by,
-			us2.full_name as updated_by_name,
+			COALESCE(rs.created_by, 0) AS created_by,
+			COALESCE(us1.full_name, '') AS created_by_name,
+			COALESCE(rs.updated_by, 0) AS updated_by,
+			COALESCE(us2.full_name, '') AS updated_by_name,
 			TO_CHAR(rs.created_at, 'YYYY-MM-DD HH24:MI:SS') AS created_at,
 			TO_CHAR(rs.created_at, 'YYYY-MM-DD HH24:MI:SS') AS updated_at
 		FROM roles rs
-		INNER JOIN users us1 ON(us1.id=rs.created_by)
-		INNER JOIN users us2 ON(us2.id=rs.updated_by)
+		LEFT JOIN users us1 ON(us1.id=rs.created_by)
+		LEFT JOIN users us2 ON(us2.id=rs.updated_by)
 		WHERE rs.id = $1
 	`)
 
@@ -85,6 +136,27 @@ func TestRepository_FindByID(t *testing.T) {
 	assert.Equal(t, "Admin", result.Name)
 }
 
+func TestRepository_FindByName(t *testing.T) {
+	db, mock := setupDBMock(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`
+		SELECT id, name, COALESCE(privilege, '') AS privilege
+		FROM roles
+		WHERE name = $1
+	`)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "privilege"}).AddRow(1, "ADMIN", "users:create")
+
+	mock.ExpectQuery(query).WithArgs("ADMIN").WillReturnRows(rows)
+
+	repo := NewRepository(db)
+	result, err := repo.FindByName("ADMIN")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "users:create", result.Privilege)
+}
+
 func TestRepository_Create(t *testing.T) {
 	db, mock := setupDBMock(t)
 	defer db.Close()
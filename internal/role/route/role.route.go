@@ -1,7 +1,7 @@
 package route
 
 import (
-	"github.com/dwilanang/psp/internal/middleware"
+	"github.com/dwilanang/psp/internal/permission"
 	"github.com/dwilanang/psp/internal/registry"
 	"github.com/gin-gonic/gin"
 )
@@ -11,10 +11,9 @@ func RegisterRoutes(rg *gin.RouterGroup, registry *registry.Registry) {
 
 	rolesGroup := rg.Group("/roles")
 	{
-		rolesGroup.Use(middleware.RequireRole("SUPERADMIN"))
-		rolesGroup.GET("/all", h.GetAll)
-		rolesGroup.POST("/create", h.Create)
-		rolesGroup.PUT("/update/:id", h.Update)
-		rolesGroup.DELETE("/delete/:id", h.Delete)
+		rolesGroup.GET("/all", registry.RequirePermission(permission.RolesRead), h.GetAll)
+		rolesGroup.POST("/create", registry.RequirePermission(permission.RolesCreate), h.Create)
+		rolesGroup.PUT("/update/:id", registry.RequirePermission(permission.RolesUpdate), h.Update)
+		rolesGroup.DELETE("/delete/:id", registry.RequirePermission(permission.RolesDelete), h.Delete)
 	}
 }
@@ -7,7 +7,10 @@ package mocks
 import (
 	reflect "reflect"
 
+	model "github.com/dwilanang/psp/internal/audit/model"
 	dto "github.com/dwilanang/psp/internal/role/dto"
+	model0 "github.com/dwilanang/psp/internal/role/model"
+	query "github.com/dwilanang/psp/utils/query"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -35,58 +38,60 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockService) Create(request *dto.RoleRequest) error {
+func (m *MockService) Create(request *dto.RoleRequest, actor model.Actor) (dto.RoleResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", request)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Create", request, actor)
+	ret0, _ := ret[0].(dto.RoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockServiceMockRecorder) Create(request interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) Create(request, actor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), request, actor)
 }
 
 // Delete mocks base method.
-func (m *MockService) Delete(id int64) error {
+func (m *MockService) Delete(id int64, actor model.Actor) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", id, actor)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockServiceMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) Delete(id, actor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), id, actor)
 }
 
 // GetAll mocks base method.
-func (m *MockService) GetAll() (dto.RoleResponse, error) {
+func (m *MockService) GetAll(params query.Params) (query.Result[*model0.Role], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
-	ret0, _ := ret[0].(dto.RoleResponse)
+	ret := m.ctrl.Call(m, "GetAll", params)
+	ret0, _ := ret[0].(query.Result[*model0.Role])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockServiceMockRecorder) GetAll() *gomock.Call {
+func (mr *MockServiceMockRecorder) GetAll(params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), params)
 }
 
 // Update mocks base method.
-func (m *MockService) Update(request *dto.RoleRequest) error {
+func (m *MockService) Update(request *dto.RoleRequest, actor model.Actor) (dto.RoleResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", request)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Update", request, actor)
+	ret0, _ := ret[0].(dto.RoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockServiceMockRecorder) Update(request interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) Update(request, actor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockService)(nil).Update), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockService)(nil).Update), request, actor)
 }
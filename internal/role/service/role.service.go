@@ -1,30 +1,37 @@
 package service
 
 import (
+	auditmodel "github.com/dwilanang/psp/internal/audit/model"
 	"github.com/dwilanang/psp/internal/role/dto"
+	"github.com/dwilanang/psp/internal/role/model"
+	"github.com/dwilanang/psp/utils/query"
 )
 
-//go:generate mockgen -source=role.service.go -package=rolemock -destination=../../rolemock/mock_role_service.go
+//go:generate mockgen -source=role.service.go -package=rolemock -destination=mocks/mock_role_service.go
 
 // Service defines the interface for business logic related to the Role entity.
 // It provides methods to retrieve, create, update, and delete roles.
 type Service interface {
-	// GetAll retrieves all roles and returns them in a structured response format.
-	// Returns a RoleResponse DTO and an error if the operation fails.
-	GetAll() (dto.RoleResponse, error)
+	// GetAll retrieves a page of roles, sorted and filtered as params ask.
+	// Param: params - the page, sort and filters parsed from the request.
+	// Returns the page with the total number of matching roles, and an error if the operation fails.
+	GetAll(params query.Params) (query.Result[*model.Role], error)
 
 	// Create adds a new role based on the provided request data.
-	// Param: request - a pointer to RoleRequest DTO containing role details.
-	// Returns an error if the creation fails.
-	Create(request *dto.RoleRequest) error
+	// Param: request - a pointer to RoleRequest DTO containing role details; actor - who creates the role,
+	// recorded in the audit log with it.
+	// Returns the created role and an error if the creation fails; nothing is saved when it fails.
+	Create(request *dto.RoleRequest, actor auditmodel.Actor) (dto.RoleResponse, error)
 
 	// Update modifies an existing role using the provided request data.
-	// Param: request - a pointer to RoleRequest DTO with updated role information.
-	// Returns an error if the update fails.
-	Update(request *dto.RoleRequest) error
+	// Param: request - a pointer to RoleRequest DTO with updated role information; actor - who updates the
+	// role, recorded in the audit log with the fields that changed.
+	// Returns the updated role and an error if the update fails; nothing is saved when it fails.
+	Update(request *dto.RoleRequest, actor auditmodel.Actor) (dto.RoleResponse, error)
 
 	// Delete removes a role identified by the given ID.
-	// Param: id - the ID of the role to be deleted.
-	// Returns an error if the deletion fails.
-	Delete(id int64) error
+	// Param: id - the ID of the role to be deleted; actor - who deletes the role, recorded in the audit log
+	// with its last state.
+	// Returns an error if the deletion fails; nothing is saved when it fails.
+	Delete(id int64, actor auditmodel.Actor) error
 }
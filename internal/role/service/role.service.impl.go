@@ -3,34 +3,53 @@ package service
 import (
 	"fmt"
 
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	auditmodel "github.com/dwilanang/psp/internal/audit/model"
+	auditrepository "github.com/dwilanang/psp/internal/audit/repository"
+	"github.com/dwilanang/psp/internal/permission"
 	"github.com/dwilanang/psp/internal/role/dto"
 	"github.com/dwilanang/psp/internal/role/model"
 	"github.com/dwilanang/psp/internal/role/repository"
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/dwilanang/psp/utils/query"
 )
 
+// ErrInvalidPrivilege is returned when a role privilege contains an entry that is not a valid permission.
+var ErrInvalidPrivilege = apperror.Validation("invalid privilege")
+
+// PermissionCache is notified whenever a role's privileges may have changed.
+type PermissionCache interface {
+	Invalidate()
+}
+
 type service struct {
-	repo repository.Repository
+	tx          postgres.Transactor
+	repo        repository.Repository
+	audits      auditrepository.Repository
+	permissions PermissionCache
 }
 
-func NewService(r repository.Repository) *service {
-	return &service{repo: r}
+// NewService creates the role service. Every change is written in one transaction of tx together with its
+// audit log entry. permissions may be nil when no permission cache needs invalidating.
+func NewService(tx postgres.Transactor, r repository.Repository, audits auditrepository.Repository, permissions PermissionCache) *service {
+	return &service{tx: tx, repo: r, audits: audits, permissions: permissions}
 }
 
 // GetAll implements the Service interface.
-func (s *service) GetAll() (dto.RoleResponse, error) {
-	result, err := s.repo.Fetch()
+func (s *service) GetAll(params query.Params) (query.Result[*model.Role], error) {
+	result, err := s.repo.Fetch(params)
 	if err != nil {
-		fmt.Println("s.repo.GetAll() error: ", err)
-		return dto.RoleResponse{}, err
+		return query.Result[*model.Role]{}, err
 	}
 
-	return dto.RoleResponse{
-		Data: result,
-	}, err
+	return result, nil
 }
 
 // Create implements the Service interface.
-func (s *service) Create(request *dto.RoleRequest) error {
+func (s *service) Create(request *dto.RoleRequest, actor auditmodel.Actor) (dto.RoleResponse, error) {
+	if _, err := permission.Parse(request.Privilege); err != nil {
+		return dto.RoleResponse{}, fmt.Errorf("%w: %v", ErrInvalidPrivilege, err)
+	}
 
 	role := &model.Role{
 		Name:      request.Name,
@@ -38,16 +57,27 @@ func (s *service) Create(request *dto.RoleRequest) error {
 		CreatedBy: request.By,
 	}
 
-	err := s.repo.Create(role)
+	err := s.tx.Within(func(tx postgres.Executor) error {
+		err := s.repo.WithTx(tx).Create(role)
+		if err != nil {
+			return err
+		}
+
+		return s.record(tx, auditmodel.NewEntry(actor, auditmodel.EntityRole, role.ID, auditmodel.ActionCreate, nil, snapshot(role)))
+	})
 	if err != nil {
-		fmt.Println("s.repo.Create() error: ", err)
+		return dto.RoleResponse{}, err
 	}
 
-	return err
+	return dto.RoleResponse{Data: toData(role)}, nil
 }
 
 // Update implements the Service interface.
-func (s *service) Update(request *dto.RoleRequest) error {
+func (s *service) Update(request *dto.RoleRequest, actor auditmodel.Actor) (dto.RoleResponse, error) {
+	if _, err := permission.Parse(request.Privilege); err != nil {
+		return dto.RoleResponse{}, fmt.Errorf("%w: %v", ErrInvalidPrivilege, err)
+	}
+
 	role := &model.Role{
 		ID:        request.ID,
 		Name:      request.Name,
@@ -55,21 +85,76 @@ func (s *service) Update(request *dto.RoleRequest) error {
 		UpdatedBy: request.By,
 	}
 
-	err := s.repo.Update(role)
+	err := s.tx.Within(func(tx postgres.Executor) error {
+		roles := s.repo.WithTx(tx)
+		before, err := roles.FindByID(role.ID)
+		if err != nil {
+			return err
+		}
+
+		err = roles.Update(role)
+		if err != nil {
+			return err
+		}
+
+		return s.record(tx, auditmodel.NewEntry(actor, auditmodel.EntityRole, role.ID, auditmodel.ActionUpdate, snapshot(before), snapshot(role)))
+	})
 	if err != nil {
-		fmt.Println("s.repo.Update() error: ", err)
+		return dto.RoleResponse{}, err
 	}
 
-	return err
+	s.invalidate()
+	return dto.RoleResponse{Data: toData(role)}, nil
 }
 
 // Delete implements the Service interface.
-func (s *service) Delete(id int64) error {
-
-	err := s.repo.Delete(id)
+func (s *service) Delete(id int64, actor auditmodel.Actor) error {
+	err := s.tx.Within(func(tx postgres.Executor) error {
+		roles := s.repo.WithTx(tx)
+		before, err := roles.FindByID(id)
+		if err != nil {
+			return err
+		}
+
+		err = roles.Delete(id)
+		if err != nil {
+			return err
+		}
+
+		return s.record(tx, auditmodel.NewEntry(actor, auditmodel.EntityRole, id, auditmodel.ActionDelete, snapshot(before), nil))
+	})
 	if err != nil {
-		fmt.Println("s.repo.Delete() error: ", err)
+		return err
+	}
+
+	s.invalidate()
+	return nil
+}
+
+// record appends entry to the audit log in tx.
+func (s *service) record(tx postgres.Executor, entry *auditmodel.Entry) error {
+	return s.audits.WithTx(tx).Create(entry)
+}
+
+// snapshot returns the fields of a role recorded in the audit log.
+func snapshot(role *model.Role) auditmodel.Snapshot {
+	return auditmodel.Snapshot{
+		"name":      role.Name,
+		"privilege": role.Privilege,
+	}
+}
+
+// toData maps a role to the fields the API returns.
+func toData(role *model.Role) dto.RoleData {
+	return dto.RoleData{
+		ID:        role.ID,
+		Name:      role.Name,
+		Privilege: role.Privilege,
 	}
+}
 
-	return err
+func (s *service) invalidate() {
+	if s.permissions != nil {
+		s.permissions.Invalidate()
+	}
 }
@@ -4,45 +4,74 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	auditmodel "github.com/dwilanang/psp/internal/audit/model"
+	mockaudit "github.com/dwilanang/psp/internal/audit/repository/mocks"
 	"github.com/dwilanang/psp/internal/role/dto"
 	"github.com/dwilanang/psp/internal/role/model"
 	mockrepo "github.com/dwilanang/psp/internal/role/repository/mocks"
+	"github.com/dwilanang/psp/utils/query"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
+var actor = auditmodel.Actor{UserID: 1, IPAddress: "10.0.0.1", RequestID: "req-1"}
+
+// fakeTransactor runs the unit of work without a database and records whether it would be committed.
+type fakeTransactor struct {
+	committed  int
+	rolledBack int
+}
+
+func (f *fakeTransactor) Within(fn func(tx postgres.Executor) error) error {
+	if err := fn(nil); err != nil {
+		f.rolledBack++
+		return err
+	}
+	f.committed++
+	return nil
+}
+
+// newTestService returns the service with mocked repositories bound to any transaction.
+func newTestService(ctrl *gomock.Controller, permissions PermissionCache) (*service, *mockrepo.MockRepository, *mockaudit.MockRepository, *fakeTransactor) {
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).AnyTimes()
+	mockAudits := mockaudit.NewMockRepository(ctrl)
+	mockAudits.EXPECT().WithTx(gomock.Any()).Return(mockAudits).AnyTimes()
+	tx := &fakeTransactor{}
+	return NewService(tx, mockRepo, mockAudits, permissions), mockRepo, mockAudits, tx
+}
+
 func TestService_GetAll(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mockrepo.NewMockRepository(ctrl)
-	svc := NewService(mockRepo)
+	svc, mockRepo, _, _ := newTestService(ctrl, nil)
 
 	expected := []*model.Role{
 		{ID: 1, Name: "Admin", Privilege: "all"},
 		{ID: 2, Name: "User", Privilege: "read"},
 	}
 
-	mockRepo.EXPECT().Fetch().Return(expected, nil)
-
-	resp, err := svc.GetAll()
+	params := query.Params{Page: 1, PerPage: 20}
+	mockRepo.EXPECT().Fetch(params).Return(query.Result[*model.Role]{Rows: expected, Total: 2}, nil)
 
-	data := resp.Data.([]*model.Role)
+	resp, err := svc.GetAll(params)
 
 	assert.NoError(t, err)
-	assert.Equal(t, len(expected), len(data))
+	assert.Equal(t, len(expected), len(resp.Rows))
+	assert.Equal(t, int64(2), resp.Total)
 }
 
 func TestService_GetAll_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mockrepo.NewMockRepository(ctrl)
-	svc := NewService(mockRepo)
+	svc, mockRepo, _, _ := newTestService(ctrl, nil)
 
-	mockRepo.EXPECT().Fetch().Return(nil, errors.New("fetch error"))
+	mockRepo.EXPECT().Fetch(gomock.Any()).Return(query.Result[*model.Role]{}, errors.New("fetch error"))
 
-	_, err := svc.GetAll()
+	_, err := svc.GetAll(query.Params{Page: 1, PerPage: 20})
 	assert.Error(t, err)
 }
 
@@ -50,59 +79,102 @@ func TestService_Create(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mockrepo.NewMockRepository(ctrl)
-	svc := NewService(mockRepo)
-
-	req := &dto.RoleRequest{Name: "Manager", Privilege: "manage", By: int64(1)}
-
-	mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
-
-	err := svc.Create(req)
+	svc, mockRepo, mockAudits, tx := newTestService(ctrl, nil)
+
+	req := &dto.RoleRequest{Name: "Manager", Privilege: "users:create", By: int64(1)}
+
+	mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(role *model.Role) error {
+		role.ID = 5
+		return nil
+	})
+	mockAudits.EXPECT().Create(gomock.Any()).DoAndReturn(func(entry *auditmodel.Entry) error {
+		assert.Equal(t, &auditmodel.Entry{
+			ActorID:   1,
+			IPAddress: "10.0.0.1",
+			RequestID: "req-1",
+			Entity:    auditmodel.EntityRole,
+			EntityID:  "5",
+			Action:    auditmodel.ActionCreate,
+			After:     auditmodel.Snapshot{"name": "Manager", "privilege": "users:create"},
+		}, entry)
+		return nil
+	})
+
+	resp, err := svc.Create(req, actor)
 	assert.NoError(t, err)
+	assert.Equal(t, dto.RoleData{ID: 5, Name: "Manager", Privilege: "users:create"}, resp.Data)
+	assert.Equal(t, 1, tx.committed)
 }
 
 func TestService_Create_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mockrepo.NewMockRepository(ctrl)
-	svc := NewService(mockRepo)
+	svc, mockRepo, _, tx := newTestService(ctrl, nil)
 
-	req := &dto.RoleRequest{Name: "Manager", Privilege: "manage", By: int64(1)}
+	req := &dto.RoleRequest{Name: "Manager", Privilege: "users:create", By: int64(1)}
 
 	mockRepo.EXPECT().Create(gomock.Any()).Return(errors.New("create error"))
 
-	err := svc.Create(req)
+	_, err := svc.Create(req, actor)
+	assert.Error(t, err)
+	assert.Equal(t, 1, tx.rolledBack)
+}
+
+func TestService_Create_AuditErrorRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, mockRepo, mockAudits, tx := newTestService(ctrl, nil)
+
+	req := &dto.RoleRequest{Name: "Manager", Privilege: "users:create", By: int64(1)}
+
+	mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+	mockAudits.EXPECT().Create(gomock.Any()).Return(errors.New("audit error"))
+
+	_, err := svc.Create(req, actor)
 	assert.Error(t, err)
+	assert.Equal(t, 1, tx.rolledBack)
+	assert.Equal(t, 0, tx.committed)
 }
 
 func TestService_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mockrepo.NewMockRepository(ctrl)
-	svc := NewService(mockRepo)
+	svc, mockRepo, mockAudits, tx := newTestService(ctrl, nil)
 
-	req := &dto.RoleRequest{ID: 1, Name: "Updated", Privilege: "edit", By: int64(1)}
+	req := &dto.RoleRequest{ID: 1, Name: "Manager", Privilege: "roles:update", By: int64(1)}
 
+	mockRepo.EXPECT().FindByID(int64(1)).Return(&model.Role{ID: 1, Name: "Manager", Privilege: "roles:read"}, nil)
 	mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
-
-	err := svc.Update(req)
+	mockAudits.EXPECT().Create(gomock.Any()).DoAndReturn(func(entry *auditmodel.Entry) error {
+		// Only the privilege changed, so the name is left out of the diff.
+		assert.Equal(t, auditmodel.ActionUpdate, entry.Action)
+		assert.Equal(t, "1", entry.EntityID)
+		assert.Equal(t, auditmodel.Snapshot{"privilege": "roles:read"}, entry.Before)
+		assert.Equal(t, auditmodel.Snapshot{"privilege": "roles:update"}, entry.After)
+		return nil
+	})
+
+	resp, err := svc.Update(req, actor)
 	assert.NoError(t, err)
+	assert.Equal(t, dto.RoleData{ID: 1, Name: "Manager", Privilege: "roles:update"}, resp.Data)
+	assert.Equal(t, 1, tx.committed)
 }
 
 func TestService_Update_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mockrepo.NewMockRepository(ctrl)
-	svc := NewService(mockRepo)
+	svc, mockRepo, _, _ := newTestService(ctrl, nil)
 
-	req := &dto.RoleRequest{ID: 1, Name: "Updated", Privilege: "edit", By: int64(1)}
+	req := &dto.RoleRequest{ID: 1, Name: "Updated", Privilege: "roles:update", By: int64(1)}
 
+	mockRepo.EXPECT().FindByID(int64(1)).Return(&model.Role{ID: 1}, nil)
 	mockRepo.EXPECT().Update(gomock.Any()).Return(errors.New("update error"))
 
-	err := svc.Update(req)
+	_, err := svc.Update(req, actor)
 	assert.Error(t, err)
 }
 
@@ -110,24 +182,86 @@ func TestService_Delete(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mockrepo.NewMockRepository(ctrl)
-	svc := NewService(mockRepo)
+	svc, mockRepo, mockAudits, tx := newTestService(ctrl, nil)
 
+	mockRepo.EXPECT().FindByID(int64(1)).Return(&model.Role{ID: 1, Name: "Manager", Privilege: "roles:read"}, nil)
 	mockRepo.EXPECT().Delete(int64(1)).Return(nil)
-
-	err := svc.Delete(1)
+	mockAudits.EXPECT().Create(gomock.Any()).DoAndReturn(func(entry *auditmodel.Entry) error {
+		assert.Equal(t, auditmodel.ActionDelete, entry.Action)
+		assert.Equal(t, auditmodel.Snapshot{"name": "Manager", "privilege": "roles:read"}, entry.Before)
+		assert.Nil(t, entry.After)
+		return nil
+	})
+
+	err := svc.Delete(1, actor)
 	assert.NoError(t, err)
+	assert.Equal(t, 1, tx.committed)
 }
 
 func TestService_Delete_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepo := mockrepo.NewMockRepository(ctrl)
-	svc := NewService(mockRepo)
+	svc, mockRepo, _, _ := newTestService(ctrl, nil)
 
+	mockRepo.EXPECT().FindByID(int64(1)).Return(&model.Role{ID: 1}, nil)
 	mockRepo.EXPECT().Delete(int64(1)).Return(errors.New("delete error"))
 
-	err := svc.Delete(1)
+	err := svc.Delete(1, actor)
+	assert.Error(t, err)
+}
+
+type fakePermissionCache struct {
+	invalidated int
+}
+
+func (f *fakePermissionCache) Invalidate() {
+	f.invalidated++
+}
+
+func TestService_Create_InvalidPrivilege(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, _, _, _ := newTestService(ctrl, nil)
+
+	req := &dto.RoleRequest{Name: "Manager", Privilege: "manage everything", By: int64(1)}
+
+	_, err := svc.Create(req, actor)
+	assert.ErrorIs(t, err, ErrInvalidPrivilege)
+}
+
+func TestService_Update_InvalidatesPermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache := &fakePermissionCache{}
+	svc, mockRepo, mockAudits, _ := newTestService(ctrl, cache)
+
+	req := &dto.RoleRequest{ID: 1, Name: "Updated", Privilege: "users:create, roles:*", By: int64(1)}
+
+	mockRepo.EXPECT().FindByID(int64(1)).Return(&model.Role{ID: 1}, nil)
+	mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+	mockAudits.EXPECT().Create(gomock.Any()).Return(nil)
+
+	_, err := svc.Update(req, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cache.invalidated)
+}
+
+func TestService_Update_ErrorKeepsPermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache := &fakePermissionCache{}
+	svc, mockRepo, _, _ := newTestService(ctrl, cache)
+
+	req := &dto.RoleRequest{ID: 1, Name: "Updated", Privilege: "users:create", By: int64(1)}
+
+	mockRepo.EXPECT().FindByID(int64(1)).Return(&model.Role{ID: 1}, nil)
+	mockRepo.EXPECT().Update(gomock.Any()).Return(errors.New("update error"))
+
+	_, err := svc.Update(req, actor)
 	assert.Error(t, err)
+	assert.Equal(t, 0, cache.invalidated)
 }
@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+type SalaryRequest struct {
+	UserID        int64           `json:"user_id" binding:"required"`
+	Amount        decimal.Decimal `json:"amount" binding:"required" swaggertype:"string" example:"5000000.00"`
+	EffectiveFrom string          `json:"effective_from" binding:"required,iso_date" example:"2025-01-01"`
+	Note          string          `json:"note"`
+	By            int64           `json:"by" swaggerignore:"true"`
+}
+
+type SalaryCorrectionRequest struct {
+	ID            int64           `json:"id" swaggerignore:"true"`
+	Amount        decimal.Decimal `json:"amount" binding:"required" swaggertype:"string" example:"5000000.00"`
+	EffectiveFrom string          `json:"effective_from" binding:"required,iso_date" example:"2025-01-01"`
+	EffectiveTo   string          `json:"effective_to" binding:"omitempty,iso_date" example:"2025-06-30"`
+	Note          string          `json:"note"`
+	By            int64           `json:"by" swaggerignore:"true"`
+}
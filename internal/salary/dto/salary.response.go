@@ -0,0 +1,21 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+type SalaryResponse struct {
+	Data SalaryData `json:"data"`
+}
+
+type SalaryListResponse struct {
+	Data []SalaryData `json:"data"`
+}
+
+type SalaryData struct {
+	ID            int64           `json:"id"`
+	UserID        int64           `json:"user_id"`
+	FullName      string          `json:"full_name"`
+	Amount        decimal.Decimal `json:"amount" swaggertype:"string" example:"5000000.00"`
+	EffectiveFrom string          `json:"effective_from" example:"2025-01-01"`
+	EffectiveTo   *string         `json:"effective_to" example:"2025-06-30"`
+	Note          string          `json:"note"`
+}
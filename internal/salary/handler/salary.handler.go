@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/internal/auth/util"
+	"github.com/dwilanang/psp/internal/salary"
+	"github.com/dwilanang/psp/internal/salary/dto"
+	"github.com/dwilanang/psp/internal/salary/service"
+	"github.com/dwilanang/psp/utils"
+	utilrequest "github.com/dwilanang/psp/utils/request"
+	"github.com/dwilanang/psp/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	Deps salary.Dependencies
+}
+
+func NewHandler(deps salary.Dependencies) *Handler {
+	return &Handler{
+		Deps: deps,
+	}
+}
+
+// Set godoc
+// @Security BearerAuth
+// @Summary      Set salary
+// @Description  Start a new salary for a user from effective_from; the salary in effect until then is closed the day before
+// @Tags         salary
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.SalaryRequest  true  "Salary payload"
+// @Success      201   {object}  response.ApiResponse{data=dto.SalaryData}
+// @Failure      400   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /salaries/set [post]
+func (h *Handler) Set(c *gin.Context) {
+	var sr dto.SalaryRequest
+	if err := c.ShouldBindJSON(&sr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	actor, err := util.GetActor(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	sr.By = actor.UserID
+
+	resp, err := h.Deps.Service.Set(&sr, actor)
+	if err != nil {
+		response.Error(c, err, "could not set salary")
+		return
+	}
+	response.Created(c, resp.Data)
+}
+
+// List godoc
+// @Security BearerAuth
+// @Summary      Get salary history
+// @Description  Get the salary history of a user, most recent first
+// @Tags         salary
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  response.ApiResponse{data=[]dto.SalaryData}
+// @Failure      500  {object}  response.Problem
+// @Router       /salaries/user/{id} [get]
+func (h *Handler) List(c *gin.Context) {
+	resp, err := h.Deps.Service.List(utils.ConvertStringToInt(c.Param("id")))
+	if err != nil {
+		response.Error(c, err, "could not fetch salaries")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// Effective godoc
+// @Security BearerAuth
+// @Summary      Get effective salary
+// @Description  Get the salary in effect for a user on a date, today when omitted
+// @Tags         salary
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int     true   "User ID"
+// @Param        date  query     string  false  "Date (YYYY-MM-DD)"
+// @Success      200   {object}  response.ApiResponse{data=dto.SalaryData}
+// @Failure      400   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /salaries/user/{id}/effective [get]
+func (h *Handler) Effective(c *gin.Context) {
+	date := c.DefaultQuery("date", time.Now().Format(service.DateLayout))
+
+	resp, err := h.Deps.Service.Effective(utils.ConvertStringToInt(c.Param("id")), date)
+	if err != nil {
+		response.Error(c, err, "could not fetch salary")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// Correct godoc
+// @Security BearerAuth
+// @Summary      Correct salary
+// @Description  Correct the amount or effective period of an existing salary record
+// @Tags         salary
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                          true  "Salary ID"
+// @Param        body  body      dto.SalaryCorrectionRequest  true  "Salary correction payload"
+// @Success      200   {object}  response.ApiResponse{data=dto.SalaryData}
+// @Failure      400   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /salaries/correct/{id} [put]
+func (h *Handler) Correct(c *gin.Context) {
+	var sr dto.SalaryCorrectionRequest
+	if err := c.ShouldBindJSON(&sr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	actor, err := util.GetActor(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	sr.ID = utils.ConvertStringToInt(c.Param("id"))
+	sr.By = actor.UserID
+
+	resp, err := h.Deps.Service.Correct(&sr, actor)
+	if err != nil {
+		response.Error(c, err, "could not correct salary")
+		return
+	}
+	response.OK(c, resp.Data)
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Salary is one entry of a user's salary history.
+// It is in effect from EffectiveFrom through EffectiveTo, both inclusive; a nil EffectiveTo means open-ended.
+type Salary struct {
+	ID            int64           `db:"id"`
+	UserID        int64           `db:"user_id"`
+	FullName      string          `db:"full_name"`
+	Amount        decimal.Decimal `db:"amount"`
+	EffectiveFrom time.Time       `db:"effective_from"`
+	EffectiveTo   *time.Time      `db:"effective_to"`
+	Note          string          `db:"note"`
+	CreatedBy     int64           `db:"created_by"`
+	CreatedAt     time.Time       `db:"created_at"`
+	UpdatedBy     int64           `db:"updated_by"`
+	UpdatedAt     time.Time       `db:"updated_at"`
+}
+
+// InEffect reports whether the salary applies on the given date.
+func (s *Salary) InEffect(date time.Time) bool {
+	if date.Before(s.EffectiveFrom) {
+		return false
+	}
+	return s.EffectiveTo == nil || !date.After(*s.EffectiveTo)
+}
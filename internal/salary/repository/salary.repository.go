@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/salary/model"
+)
+
+//go:generate mockgen -source=salary.repository.go -package=mocks -destination=mocks/mock_salary_repository.go
+
+// Repository defines an interface for data operations related to a user's salary history.
+type Repository interface {
+	// WithTx returns a repository running its statements in tx, e.g. one begun by postgres.Transactor.Within.
+	// Param: tx - the transaction to bind to.
+	// Returns a Repository bound to tx; the receiver is left unchanged.
+	WithTx(tx postgres.Executor) Repository
+
+	// FetchByUser retrieves the salary history of a user, most recent first.
+	// Param: userID - the ID of the user.
+	// Returns a slice of Salary pointers and an error if the operation fails.
+	FetchByUser(userID int64) ([]*model.Salary, error)
+
+	// FindByID retrieves a salary record by its ID.
+	// Param: id - the ID of the salary record.
+	// Returns a pointer to the Salary and an error if the operation fails or the record is not found.
+	FindByID(id int64) (*model.Salary, error)
+
+	// FindLatest retrieves the salary record of a user with the most recent effective_from.
+	// Param: userID - the ID of the user.
+	// Returns a pointer to the Salary and an error if the operation fails or the user has no salary.
+	FindLatest(userID int64) (*model.Salary, error)
+
+	// FindEffective retrieves the salary record in effect for a user on the given date.
+	// Param: userID - the ID of the user; date - the day to resolve.
+	// Returns a pointer to the Salary and an error if the operation fails or no salary is in effect.
+	FindEffective(userID int64, date time.Time) (*model.Salary, error)
+
+	// HasOverlap reports whether any salary of the user, other than excludeID, is in effect during the period.
+	// Param: userID - the ID of the user; from, to - the inclusive period, to is nil when open-ended;
+	// excludeID - the ID of a record to ignore, or 0.
+	// Returns true if an overlapping record exists and an error if the operation fails.
+	HasOverlap(userID int64, from time.Time, to *time.Time, excludeID int64) (bool, error)
+
+	// Create inserts a new open-ended salary record. The user's current open-ended salary, if it started
+	// before the new one, is closed the day before the new effective_from in the same transaction, which
+	// is the one the repository is bound to, if any.
+	// Param: salary - a pointer to the Salary entity to be created.
+	// Returns an error if the operation fails.
+	Create(salary *model.Salary) error
+
+	// Update corrects the amount, effective period and note of an existing salary record.
+	// Param: salary - a pointer to the Salary entity with corrected data.
+	// Returns an error if the operation fails.
+	Update(salary *model.Salary) error
+}
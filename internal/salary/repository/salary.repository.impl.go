@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/salary/model"
+)
+
+const selectSalary = `
+		SELECT 
+			us.id, us.user_id,
+			COALESCE(u.full_name, '') AS full_name,
+			us.amount, us.effective_from, us.effective_to,
+			COALESCE(us.note, '') AS note,
+			COALESCE(us.created_by, 0) AS created_by,
+			us.created_at,
+			COALESCE(us.updated_by, us.created_by, 0) AS updated_by,
+			COALESCE(us.updated_at, us.created_at) AS updated_at
+		FROM user_salaries us
+		INNER JOIN users u ON(u.id=us.user_id)
+`
+
+type repository struct {
+	db *postgres.DB
+	q  postgres.Executor // q is the master, or the transaction the repository is bound to by WithTx.
+}
+
+func NewRepository(db *postgres.DB) *repository {
+	return &repository{db: db, q: db.DB}
+}
+
+func (r *repository) WithTx(tx postgres.Executor) Repository {
+	return &repository{db: r.db, q: tx}
+}
+
+func (r *repository) FetchByUser(userID int64) ([]*model.Salary, error) {
+	salaries := []*model.Salary{}
+	query := selectSalary + `
+		WHERE us.user_id = $1
+		ORDER BY us.effective_from DESC
+	`
+	err := r.db.Replica().Select(&salaries, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return salaries, nil
+}
+
+func (r *repository) FindByID(id int64) (*model.Salary, error) {
+	var salary model.Salary
+	query := selectSalary + `
+		WHERE us.id = $1
+	`
+	err := r.q.Get(&salary, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return &salary, nil
+}
+
+func (r *repository) FindLatest(userID int64) (*model.Salary, error) {
+	var salary model.Salary
+	query := selectSalary + `
+		WHERE us.user_id = $1
+		ORDER BY us.effective_from DESC
+		LIMIT 1
+	`
+	err := r.q.Get(&salary, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &salary, nil
+}
+
+func (r *repository) FindEffective(userID int64, date time.Time) (*model.Salary, error) {
+	var salary model.Salary
+	query := selectSalary + `
+		WHERE us.user_id = $1 AND us.effective_from <= $2 AND (us.effective_to IS NULL OR us.effective_to >= $2)
+	`
+	err := r.q.Get(&salary, query, userID, date)
+	if err != nil {
+		return nil, err
+	}
+	return &salary, nil
+}
+
+func (r *repository) HasOverlap(userID int64, from time.Time, to *time.Time, excludeID int64) (bool, error) {
+	var overlap bool
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM user_salaries
+			WHERE user_id = $1 AND id <> $4
+			AND daterange(effective_from, effective_to, '[]') && daterange($2::date, $3::date, '[]')
+		)
+	`
+	err := r.q.Get(&overlap, query, userID, from, to, excludeID)
+	return overlap, err
+}
+
+func (r *repository) Create(salary *model.Salary) error {
+	return postgres.Within(r.q, func(tx postgres.Executor) error {
+		closeQuery := `
+			UPDATE user_salaries SET effective_to = $1::date - 1, updated_by = $2, updated_at = NOW()
+			WHERE user_id = $3 AND effective_to IS NULL AND effective_from < $1
+		`
+		_, err := tx.Exec(closeQuery, salary.EffectiveFrom, salary.CreatedBy, salary.UserID)
+		if err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO user_salaries (user_id, amount, effective_from, note, created_by, created_at, updated_by, updated_at)
+			VALUES ($1, $2, $3, $4, $5, NOW(), $5, NOW())
+			RETURNING id, created_at
+		`
+		return tx.QueryRowx(
+			query,
+			salary.UserID,
+			salary.Amount,
+			salary.EffectiveFrom,
+			salary.Note,
+			salary.CreatedBy,
+		).Scan(&salary.ID, &salary.CreatedAt)
+	})
+}
+
+func (r *repository) Update(salary *model.Salary) error {
+	query := `
+		UPDATE user_salaries SET amount = $1, effective_from = $2, effective_to = $3, note = $4, updated_by = $5, updated_at = NOW()
+		WHERE id = $6
+		RETURNING updated_at
+	`
+	return r.q.QueryRowx(
+		query,
+		salary.Amount,
+		salary.EffectiveFrom,
+		salary.EffectiveTo,
+		salary.Note,
+		salary.UpdatedBy,
+		salary.ID,
+	).Scan(&salary.UpdatedAt)
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/salary/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockDB(t *testing.T) (*postgres.DB, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	return postgres.NewDB(sqlx.NewDb(db, "postgres")), mock, func() { db.Close() }
+}
+
+var salaryColumns = []string{"id", "user_id", "full_name", "amount", "effective_from", "effective_to", "note", "created_by", "created_at", "updated_by", "updated_at"}
+
+func TestFetchByUser_Success(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows(salaryColumns).
+		AddRow(2, 7, "John Doe", "5500000.00", from, nil, "", 1, time.Now(), 1, time.Now()).
+		AddRow(1, 7, "John Doe", "5000000.00", from.AddDate(-1, 0, 0), to, "", 1, time.Now(), 1, time.Now())
+
+	mock.ExpectQuery("FROM user_salaries us").
+		WithArgs(int64(7)).
+		WillReturnRows(rows)
+
+	salaries, err := repo.FetchByUser(7)
+
+	assert.NoError(t, err)
+	assert.Len(t, salaries, 2)
+	assert.True(t, decimal.RequireFromString("5500000").Equal(salaries[0].Amount))
+	assert.Nil(t, salaries[0].EffectiveTo)
+	assert.Equal(t, to, *salaries[1].EffectiveTo)
+}
+
+func TestFindEffective_Success(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	date := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows(salaryColumns).
+		AddRow(2, 7, "John Doe", "5500000.00", date.AddDate(0, -2, 0), nil, "", 1, time.Now(), 1, time.Now())
+
+	mock.ExpectQuery("us.effective_from <= \\$2").
+		WithArgs(int64(7), date).
+		WillReturnRows(rows)
+
+	salary, err := repo.FindEffective(7, date)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), salary.ID)
+}
+
+func TestHasOverlap(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT EXISTS").
+		WithArgs(int64(7), from, nil, int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	overlap, err := repo.HasOverlap(7, from, nil, 3)
+
+	assert.NoError(t, err)
+	assert.True(t, overlap)
+}
+
+func TestCreate_ClosesPreviousSalary(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	salary := &model.Salary{
+		UserID:        7,
+		Amount:        decimal.RequireFromString("5500000.00"),
+		EffectiveFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedBy:     1,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE user_salaries SET effective_to").
+		WithArgs(salary.EffectiveFrom, int64(1), int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO user_salaries").
+		WithArgs(int64(7), salary.Amount, salary.EffectiveFrom, "", int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(2, time.Now()))
+	mock.ExpectCommit()
+
+	err := repo.Create(salary)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), salary.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate_RollsBackOnInsertError(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	salary := &model.Salary{
+		UserID:        7,
+		Amount:        decimal.RequireFromString("5500000.00"),
+		EffectiveFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedBy:     1,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE user_salaries SET effective_to").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO user_salaries").
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	err := repo.Create(salary)
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,19 @@
+package route
+
+import (
+	"github.com/dwilanang/psp/internal/permission"
+	"github.com/dwilanang/psp/internal/registry"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup, registry *registry.Registry) {
+	h := registry.NewSalaryHandler()
+
+	salariesGroup := rg.Group("/salaries")
+	{
+		salariesGroup.POST("/set", registry.RequirePermission(permission.SalariesCreate), h.Set)
+		salariesGroup.GET("/user/:id", registry.RequirePermission(permission.SalariesRead), h.List)
+		salariesGroup.GET("/user/:id/effective", registry.RequirePermission(permission.SalariesRead), h.Effective)
+		salariesGroup.PUT("/correct/:id", registry.RequirePermission(permission.SalariesUpdate), h.Correct)
+	}
+}
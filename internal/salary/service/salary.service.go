@@ -0,0 +1,43 @@
+package service
+
+import (
+	"time"
+
+	auditmodel "github.com/dwilanang/psp/internal/audit/model"
+	"github.com/dwilanang/psp/internal/salary/dto"
+	"github.com/dwilanang/psp/internal/salary/model"
+)
+
+//go:generate mockgen -source=salary.service.go -package=mocks -destination=mocks/mock_salary_service.go
+
+// Service defines the interface for business logic related to a user's salary history.
+// Every amount is handled as an exact decimal and every effective period is a range of whole days.
+type Service interface {
+	// Set starts a new salary for a user from the given effective date. The salary in effect until then
+	// is closed the day before; starting on or before an existing period is rejected with ErrOverlap.
+	// Param: request - a pointer to SalaryRequest DTO; actor - who sets the salary, recorded in the audit log
+	// with the new salary and the one it closes.
+	// Returns the created salary and an error if the request is invalid or the operation fails.
+	Set(request *dto.SalaryRequest, actor auditmodel.Actor) (dto.SalaryResponse, error)
+
+	// List returns the salary history of a user, most recent first.
+	// Param: userID - the ID of the user.
+	// Returns a SalaryListResponse DTO and an error if the operation fails.
+	List(userID int64) (dto.SalaryListResponse, error)
+
+	// Correct fixes the amount or effective period of an existing salary record.
+	// Param: request - a pointer to SalaryCorrectionRequest DTO; actor - who corrects the salary, recorded in
+	// the audit log with the fields that changed.
+	// Returns the corrected salary and ErrOverlap if the new period collides with another record.
+	Correct(request *dto.SalaryCorrectionRequest, actor auditmodel.Actor) (dto.SalaryResponse, error)
+
+	// Effective returns the salary in effect for a user on a date formatted as YYYY-MM-DD.
+	// Param: userID - the ID of the user; date - the day to resolve.
+	// Returns a SalaryResponse DTO and ErrSalaryNotFound if no salary is in effect.
+	Effective(userID int64, date string) (dto.SalaryResponse, error)
+
+	// Resolve returns the salary in effect for a user on the given day, for use by other modules such as payslip.
+	// Param: userID - the ID of the user; date - the day to resolve.
+	// Returns the Salary model and ErrSalaryNotFound if no salary is in effect.
+	Resolve(userID int64, date time.Time) (*model.Salary, error)
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	auditmodel "github.com/dwilanang/psp/internal/audit/model"
+	auditrepository "github.com/dwilanang/psp/internal/audit/repository"
+	"github.com/dwilanang/psp/internal/payroll/lock"
+	"github.com/dwilanang/psp/internal/salary/dto"
+	"github.com/dwilanang/psp/internal/salary/model"
+	"github.com/dwilanang/psp/internal/salary/repository"
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/shopspring/decimal"
+)
+
+// DateLayout is the format of every effective date accepted and returned by the salary module.
+const DateLayout = "2006-01-02"
+
+var (
+	ErrSalaryNotFound = apperror.NotFound("salary not found")
+	ErrOverlap        = apperror.Conflict("salary period overlaps an existing salary")
+	ErrInvalidAmount  = apperror.Validation("amount must be positive with at most 2 decimal places")
+	ErrInvalidDate    = apperror.Validation("dates must be formatted as YYYY-MM-DD and effective_to must not be before effective_from")
+	ErrPeriodLocked   = apperror.Conflict("salary change falls in a closed payroll period")
+)
+
+type service struct {
+	tx      postgres.Transactor
+	repo    repository.Repository
+	audits  auditrepository.Repository
+	periods lock.PeriodLocker
+}
+
+// NewService creates the salary service. Every change is written in one transaction of tx together with
+// its audit log entries. periods may be nil when payroll periods are not enforced.
+func NewService(tx postgres.Transactor, r repository.Repository, audits auditrepository.Repository, periods lock.PeriodLocker) *service {
+	return &service{tx: tx, repo: r, audits: audits, periods: periods}
+}
+
+// Set implements the Service interface.
+func (s *service) Set(request *dto.SalaryRequest, actor auditmodel.Actor) (dto.SalaryResponse, error) {
+	if err := validateAmount(request.Amount); err != nil {
+		return dto.SalaryResponse{}, err
+	}
+	from, err := parseDate(request.EffectiveFrom)
+	if err != nil {
+		return dto.SalaryResponse{}, err
+	}
+
+	// Periods never overlap, so only the latest one can collide with a period starting at `from`:
+	// it is rejected if it starts on/after `from` or is already closed on/after it.
+	latest, err := s.repo.FindLatest(request.UserID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return dto.SalaryResponse{}, err
+	}
+	if latest != nil {
+		if !latest.EffectiveFrom.Before(from) || (latest.EffectiveTo != nil && !latest.EffectiveTo.Before(from)) {
+			return dto.SalaryResponse{}, ErrOverlap
+		}
+	}
+	if err := s.checkUnlocked(from, nil); err != nil {
+		return dto.SalaryResponse{}, err
+	}
+
+	salary := &model.Salary{
+		UserID:        request.UserID,
+		Amount:        request.Amount,
+		EffectiveFrom: from,
+		Note:          request.Note,
+		CreatedBy:     request.By,
+		UpdatedBy:     request.By,
+	}
+
+	err = s.tx.Within(func(tx postgres.Executor) error {
+		err := s.repo.WithTx(tx).Create(salary)
+		if err != nil {
+			return err
+		}
+
+		// Create closes the open-ended salary in effect until then, which is recorded as its update.
+		if latest != nil && latest.EffectiveTo == nil {
+			closed := *latest
+			to := from.AddDate(0, 0, -1)
+			closed.EffectiveTo = &to
+			err := s.record(tx, auditmodel.NewEntry(actor, auditmodel.EntitySalary, latest.ID, auditmodel.ActionUpdate, snapshot(latest), snapshot(&closed)))
+			if err != nil {
+				return err
+			}
+		}
+		return s.record(tx, auditmodel.NewEntry(actor, auditmodel.EntitySalary, salary.ID, auditmodel.ActionCreate, nil, snapshot(salary)))
+	})
+	if err != nil {
+		return dto.SalaryResponse{}, err
+	}
+
+	return dto.SalaryResponse{Data: toData(salary)}, nil
+}
+
+// List implements the Service interface.
+func (s *service) List(userID int64) (dto.SalaryListResponse, error) {
+	salaries, err := s.repo.FetchByUser(userID)
+	if err != nil {
+		return dto.SalaryListResponse{}, err
+	}
+
+	data := make([]dto.SalaryData, 0, len(salaries))
+	for _, salary := range salaries {
+		data = append(data, toData(salary))
+	}
+	return dto.SalaryListResponse{Data: data}, nil
+}
+
+// Correct implements the Service interface.
+func (s *service) Correct(request *dto.SalaryCorrectionRequest, actor auditmodel.Actor) (dto.SalaryResponse, error) {
+	if err := validateAmount(request.Amount); err != nil {
+		return dto.SalaryResponse{}, err
+	}
+	from, err := parseDate(request.EffectiveFrom)
+	if err != nil {
+		return dto.SalaryResponse{}, err
+	}
+	var to *time.Time
+	if request.EffectiveTo != "" {
+		t, err := parseDate(request.EffectiveTo)
+		if err != nil {
+			return dto.SalaryResponse{}, err
+		}
+		if t.Before(from) {
+			return dto.SalaryResponse{}, ErrInvalidDate
+		}
+		to = &t
+	}
+
+	salary, err := s.repo.FindByID(request.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dto.SalaryResponse{}, ErrSalaryNotFound
+		}
+		return dto.SalaryResponse{}, err
+	}
+
+	// Both the days the record covered and the days it will cover must still be open for payroll.
+	if err := s.checkUnlocked(salary.EffectiveFrom, salary.EffectiveTo); err != nil {
+		return dto.SalaryResponse{}, err
+	}
+	if err := s.checkUnlocked(from, to); err != nil {
+		return dto.SalaryResponse{}, err
+	}
+
+	overlap, err := s.repo.HasOverlap(salary.UserID, from, to, salary.ID)
+	if err != nil {
+		return dto.SalaryResponse{}, err
+	}
+	if overlap {
+		return dto.SalaryResponse{}, ErrOverlap
+	}
+
+	before := snapshot(salary)
+	salary.Amount = request.Amount
+	salary.EffectiveFrom = from
+	salary.EffectiveTo = to
+	salary.Note = request.Note
+	salary.UpdatedBy = request.By
+
+	err = s.tx.Within(func(tx postgres.Executor) error {
+		err := s.repo.WithTx(tx).Update(salary)
+		if err != nil {
+			return err
+		}
+		return s.record(tx, auditmodel.NewEntry(actor, auditmodel.EntitySalary, salary.ID, auditmodel.ActionUpdate, before, snapshot(salary)))
+	})
+	if err != nil {
+		return dto.SalaryResponse{}, err
+	}
+
+	return dto.SalaryResponse{Data: toData(salary)}, nil
+}
+
+// Effective implements the Service interface.
+func (s *service) Effective(userID int64, date string) (dto.SalaryResponse, error) {
+	day, err := parseDate(date)
+	if err != nil {
+		return dto.SalaryResponse{}, err
+	}
+
+	salary, err := s.Resolve(userID, day)
+	if err != nil {
+		return dto.SalaryResponse{}, err
+	}
+	return dto.SalaryResponse{Data: toData(salary)}, nil
+}
+
+// Resolve implements the Service interface.
+func (s *service) Resolve(userID int64, date time.Time) (*model.Salary, error) {
+	salary, err := s.repo.FindEffective(userID, truncateDay(date))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSalaryNotFound
+		}
+		return nil, err
+	}
+	return salary, nil
+}
+
+// record appends entry to the audit log in tx.
+func (s *service) record(tx postgres.Executor, entry *auditmodel.Entry) error {
+	return s.audits.WithTx(tx).Create(entry)
+}
+
+// snapshot returns the fields of a salary recorded in the audit log, formatted like the API returns them.
+func snapshot(salary *model.Salary) auditmodel.Snapshot {
+	data := toData(salary)
+	var to any
+	if data.EffectiveTo != nil {
+		to = *data.EffectiveTo
+	}
+	return auditmodel.Snapshot{
+		"user_id":        data.UserID,
+		"amount":         data.Amount.StringFixed(2),
+		"effective_from": data.EffectiveFrom,
+		"effective_to":   to,
+		"note":           data.Note,
+	}
+}
+
+func (s *service) checkUnlocked(from time.Time, to *time.Time) error {
+	if s.periods == nil {
+		return nil
+	}
+
+	locked, err := s.periods.IsLocked(from, to)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return ErrPeriodLocked
+	}
+	return nil
+}
+
+func validateAmount(amount decimal.Decimal) error {
+	if !amount.IsPositive() || !amount.Equal(amount.Round(2)) {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	date, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+	return date, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func toData(salary *model.Salary) dto.SalaryData {
+	data := dto.SalaryData{
+		ID:            salary.ID,
+		UserID:        salary.UserID,
+		FullName:      salary.FullName,
+		Amount:        salary.Amount.Round(2),
+		EffectiveFrom: salary.EffectiveFrom.Format(DateLayout),
+		Note:          salary.Note,
+	}
+	if salary.EffectiveTo != nil {
+		to := salary.EffectiveTo.Format(DateLayout)
+		data.EffectiveTo = &to
+	}
+	return data
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	mockaudit "github.com/dwilanang/psp/internal/audit/repository/mocks"
+	"github.com/dwilanang/psp/internal/payroll/lock"
+	mockrepo "github.com/dwilanang/psp/internal/salary/repository/mocks"
+
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	auditmodel "github.com/dwilanang/psp/internal/audit/model"
+	"github.com/dwilanang/psp/internal/salary/dto"
+	"github.com/dwilanang/psp/internal/salary/model"
+)
+
+func day(value string) time.Time {
+	t, _ := time.Parse(DateLayout, value)
+	return t
+}
+
+var actor = auditmodel.Actor{UserID: 1, IPAddress: "10.0.0.1", RequestID: "req-1"}
+
+// fakeTransactor runs the unit of work without a database and records whether it would be committed.
+type fakeTransactor struct {
+	committed  int
+	rolledBack int
+}
+
+func (f *fakeTransactor) Within(fn func(tx postgres.Executor) error) error {
+	if err := fn(nil); err != nil {
+		f.rolledBack++
+		return err
+	}
+	f.committed++
+	return nil
+}
+
+// newTestService returns the service with mocked repositories bound to any transaction.
+func newTestService(ctrl *gomock.Controller, periods lock.PeriodLocker) (*service, *mockrepo.MockRepository, *mockaudit.MockRepository) {
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).AnyTimes()
+	mockAudits := mockaudit.NewMockRepository(ctrl)
+	mockAudits.EXPECT().WithTx(gomock.Any()).Return(mockAudits).AnyTimes()
+	return NewService(&fakeTransactor{}, mockRepo, mockAudits, periods), mockRepo, mockAudits
+}
+
+func TestService_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, mockRepo, mockAudits := newTestService(ctrl, nil)
+
+	mockRepo.EXPECT().FindLatest(int64(7)).Return(&model.Salary{ID: 1, UserID: 7, EffectiveFrom: day("2024-01-01")}, nil)
+	mockRepo.
+		EXPECT().
+		Create(gomock.AssignableToTypeOf(&model.Salary{})).
+		DoAndReturn(func(salary *model.Salary) error {
+			assert.Equal(t, day("2025-01-01"), salary.EffectiveFrom)
+			assert.Nil(t, salary.EffectiveTo)
+			assert.Equal(t, int64(1), salary.CreatedBy)
+			salary.ID = 2
+			return nil
+		})
+	gomock.InOrder(
+		// The open-ended salary closed by Create is recorded as updated.
+		mockAudits.EXPECT().Create(gomock.Any()).DoAndReturn(func(entry *auditmodel.Entry) error {
+			assert.Equal(t, "1", entry.EntityID)
+			assert.Equal(t, auditmodel.ActionUpdate, entry.Action)
+			assert.Equal(t, auditmodel.Snapshot{"effective_to": nil}, entry.Before)
+			assert.Equal(t, auditmodel.Snapshot{"effective_to": "2024-12-31"}, entry.After)
+			return nil
+		}),
+		mockAudits.EXPECT().Create(gomock.Any()).DoAndReturn(func(entry *auditmodel.Entry) error {
+			assert.Equal(t, auditmodel.EntitySalary, entry.Entity)
+			assert.Equal(t, "2", entry.EntityID)
+			assert.Equal(t, auditmodel.ActionCreate, entry.Action)
+			assert.Equal(t, "5500000.50", entry.After["amount"])
+			return nil
+		}),
+	)
+
+	resp, err := svc.Set(&dto.SalaryRequest{
+		UserID:        7,
+		Amount:        decimal.RequireFromString("5500000.50"),
+		EffectiveFrom: "2025-01-01",
+		By:            1,
+	}, actor)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Data.ID)
+	assert.Equal(t, "5500000.5", resp.Data.Amount.String())
+	assert.Nil(t, resp.Data.EffectiveTo)
+}
+
+func TestService_Set_FirstSalary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, mockRepo, mockAudits := newTestService(ctrl, nil)
+
+	mockRepo.EXPECT().FindLatest(int64(7)).Return(nil, sql.ErrNoRows)
+	mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+	mockAudits.EXPECT().Create(gomock.Any()).Return(nil)
+
+	_, err := svc.Set(&dto.SalaryRequest{UserID: 7, Amount: decimal.NewFromInt(100), EffectiveFrom: "2025-01-01"}, actor)
+
+	assert.NoError(t, err)
+}
+
+func TestService_Set_Overlap(t *testing.T) {
+	closed := day("2025-06-30")
+	tests := []struct {
+		name   string
+		latest *model.Salary
+	}{
+		{"starts on the same day", &model.Salary{EffectiveFrom: day("2025-01-01")}},
+		{"starts later", &model.Salary{EffectiveFrom: day("2025-03-01")}},
+		{"closed after the new start", &model.Salary{EffectiveFrom: day("2024-01-01"), EffectiveTo: &closed}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc, mockRepo, _ := newTestService(ctrl, nil)
+
+			mockRepo.EXPECT().FindLatest(int64(7)).Return(tt.latest, nil)
+
+			_, err := svc.Set(&dto.SalaryRequest{UserID: 7, Amount: decimal.NewFromInt(100), EffectiveFrom: "2025-01-01"}, actor)
+			assert.ErrorIs(t, err, ErrOverlap)
+		})
+	}
+}
+
+func TestService_Set_InvalidInput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, _, _ := newTestService(ctrl, nil)
+
+	_, err := svc.Set(&dto.SalaryRequest{UserID: 7, Amount: decimal.NewFromInt(-1), EffectiveFrom: "2025-01-01"}, actor)
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+
+	_, err = svc.Set(&dto.SalaryRequest{UserID: 7, Amount: decimal.RequireFromString("10.005"), EffectiveFrom: "2025-01-01"}, actor)
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+
+	_, err = svc.Set(&dto.SalaryRequest{UserID: 7, Amount: decimal.NewFromInt(100), EffectiveFrom: "01/01/2025"}, actor)
+	assert.ErrorIs(t, err, ErrInvalidDate)
+}
+
+func TestService_Correct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, mockRepo, mockAudits := newTestService(ctrl, nil)
+
+	to := day("2025-06-30")
+	mockRepo.EXPECT().FindByID(int64(2)).Return(&model.Salary{ID: 2, UserID: 7, EffectiveFrom: day("2025-01-01")}, nil)
+	mockRepo.EXPECT().HasOverlap(int64(7), day("2025-02-01"), &to, int64(2)).Return(false, nil)
+	mockRepo.EXPECT().Update(gomock.AssignableToTypeOf(&model.Salary{})).Return(nil)
+	mockAudits.EXPECT().Create(gomock.Any()).DoAndReturn(func(entry *auditmodel.Entry) error {
+		assert.Equal(t, auditmodel.Snapshot{"amount": "0.00", "effective_from": "2025-01-01", "effective_to": nil}, entry.Before)
+		assert.Equal(t, auditmodel.Snapshot{"amount": "6000000.00", "effective_from": "2025-02-01", "effective_to": "2025-06-30"}, entry.After)
+		return nil
+	})
+
+	resp, err := svc.Correct(&dto.SalaryCorrectionRequest{
+		ID:            2,
+		Amount:        decimal.NewFromInt(6000000),
+		EffectiveFrom: "2025-02-01",
+		EffectiveTo:   "2025-06-30",
+	}, actor)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-02-01", resp.Data.EffectiveFrom)
+	assert.Equal(t, "2025-06-30", *resp.Data.EffectiveTo)
+}
+
+func TestService_Correct_Overlap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, mockRepo, _ := newTestService(ctrl, nil)
+
+	mockRepo.EXPECT().FindByID(int64(2)).Return(&model.Salary{ID: 2, UserID: 7}, nil)
+	mockRepo.EXPECT().HasOverlap(int64(7), day("2024-06-01"), nil, int64(2)).Return(true, nil)
+
+	_, err := svc.Correct(&dto.SalaryCorrectionRequest{ID: 2, Amount: decimal.NewFromInt(100), EffectiveFrom: "2024-06-01"}, actor)
+
+	assert.ErrorIs(t, err, ErrOverlap)
+}
+
+func TestService_Correct_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, mockRepo, _ := newTestService(ctrl, nil)
+
+	mockRepo.EXPECT().FindByID(int64(9)).Return(nil, sql.ErrNoRows)
+
+	_, err := svc.Correct(&dto.SalaryCorrectionRequest{ID: 9, Amount: decimal.NewFromInt(100), EffectiveFrom: "2025-01-01"}, actor)
+
+	assert.ErrorIs(t, err, ErrSalaryNotFound)
+}
+
+func TestService_Effective(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, mockRepo, _ := newTestService(ctrl, nil)
+
+	mockRepo.EXPECT().FindEffective(int64(7), day("2025-03-15")).Return(&model.Salary{ID: 2, UserID: 7, Amount: decimal.NewFromInt(100), EffectiveFrom: day("2025-01-01")}, nil)
+	mockRepo.EXPECT().FindEffective(int64(7), day("2020-01-01")).Return(nil, sql.ErrNoRows)
+
+	resp, err := svc.Effective(7, "2025-03-15")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Data.ID)
+
+	_, err = svc.Effective(7, "2020-01-01")
+	assert.ErrorIs(t, err, ErrSalaryNotFound)
+}
+
+type fakePeriodLocker struct {
+	closedFrom time.Time
+}
+
+func (f *fakePeriodLocker) IsLocked(from time.Time, to *time.Time) (bool, error) {
+	return to == nil || !to.Before(f.closedFrom), nil
+}
+
+func TestService_Set_PeriodLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, mockRepo, _ := newTestService(ctrl, &fakePeriodLocker{closedFrom: day("2025-01-01")})
+
+	mockRepo.EXPECT().FindLatest(int64(7)).Return(&model.Salary{ID: 1, UserID: 7, EffectiveFrom: day("2024-01-01")}, nil)
+
+	_, err := svc.Set(&dto.SalaryRequest{UserID: 7, Amount: decimal.NewFromInt(100), EffectiveFrom: "2025-01-15"}, actor)
+
+	assert.ErrorIs(t, err, ErrPeriodLocked)
+}
+
+func TestService_Correct_PeriodLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, mockRepo, _ := newTestService(ctrl, &fakePeriodLocker{closedFrom: day("2025-01-01")})
+
+	// The record itself ends before the closed period, but the correction would extend it into it.
+	to := day("2024-12-31")
+	newTo := day("2025-01-31")
+	mockRepo.EXPECT().FindByID(int64(2)).Return(&model.Salary{ID: 2, UserID: 7, EffectiveFrom: day("2024-06-01"), EffectiveTo: &to}, nil)
+
+	_, err := svc.Correct(&dto.SalaryCorrectionRequest{ID: 2, Amount: decimal.NewFromInt(100), EffectiveFrom: "2024-06-01", EffectiveTo: newTo.Format(DateLayout)}, actor)
+
+	assert.ErrorIs(t, err, ErrPeriodLocked)
+}
@@ -1,16 +1,43 @@
 package dto
 
+import "github.com/shopspring/decimal"
+
 type UserRequest struct {
-	Password string `json:"password" binding:"required"`
-	Username string `json:"username" binding:"required"`
+	Password string                    `json:"password" binding:"required,strong_password"`
+	Username string                    `json:"username" binding:"required,username"`
+	FullName string                    `json:"full_name" binding:"required"`
+	RoleID   int64                     `json:"role_id" binding:"required"`
+	Salary   *UserInitialSalaryRequest `json:"salary" binding:"omitempty"`
+	By       int64                     `json:"by"`
+}
+
+// UserInitialSalaryRequest is the optional salary recorded together with a new user.
+type UserInitialSalaryRequest struct {
+	Amount        decimal.Decimal `json:"amount" binding:"required" swaggertype:"string" example:"5000000.00"`
+	EffectiveFrom string          `json:"effective_from" binding:"required,iso_date" example:"2025-01-01"`
+}
+
+type UserFilterRequest struct {
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PerPage  int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Role     string `form:"role"`
+	Username string `form:"username"`
+}
+
+type UserUpdateRequest struct {
+	Username string `json:"username" binding:"required,username"`
 	FullName string `json:"full_name" binding:"required"`
-	RoleID   int64  `json:"role_id" binding:"required"`
-	By       int64  `json:"by"`
+	By       int64  `json:"by" swaggerignore:"true"`
+}
+
+type UserRoleRequest struct {
+	RoleID int64 `json:"role_id" binding:"required"`
+	By     int64 `json:"by" swaggerignore:"true"`
 }
 
 type UserSalaryRequest struct {
-	UserID        int64   `json:"user_id" binding:"required"`
-	Amount        float64 `json:"amount" binding:"required"`
-	EffectiveFrom string  `json:"effective_from" binding:"required"`
-	By            int64   `json:"by" swaggerignore:"true"`
+	UserID        int64           `json:"user_id" binding:"required"`
+	Amount        decimal.Decimal `json:"amount" binding:"required" swaggertype:"string" example:"5000000.00"`
+	EffectiveFrom string          `json:"effective_from" binding:"required,iso_date"`
+	By            int64           `json:"by" swaggerignore:"true"`
 }
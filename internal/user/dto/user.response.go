@@ -1,5 +1,11 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
 type UserResponse struct {
 	Data UserData `json:"data"`
 }
@@ -9,7 +15,29 @@ type UserData struct {
 	FullName string `json:"full_name"`
 }
 
+type UserDetailResponse struct {
+	Data UserDetail `json:"data"`
+}
+
+type UserListResponse struct {
+	Data    []UserDetail `json:"data"`
+	Page    int          `json:"page"`
+	PerPage int          `json:"per_page"`
+	Total   int64        `json:"total"`
+}
+
+type UserDetail struct {
+	UUID      string    `json:"uuid"`
+	Username  string    `json:"username"`
+	FullName  string    `json:"full_name"`
+	RoleID    int64     `json:"role_id"`
+	Role      string    `json:"role"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 type UserSalaryResponse struct {
-	FullName string  `json:"full_name"`
-	Amount   float64 `json:"amount"`
+	FullName string          `json:"full_name"`
+	Amount   decimal.Decimal `json:"amount" swaggertype:"string" example:"5000000.00"`
 }
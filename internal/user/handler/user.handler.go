@@ -1,12 +1,11 @@
 package handler
 
 import (
-	"net/http"
-
 	"github.com/dwilanang/psp/internal/auth/util"
 	"github.com/dwilanang/psp/internal/user"
 	"github.com/dwilanang/psp/internal/user/dto"
 	utilrequest "github.com/dwilanang/psp/utils/request"
+	"github.com/dwilanang/psp/utils/response"
 	"github.com/gin-gonic/gin"
 )
 
@@ -23,33 +22,230 @@ func NewHandler(deps user.Dependencies) *Handler {
 // Register godoc
 // @Security BearerAuth
 // @Summary      Register user
-// @Description  Create a new user
+// @Description  Create a new user, optionally with the salary in effect from a date; both are saved or neither is
 // @Tags         user
 // @Accept       json
 // @Produce      json
 // @Param        body  body      dto.UserRequest  true  "User registration payload"
-// @Success      201   {object}  dto.UserResponse
-// @Failure      400   {object}  map[string]string
-// @Failure      500   {object}  map[string]string
+// @Success      201   {object}  response.ApiResponse{data=dto.UserData}
+// @Failure      400   {object}  response.Problem
+// @Failure      409   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
 // @Router       /users/register [post]
 func (h *Handler) Register(c *gin.Context) {
 	var ur dto.UserRequest
 	if err := c.ShouldBindJSON(&ur); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": utilrequest.ValidateRequest(err)})
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	actor, err := util.GetActor(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	ur.By = actor.UserID
+
+	resp, err := h.Deps.Service.Register(&ur, actor)
+	if err != nil {
+		response.Error(c, err, "could not create user")
+		return
+	}
+	response.Created(c, resp.Data)
+}
+
+// GetAll godoc
+// @Security BearerAuth
+// @Summary      Get users
+// @Description  Get a paginated list of users, optionally filtered by role name and username
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        page      query     int     false  "Page number (default 1)"
+// @Param        per_page  query     int     false  "Page size (default 20, max 100)"
+// @Param        role      query     string  false  "Role name"
+// @Param        username  query     string  false  "Username contains"
+// @Success      200  {object}  response.ApiResponse{data=[]dto.UserDetail,meta=response.Meta}
+// @Failure      400  {object}  response.Problem
+// @Failure      500  {object}  response.Problem
+// @Router       /users/all [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	var fr dto.UserFilterRequest
+	if err := c.ShouldBindQuery(&fr); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	resp, err := h.Deps.Service.List(&fr)
+	if err != nil {
+		response.Error(c, err, "could not fetch users")
+		return
+	}
+	response.Page(c, resp.Data, response.NewMeta(resp.Page, resp.PerPage, resp.Total))
+}
+
+// Get godoc
+// @Security BearerAuth
+// @Summary      Get user
+// @Description  Get a user by UUID
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        uuid  path      string  true  "User UUID"
+// @Success      200   {object}  response.ApiResponse{data=dto.UserDetail}
+// @Failure      404   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /users/detail/{uuid} [get]
+func (h *Handler) Get(c *gin.Context) {
+	resp, err := h.Deps.Service.Get(c.Param("uuid"))
+	if err != nil {
+		response.Error(c, err, "could not fetch user")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// Update godoc
+// @Security BearerAuth
+// @Summary      Update user
+// @Description  Update the username and full name of a user
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        uuid  path      string                 true  "User UUID"
+// @Param        body  body      dto.UserUpdateRequest  true  "User update payload"
+// @Success      200   {object}  response.ApiResponse{data=dto.UserDetail}
+// @Failure      400   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /users/update/{uuid} [put]
+func (h *Handler) Update(c *gin.Context) {
+	var ur dto.UserUpdateRequest
+	if err := c.ShouldBindJSON(&ur); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
 		return
 	}
 
-	by, err := util.GetClaimsID(c)
+	actor, err := util.GetActor(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid claims"})
+		response.Error(c, err, "invalid claims")
 		return
 	}
-	ur.By = by
+	ur.By = actor.UserID
 
-	resp, err := h.Deps.Service.Register(&ur)
+	resp, err := h.Deps.Service.Update(c.Param("uuid"), &ur, actor)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not create user"})
+		response.Error(c, err, "could not update user")
+		return
+	}
+	response.OK(c, resp.Data)
+}
+
+// ChangeRole godoc
+// @Security BearerAuth
+// @Summary      Change user role
+// @Description  Assign a new role to a user and revoke the user's sessions
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        uuid  path      string               true  "User UUID"
+// @Param        body  body      dto.UserRoleRequest  true  "User role payload"
+// @Success      200   {object}  response.ApiResponse
+// @Failure      400   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /users/role/{uuid} [put]
+func (h *Handler) ChangeRole(c *gin.Context) {
+	var ur dto.UserRoleRequest
+	if err := c.ShouldBindJSON(&ur); err != nil {
+		response.Error(c, utilrequest.ValidateRequest(c, err), "invalid payload")
+		return
+	}
+
+	actor, err := util.GetActor(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+	ur.By = actor.UserID
+
+	if err := h.Deps.Service.ChangeRole(c.Param("uuid"), &ur, actor); err != nil {
+		response.Error(c, err, "could not change user role")
+		return
+	}
+	response.Message(c, "User role has been changed.")
+}
+
+// Deactivate godoc
+// @Security BearerAuth
+// @Summary      Deactivate user
+// @Description  Deactivate a user so they can no longer log in, and revoke the user's sessions
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        uuid  path      string  true  "User UUID"
+// @Success      200   {object}  response.ApiResponse
+// @Failure      400   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /users/deactivate/{uuid} [put]
+func (h *Handler) Deactivate(c *gin.Context) {
+	h.setActive(c, false, "User has been deactivated.")
+}
+
+// Reactivate godoc
+// @Security BearerAuth
+// @Summary      Reactivate user
+// @Description  Reactivate a previously deactivated user
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        uuid  path      string  true  "User UUID"
+// @Success      200   {object}  response.ApiResponse
+// @Failure      404   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /users/reactivate/{uuid} [put]
+func (h *Handler) Reactivate(c *gin.Context) {
+	h.setActive(c, true, "User has been reactivated.")
+}
+
+// Delete godoc
+// @Security BearerAuth
+// @Summary      Delete user
+// @Description  Soft-delete a user and revoke the user's sessions
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        uuid  path      string  true  "User UUID"
+// @Success      200   {object}  response.ApiResponse
+// @Failure      400   {object}  response.Problem
+// @Failure      404   {object}  response.Problem
+// @Failure      500   {object}  response.Problem
+// @Router       /users/delete/{uuid} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	actor, err := util.GetActor(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+
+	if err := h.Deps.Service.Delete(c.Param("uuid"), actor); err != nil {
+		response.Error(c, err, "could not delete user")
+		return
+	}
+	response.Message(c, "User has been deleted.")
+}
+
+func (h *Handler) setActive(c *gin.Context, active bool, message string) {
+	actor, err := util.GetActor(c)
+	if err != nil {
+		response.Error(c, err, "invalid claims")
+		return
+	}
+
+	if err := h.Deps.Service.SetActive(c.Param("uuid"), active, actor); err != nil {
+		response.Error(c, err, "could not update user status")
 		return
 	}
-	c.JSON(http.StatusCreated, resp)
+	response.Message(c, message)
 }
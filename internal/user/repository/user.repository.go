@@ -1,18 +1,37 @@
 package repository
 
-import "github.com/dwilanang/psp/internal/user/model"
+import (
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	"github.com/dwilanang/psp/internal/user/model"
+)
 
 //go:generate mockgen -source=user.repository.go -package=mocks -destination=mocks/mock_user_repository.go
 
 // Repository defines the interface for data access operations related to the User entity
-// and associated salary records. It includes methods for retrieving users and creating users and salaries.
+// and associated salary records. It includes methods for retrieving, creating and managing users and salaries.
+// Soft-deleted users are never returned.
 type Repository interface {
-	// FindByUUID retrieves a user by their integer-based UUID.
-	// Param: id - the UUID of the user.
+	// WithTx returns a repository running its statements in tx, e.g. one begun by postgres.Transactor.Within.
+	// Param: tx - the transaction to bind to.
+	// Returns a Repository bound to tx; the receiver is left unchanged.
+	WithTx(tx postgres.Executor) Repository
+
+	// Fetch retrieves a page of users matching the filter, ordered by ID.
+	// Param: filter - the role/username filter and the page limit and offset.
+	// Returns the users of the page, the total number of matching users and an error if the query fails.
+	Fetch(filter *model.UserFilter) ([]*model.User, int64, error)
+
+	// FindByUUID retrieves a user, including the name of their role, by their UUID.
+	// Param: uuid - the UUID of the user.
+	// Returns a pointer to the User model and an error if the user is not found or the query fails.
+	FindByUUID(uuid string) (*model.User, error)
+
+	// FindByID retrieves an active user, including the name of their role, by their numeric ID.
+	// Param: id - the ID of the user.
 	// Returns a pointer to the User model and an error if the user is not found or the query fails.
-	FindByUUID(id int) (*model.User, error)
+	FindByID(id int64) (*model.User, error)
 
-	// FindByUsername retrieves a user by their username.
+	// FindByUsername retrieves an active user by their username.
 	// Param: username - the username to search for.
 	// Returns a pointer to the User model and an error if the user is not found or the query fails.
 	FindByUsername(username string) (*model.User, error)
@@ -22,6 +41,26 @@ type Repository interface {
 	// Returns an error if the insertion fails.
 	Create(user *model.User) error
 
+	// Update modifies the profile (username and full name) of the user identified by the ID in the User struct.
+	// Param: user - a pointer to the User model with updated data.
+	// Returns an error if the update fails.
+	Update(user *model.User) error
+
+	// UpdateRole assigns a new role to a user.
+	// Param: id - the ID of the user; roleID - the ID of the new role; by - the ID of the acting user.
+	// Returns an error if the update fails.
+	UpdateRole(id int64, roleID int64, by int64) error
+
+	// SetActive deactivates or reactivates a user. Inactive users cannot log in.
+	// Param: id - the ID of the user; active - the new status; by - the ID of the acting user.
+	// Returns an error if the update fails.
+	SetActive(id int64, active bool, by int64) error
+
+	// SoftDelete marks a user as deleted while keeping the row for historical references.
+	// Param: id - the ID of the user; by - the ID of the acting user.
+	// Returns an error if the update fails.
+	SoftDelete(id int64, by int64) error
+
 	// CreateSalary inserts a new salary record for a user into the data store.
 	// Param: us - a pointer to the UserSalary model containing salary data.
 	// Returns an error if the insertion fails.
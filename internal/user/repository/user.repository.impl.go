@@ -1,26 +1,117 @@
 package repository
 
 import (
-	"database/sql"
-	"errors"
+	"fmt"
+	"strings"
 
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
 	"github.com/dwilanang/psp/internal/user/model"
-	"github.com/jmoiron/sqlx"
+	"github.com/dwilanang/psp/utils/query"
 )
 
 type repository struct {
-	db *sqlx.DB
+	db *postgres.DB
+	q  postgres.Executor // q is the master, or the transaction the repository is bound to by WithTx.
 }
 
-func NewRepository(db *sqlx.DB) *repository {
-	return &repository{db}
+func NewRepository(db *postgres.DB) *repository {
+	return &repository{db: db, q: db.DB}
 }
 
-func (r *repository) FindByUUID(id int) (*model.User, error) {
+func (r *repository) WithTx(tx postgres.Executor) Repository {
+	return &repository{db: r.db, q: tx}
+}
+
+func (r *repository) Fetch(filter *model.UserFilter) ([]*model.User, int64, error) {
+	where := []string{"u.deleted_at IS NULL"}
+	args := []any{}
+
+	if filter.Role != "" {
+		args = append(args, filter.Role)
+		where = append(where, fmt.Sprintf("r.name = $%d", len(args)))
+	}
+	if filter.Username != "" {
+		args = append(args, "%"+query.EscapeLike(filter.Username)+"%")
+		where = append(where, fmt.Sprintf("u.username ILIKE $%d", len(args)))
+	}
+	conditions := strings.Join(where, " AND ")
+
+	// Count and page on the same replica so the total matches the page.
+	replica := r.db.Reader(r.q)
+
+	var total int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM users u
+		INNER JOIN roles r ON(u.role_id=r.id)
+		WHERE ` + conditions
+	if err := replica.Get(&total, countQuery, args...); err != nil {
+		return nil, 0, postgres.WrapError(err, "user")
+	}
+
+	users := []*model.User{}
+	listQuery := `
+		SELECT 
+			u.id,
+			u.uuid,
+			u.username,
+			u.full_name,
+			u.role_id,
+			r.name AS role,
+			u.is_active,
+			u.created_at,
+			COALESCE(u.updated_at, u.created_at) AS updated_at
+		FROM users u
+		INNER JOIN roles r ON(u.role_id=r.id)
+		WHERE ` + conditions + fmt.Sprintf(`
+		ORDER BY u.id
+		LIMIT $%d OFFSET $%d
+	`, len(args)+1, len(args)+2)
+	err := replica.Select(&users, listQuery, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, postgres.WrapError(err, "user")
+	}
+	return users, total, nil
+}
+
+func (r *repository) FindByUUID(uuid string) (*model.User, error) {
+	var user model.User
+	query := `
+		SELECT 
+			u.id,
+			u.uuid,
+			u.username,
+			u.full_name,
+			u.role_id,
+			r.name AS role,
+			u.is_active,
+			u.created_at,
+			COALESCE(u.updated_at, u.created_at) AS updated_at
+		FROM users u
+		INNER JOIN roles r ON(u.role_id=r.id)
+		WHERE u.uuid = $1 AND u.deleted_at IS NULL
+	`
+	err := r.q.Get(&user, query, uuid)
+	if err != nil {
+		return nil, postgres.WrapError(err, "user")
+	}
+	return &user, nil
+}
+
+func (r *repository) FindByID(id int64) (*model.User, error) {
 	var user model.User
-	err := r.db.Get(&user, "SELECT * FROM users WHERE uuid = $1", id)
+	query := `
+		SELECT 
+			u.id,
+			u.uuid,
+			r.name AS role
+		FROM users u
+		INNER JOIN roles r ON(u.role_id=r.id)
+		WHERE u.id = $1 AND u.is_active AND u.deleted_at IS NULL
+	`
+	err := r.q.Get(&user, query, id)
 	if err != nil {
-		return nil, err
+		return nil, postgres.WrapError(err, "user")
 	}
 	return &user, nil
 }
@@ -35,14 +126,11 @@ func (r *repository) FindByUsername(username string) (*model.User, error) {
 			r.name AS role
 		FROM users u
 		INNER JOIN roles r ON(u.role_id=r.id)
-		WHERE u.username = $1
+		WHERE u.username = $1 AND u.is_active AND u.deleted_at IS NULL
 	`
-	err := r.db.Get(&user, query, username)
+	err := r.q.Get(&user, query, username)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("failed")
-		}
-		return nil, err
+		return nil, postgres.WrapError(err, "user")
 	}
 	return &user, nil
 }
@@ -51,9 +139,9 @@ func (r *repository) Create(user *model.User) error {
 	query := `
 		INSERT INTO users (uuid, username, password_hash, full_name, role_id, created_by, created_at)
 		VALUES ($1, $2, $3, $4, $5, $6, NOW())
-		RETURNING id, created_at
+		RETURNING id, is_active, created_at
 	`
-	return r.db.QueryRowx(
+	err := r.q.QueryRowx(
 		query,
 		user.UUID,
 		user.Username,
@@ -61,20 +149,65 @@ func (r *repository) Create(user *model.User) error {
 		user.FullName,
 		user.RoleID,
 		user.CreatedBy,
-	).Scan(&user.ID, &user.CreatedAt)
+	).Scan(&user.ID, &user.IsActive, &user.CreatedAt)
+
+	return postgres.WrapError(err, "user")
+}
+
+func (r *repository) Update(user *model.User) error {
+	query := `
+		UPDATE users SET username = $1, full_name = $2, updated_by = $3, updated_at = NOW()
+		WHERE id = $4 AND deleted_at IS NULL
+		RETURNING updated_at
+	`
+	err := r.q.QueryRowx(
+		query,
+		user.Username,
+		user.FullName,
+		user.UpdatedBy,
+		user.ID,
+	).Scan(&user.UpdatedAt)
+
+	return postgres.WrapError(err, "user")
+}
+
+func (r *repository) UpdateRole(id int64, roleID int64, by int64) error {
+	query := `
+		UPDATE users SET role_id = $1, updated_by = $2, updated_at = NOW() WHERE id = $3 AND deleted_at IS NULL
+	`
+	_, err := r.q.Exec(query, roleID, by, id)
+	return postgres.WrapError(err, "user")
+}
+
+func (r *repository) SetActive(id int64, active bool, by int64) error {
+	query := `
+		UPDATE users SET is_active = $1, updated_by = $2, updated_at = NOW() WHERE id = $3 AND deleted_at IS NULL
+	`
+	_, err := r.q.Exec(query, active, by, id)
+	return postgres.WrapError(err, "user")
+}
+
+func (r *repository) SoftDelete(id int64, by int64) error {
+	query := `
+		UPDATE users SET is_active = false, deleted_by = $1, deleted_at = NOW() WHERE id = $2 AND deleted_at IS NULL
+	`
+	_, err := r.q.Exec(query, by, id)
+	return postgres.WrapError(err, "user")
 }
 
 func (r *repository) CreateSalary(us *model.UserSalary) error {
 	query := `
-		INSERT INTO user_salaries (user_id, amount, effective_from, created_by, created_at)
-		VALUES ($1, $2, $3, $4, NOW())
+		INSERT INTO user_salaries (user_id, amount, effective_from, created_by, created_at, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), $4, NOW())
 		RETURNING id, created_at
 	`
-	return r.db.QueryRowx(
+	err := r.q.QueryRowx(
 		query,
 		us.UserID,
 		us.Amount,
 		us.EffectiveFrom,
 		us.CreatedBy,
 	).Scan(&us.ID, &us.CreatedAt)
+
+	return postgres.WrapError(err, "salary")
 }
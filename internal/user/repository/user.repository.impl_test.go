@@ -7,17 +7,19 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
 	"github.com/dwilanang/psp/internal/user/model"
 	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
-func setupMockDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock, func()) {
+func setupMockDB(t *testing.T) (*postgres.DB, sqlmock.Sqlmock, func()) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("failed to open sqlmock database: %v", err)
 	}
-	return sqlx.NewDb(db, "postgres"), mock, func() { db.Close() }
+	return postgres.NewDB(sqlx.NewDb(db, "postgres")), mock, func() { db.Close() }
 }
 
 func TestFindByUUID_Success(t *testing.T) {
@@ -30,15 +32,43 @@ func TestFindByUUID_Success(t *testing.T) {
 		AddRow(1, "uuid-123", "johndoe", "hashedpass", "John Doe", 2)
 
 	mock.ExpectQuery("SELECT").
-		WithArgs(1).
+		WithArgs("uuid-123").
 		WillReturnRows(rows)
 
-	user, err := repo.FindByUUID(1)
+	user, err := repo.FindByUUID("uuid-123")
 	fmt.Println(err)
 	assert.NoError(t, err)
 	assert.Equal(t, "johndoe", user.Username)
 }
 
+func TestFindByID_Success(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "role"}).
+		AddRow(1, "uuid-123", "admin")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+		SELECT 
+			u.id,
+			u.uuid,
+			r.name AS role
+		FROM users u
+		INNER JOIN roles r ON(u.role_id=r.id)
+		WHERE u.id = $1 AND u.is_active AND u.deleted_at IS NULL
+	`)).
+		WithArgs(int64(1)).
+		WillReturnRows(rows)
+
+	user, err := repo.FindByID(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "uuid-123", user.UUID)
+	assert.Equal(t, "admin", user.Role)
+}
+
 func TestFindByUsername_Success(t *testing.T) {
 	db, mock, close := setupMockDB(t)
 	defer close()
@@ -56,7 +86,7 @@ func TestFindByUsername_Success(t *testing.T) {
 			r.name AS role
 		FROM users u
 		INNER JOIN roles r ON(u.role_id=r.id)
-		WHERE u.username = $1
+		WHERE u.username = $1 AND u.is_active AND u.deleted_at IS NULL
 	`)).
 		WithArgs("johndoe").
 		WillReturnRows(rows)
@@ -86,15 +116,111 @@ func TestCreate_Success(t *testing.T) {
 	createdAt := time.Now()
 	mock.ExpectQuery("INSERT INTO users").
 		WithArgs(user.UUID, user.Username, user.PasswordHash, user.FullName, user.RoleID, user.CreatedBy).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(10, createdAt))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "is_active", "created_at"}).AddRow(10, true, createdAt))
 
 	err := repo.Create(user)
 
 	assert.NoError(t, err)
 	assert.Equal(t, int64(10), user.ID)
+	assert.True(t, user.IsActive)
 	assert.WithinDuration(t, createdAt, user.CreatedAt, time.Second)
 }
 
+func TestFetch_Success(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*)`)).
+		WithArgs("ADMIN", "%john%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(21))
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "username", "full_name", "role_id", "role", "is_active", "created_at", "updated_at"}).
+		AddRow(21, "uuid-123", "johndoe", "John Doe", 2, "ADMIN", true, time.Now(), time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE u.deleted_at IS NULL AND r.name = $1 AND u.username ILIKE $2
+		ORDER BY u.id
+		LIMIT $3 OFFSET $4`)).
+		WithArgs("ADMIN", "%john%", 20, 20).
+		WillReturnRows(rows)
+
+	users, total, err := repo.Fetch(&model.UserFilter{Role: "ADMIN", Username: "john", Limit: 20, Offset: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(21), total)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "ADMIN", users[0].Role)
+}
+
+func TestFetch_EscapesUsernameWildcards(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*)`)).
+		WithArgs(`%50\%\_x%`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE u.deleted_at IS NULL AND u.username ILIKE $1`)).
+		WithArgs(`%50\%\_x%`, 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	users, total, err := repo.Fetch(&model.UserFilter{Username: "50%_x", Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Empty(t, users)
+}
+
+func TestUpdate_Success(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	user := &model.User{ID: 1, Username: "johndoe", FullName: "John Doe", UpdatedBy: 2}
+
+	updatedAt := time.Now()
+	mock.ExpectQuery("UPDATE users SET username").
+		WithArgs(user.Username, user.FullName, user.UpdatedBy, user.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+
+	err := repo.Update(user)
+
+	assert.NoError(t, err)
+	assert.WithinDuration(t, updatedAt, user.UpdatedAt, time.Second)
+}
+
+func TestSetActive_Success(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE users SET is_active = $1, updated_by = $2, updated_at = NOW() WHERE id = $3 AND deleted_at IS NULL`)).
+		WithArgs(false, int64(2), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SetActive(1, false, 2)
+	assert.NoError(t, err)
+}
+
+func TestSoftDelete_Success(t *testing.T) {
+	db, mock, close := setupMockDB(t)
+	defer close()
+
+	repo := NewRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE users SET is_active = false, deleted_by = $1, deleted_at = NOW() WHERE id = $2 AND deleted_at IS NULL`)).
+		WithArgs(int64(2), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SoftDelete(1, 2)
+	assert.NoError(t, err)
+}
+
 func TestCreateSalary_Success(t *testing.T) {
 	db, mock, close := setupMockDB(t)
 	defer close()
@@ -103,7 +229,7 @@ func TestCreateSalary_Success(t *testing.T) {
 
 	salary := &model.UserSalary{
 		UserID:        1,
-		Amount:        5000000,
+		Amount:        decimal.RequireFromString("5000000.00"),
 		EffectiveFrom: time.Now().Format("2006-01-02 15:04:05"),
 		CreatedBy:     1,
 	}
@@ -1,7 +1,7 @@
 package route
 
 import (
-	"github.com/dwilanang/psp/internal/middleware"
+	"github.com/dwilanang/psp/internal/permission"
 	"github.com/dwilanang/psp/internal/registry"
 	"github.com/gin-gonic/gin"
 )
@@ -11,7 +11,13 @@ func RegisterRoutes(rg *gin.RouterGroup, registry *registry.Registry) {
 
 	usersGroup := rg.Group("/users")
 	{
-		usersGroup.Use(middleware.RequireRole("SUPERADMIN"))
-		usersGroup.POST("/register", h.Register)
+		usersGroup.POST("/register", registry.RequirePermission(permission.UsersCreate), h.Register)
+		usersGroup.GET("/all", registry.RequirePermission(permission.UsersRead), h.GetAll)
+		usersGroup.GET("/detail/:uuid", registry.RequirePermission(permission.UsersRead), h.Get)
+		usersGroup.PUT("/update/:uuid", registry.RequirePermission(permission.UsersUpdate), h.Update)
+		usersGroup.PUT("/role/:uuid", registry.RequirePermission(permission.UsersChangeRole), h.ChangeRole)
+		usersGroup.PUT("/deactivate/:uuid", registry.RequirePermission(permission.UsersDeactivate), h.Deactivate)
+		usersGroup.PUT("/reactivate/:uuid", registry.RequirePermission(permission.UsersDeactivate), h.Reactivate)
+		usersGroup.DELETE("/delete/:uuid", registry.RequirePermission(permission.UsersDelete), h.Delete)
 	}
 }
@@ -7,6 +7,7 @@ package mocks
 import (
 	reflect "reflect"
 
+	model "github.com/dwilanang/psp/internal/audit/model"
 	dto "github.com/dwilanang/psp/internal/user/dto"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -34,17 +35,104 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// ChangeRole mocks base method.
+func (m *MockService) ChangeRole(uuid string, request *dto.UserRoleRequest, actor model.Actor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeRole", uuid, request, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeRole indicates an expected call of ChangeRole.
+func (mr *MockServiceMockRecorder) ChangeRole(uuid, request, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeRole", reflect.TypeOf((*MockService)(nil).ChangeRole), uuid, request, actor)
+}
+
+// Delete mocks base method.
+func (m *MockService) Delete(uuid string, actor model.Actor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", uuid, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceMockRecorder) Delete(uuid, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), uuid, actor)
+}
+
+// Get mocks base method.
+func (m *MockService) Get(uuid string) (dto.UserDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", uuid)
+	ret0, _ := ret[0].(dto.UserDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockServiceMockRecorder) Get(uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), uuid)
+}
+
+// List mocks base method.
+func (m *MockService) List(request *dto.UserFilterRequest) (dto.UserListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", request)
+	ret0, _ := ret[0].(dto.UserListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), request)
+}
+
 // Register mocks base method.
-func (m *MockService) Register(request *dto.UserRequest) (dto.UserResponse, error) {
+func (m *MockService) Register(request *dto.UserRequest, actor model.Actor) (dto.UserResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Register", request)
+	ret := m.ctrl.Call(m, "Register", request, actor)
 	ret0, _ := ret[0].(dto.UserResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Register indicates an expected call of Register.
-func (mr *MockServiceMockRecorder) Register(request interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) Register(request, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockService)(nil).Register), request, actor)
+}
+
+// SetActive mocks base method.
+func (m *MockService) SetActive(uuid string, active bool, actor model.Actor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetActive", uuid, active, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetActive indicates an expected call of SetActive.
+func (mr *MockServiceMockRecorder) SetActive(uuid, active, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActive", reflect.TypeOf((*MockService)(nil).SetActive), uuid, active, actor)
+}
+
+// Update mocks base method.
+func (m *MockService) Update(uuid string, request *dto.UserUpdateRequest, actor model.Actor) (dto.UserDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", uuid, request, actor)
+	ret0, _ := ret[0].(dto.UserDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockServiceMockRecorder) Update(uuid, request, actor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockService)(nil).Register), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockService)(nil).Update), uuid, request, actor)
 }
@@ -1,6 +1,7 @@
 package service
 
 import (
+	auditmodel "github.com/dwilanang/psp/internal/audit/model"
 	"github.com/dwilanang/psp/internal/user/dto"
 )
 
@@ -9,8 +10,43 @@ import (
 // Service defines the interface for business logic related to user management,
 // including user registration and salary creation.
 type Service interface {
-	// Register handles the registration of a new user.
-	// Param: request - a pointer to UserRequest DTO containing user registration data.
-	// Returns a UserResponse DTO and an error if the registration fails.
-	Register(request *dto.UserRequest) (dto.UserResponse, error)
+	// Register handles the registration of a new user, together with their initial salary when one is given.
+	// Param: request - a pointer to UserRequest DTO containing user registration data; actor - who registers
+	// the user, recorded in the audit log with the user and their salary.
+	// Returns a UserResponse DTO, ErrInvalidSalary, ErrPeriodLocked or ErrRoleNotFound, and an error if the registration fails;
+	// nothing is saved when it fails.
+	Register(request *dto.UserRequest, actor auditmodel.Actor) (dto.UserResponse, error)
+
+	// List returns a page of users filtered by role name and/or username.
+	// Param: request - a pointer to UserFilterRequest DTO with the filters and page; zero values use defaults.
+	// Returns a UserListResponse DTO and an error if the query fails.
+	List(request *dto.UserFilterRequest) (dto.UserListResponse, error)
+
+	// Get returns a single user by UUID.
+	// Param: uuid - the UUID of the user.
+	// Returns a UserDetailResponse DTO and ErrUserNotFound if no such user exists.
+	Get(uuid string) (dto.UserDetailResponse, error)
+
+	// Update changes the username and full name of a user.
+	// Param: uuid - the UUID of the user; request - a pointer to UserUpdateRequest DTO; actor - who updates
+	// the user, recorded in the audit log.
+	// Returns the updated UserDetailResponse DTO and ErrUserNotFound if no such user exists.
+	Update(uuid string, request *dto.UserUpdateRequest, actor auditmodel.Actor) (dto.UserDetailResponse, error)
+
+	// ChangeRole assigns a new role to a user and revokes their sessions so the new role takes effect.
+	// Param: uuid - the UUID of the user; request - a pointer to UserRoleRequest DTO; actor - who changes
+	// the role, recorded in the audit log.
+	// Returns ErrUserNotFound if no such user exists and ErrRoleNotFound if no such role exists.
+	ChangeRole(uuid string, request *dto.UserRoleRequest, actor auditmodel.Actor) error
+
+	// SetActive deactivates or reactivates a user. Deactivating also revokes the user's sessions.
+	// Param: uuid - the UUID of the user; active - the new status; actor - the acting user, recorded in the
+	// audit log.
+	// Returns ErrUserNotFound if no such user exists and ErrSelfAction when users target themselves.
+	SetActive(uuid string, active bool, actor auditmodel.Actor) error
+
+	// Delete soft-deletes a user and revokes their sessions.
+	// Param: uuid - the UUID of the user; actor - the acting user, recorded in the audit log.
+	// Returns ErrUserNotFound if no such user exists and ErrSelfAction when users target themselves.
+	Delete(uuid string, actor auditmodel.Actor) error
 }
@@ -1,25 +1,74 @@
 package service
 
 import (
-	"fmt"
+	"time"
 
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	auditmodel "github.com/dwilanang/psp/internal/audit/model"
+	auditrepository "github.com/dwilanang/psp/internal/audit/repository"
+	"github.com/dwilanang/psp/internal/payroll/lock"
+	rolerepository "github.com/dwilanang/psp/internal/role/repository"
 	"github.com/dwilanang/psp/internal/user/dto"
 	"github.com/dwilanang/psp/internal/user/model"
 	"github.com/dwilanang/psp/internal/user/repository"
+	"github.com/dwilanang/psp/pkg/apperror"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+
+	dateLayout = "2006-01-02"
+)
+
+var (
+	// ErrUserNotFound is returned when no user that is not soft-deleted matches the UUID.
+	ErrUserNotFound = apperror.NotFound("user not found")
+
+	// ErrSelfAction is returned when users try to deactivate or delete their own account.
+	ErrSelfAction = apperror.Validation("users cannot deactivate or delete themselves")
+
+	// ErrRoleNotFound is returned when a user is given a role that does not exist.
+	ErrRoleNotFound = apperror.Validation("role not found")
+
+	// ErrInvalidSalary is returned when the initial salary of a new user is not a positive amount with
+	// at most 2 decimal places or its effective date is not formatted as YYYY-MM-DD.
+	ErrInvalidSalary = apperror.Validation("salary amount must be positive with at most 2 decimal places and effective_from formatted as YYYY-MM-DD")
+
+	// ErrPeriodLocked is returned when the initial salary of a new user takes effect in a closed payroll period.
+	ErrPeriodLocked = apperror.Conflict("salary change falls in a closed payroll period")
+)
+
+// SessionRevoker revokes every session of a user, e.g. after a role change or deactivation.
+type SessionRevoker interface {
+	RevokeSessions(userID int64, by int64) error
+}
+
 type service struct {
-	repo repository.Repository
+	tx       postgres.Transactor
+	repo     repository.Repository
+	roles    rolerepository.Repository
+	audits   auditrepository.Repository
+	sessions SessionRevoker
+	periods  lock.PeriodLocker
 }
 
-func NewService(r repository.Repository) *service {
-	return &service{repo: r}
+// NewService creates the user service. Every change runs its lookups and writes in one transaction of tx,
+// together with its audit log entries. sessions may be nil when sessions do not need revoking, and periods
+// when payroll periods are not enforced.
+func NewService(tx postgres.Transactor, r repository.Repository, roles rolerepository.Repository, audits auditrepository.Repository, sessions SessionRevoker, periods lock.PeriodLocker) *service {
+	return &service{tx: tx, repo: r, roles: roles, audits: audits, sessions: sessions, periods: periods}
 }
 
 // Register implements the Service interface.
-func (s *service) Register(request *dto.UserRequest) (dto.UserResponse, error) {
+func (s *service) Register(request *dto.UserRequest, actor auditmodel.Actor) (dto.UserResponse, error) {
+	if request.Salary != nil {
+		if err := validateSalary(request.Salary); err != nil {
+			return dto.UserResponse{}, err
+		}
+	}
 
 	hashed, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -34,12 +83,40 @@ func (s *service) Register(request *dto.UserRequest) (dto.UserResponse, error) {
 		PasswordHash: string(hashed),
 		FullName:     request.FullName,
 		RoleID:       request.RoleID,
+		CreatedBy:    request.By,
 	}
 
-	err = s.repo.Create(user)
-	if err != nil {
-		fmt.Println("s.repo.Create() error: ", err)
-	}
+	// The user and their initial salary are written together, or not at all.
+	err = s.tx.Within(func(tx postgres.Executor) error {
+		if err := s.checkRole(tx, request.RoleID); err != nil {
+			return err
+		}
+		if request.Salary != nil {
+			if err := s.checkUnlocked(request.Salary.EffectiveFrom); err != nil {
+				return err
+			}
+		}
+
+		users := s.repo.WithTx(tx)
+		if err := users.Create(user); err != nil {
+			return err
+		}
+		err := s.record(tx, auditmodel.NewEntry(actor, auditmodel.EntityUser, user.UUID, auditmodel.ActionCreate, nil, snapshot(user)))
+		if err != nil || request.Salary == nil {
+			return err
+		}
+
+		salary := &model.UserSalary{
+			UserID:        user.ID,
+			Amount:        request.Salary.Amount,
+			EffectiveFrom: request.Salary.EffectiveFrom,
+			CreatedBy:     request.By,
+		}
+		if err := users.CreateSalary(salary); err != nil {
+			return err
+		}
+		return s.record(tx, auditmodel.NewEntry(actor, auditmodel.EntitySalary, salary.ID, auditmodel.ActionCreate, nil, salarySnapshot(salary)))
+	})
 
 	return dto.UserResponse{
 		Data: dto.UserData{
@@ -48,3 +125,262 @@ func (s *service) Register(request *dto.UserRequest) (dto.UserResponse, error) {
 		},
 	}, err
 }
+
+// List implements the Service interface.
+func (s *service) List(request *dto.UserFilterRequest) (dto.UserListResponse, error) {
+	page := request.Page
+	if page < 1 {
+		page = 1
+	}
+	perPage := request.PerPage
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	users, total, err := s.repo.Fetch(&model.UserFilter{
+		Role:     request.Role,
+		Username: request.Username,
+		Limit:    perPage,
+		Offset:   (page - 1) * perPage,
+	})
+	if err != nil {
+		return dto.UserListResponse{}, err
+	}
+
+	data := make([]dto.UserDetail, 0, len(users))
+	for _, u := range users {
+		data = append(data, toDetail(u))
+	}
+
+	return dto.UserListResponse{
+		Data:    data,
+		Page:    page,
+		PerPage: perPage,
+		Total:   total,
+	}, nil
+}
+
+// Get implements the Service interface.
+func (s *service) Get(uuid string) (dto.UserDetailResponse, error) {
+	user, err := s.find(s.repo, uuid)
+	if err != nil {
+		return dto.UserDetailResponse{}, err
+	}
+
+	return dto.UserDetailResponse{Data: toDetail(user)}, nil
+}
+
+// Update implements the Service interface.
+func (s *service) Update(uuid string, request *dto.UserUpdateRequest, actor auditmodel.Actor) (dto.UserDetailResponse, error) {
+	var user *model.User
+	err := s.tx.Within(func(tx postgres.Executor) error {
+		users := s.repo.WithTx(tx)
+		var err error
+		if user, err = s.find(users, uuid); err != nil {
+			return err
+		}
+		before := snapshot(user)
+
+		user.Username = request.Username
+		user.FullName = request.FullName
+		user.UpdatedBy = request.By
+		if err := users.Update(user); err != nil {
+			return err
+		}
+		return s.record(tx, auditmodel.NewEntry(actor, auditmodel.EntityUser, user.UUID, auditmodel.ActionUpdate, before, snapshot(user)))
+	})
+	if err != nil {
+		return dto.UserDetailResponse{}, err
+	}
+
+	return dto.UserDetailResponse{Data: toDetail(user)}, nil
+}
+
+// ChangeRole implements the Service interface.
+func (s *service) ChangeRole(uuid string, request *dto.UserRoleRequest, actor auditmodel.Actor) error {
+	var user *model.User
+	err := s.tx.Within(func(tx postgres.Executor) error {
+		users := s.repo.WithTx(tx)
+		var err error
+		if user, err = s.find(users, uuid); err != nil {
+			return err
+		}
+		before := snapshot(user)
+
+		if err := s.checkRole(tx, request.RoleID); err != nil {
+			return err
+		}
+		if err := users.UpdateRole(user.ID, request.RoleID, request.By); err != nil {
+			return err
+		}
+		user.RoleID = request.RoleID
+		return s.record(tx, auditmodel.NewEntry(actor, auditmodel.EntityUser, user.UUID, auditmodel.ActionUpdate, before, snapshot(user)))
+	})
+	if err != nil {
+		return err
+	}
+
+	// Access tokens carry the role name, so existing sessions would keep the old role until they expire.
+	return s.revokeSessions(user.ID, request.By)
+}
+
+// SetActive implements the Service interface.
+func (s *service) SetActive(uuid string, active bool, actor auditmodel.Actor) error {
+	var user *model.User
+	err := s.tx.Within(func(tx postgres.Executor) error {
+		users := s.repo.WithTx(tx)
+		var err error
+		if user, err = s.find(users, uuid); err != nil {
+			return err
+		}
+		if !active && user.ID == actor.UserID {
+			return ErrSelfAction
+		}
+		before := snapshot(user)
+
+		if err := users.SetActive(user.ID, active, actor.UserID); err != nil {
+			return err
+		}
+		user.IsActive = active
+		return s.record(tx, auditmodel.NewEntry(actor, auditmodel.EntityUser, user.UUID, auditmodel.ActionUpdate, before, snapshot(user)))
+	})
+	if err != nil {
+		return err
+	}
+
+	if active {
+		return nil
+	}
+	return s.revokeSessions(user.ID, actor.UserID)
+}
+
+// Delete implements the Service interface.
+func (s *service) Delete(uuid string, actor auditmodel.Actor) error {
+	var user *model.User
+	err := s.tx.Within(func(tx postgres.Executor) error {
+		users := s.repo.WithTx(tx)
+		var err error
+		if user, err = s.find(users, uuid); err != nil {
+			return err
+		}
+		if user.ID == actor.UserID {
+			return ErrSelfAction
+		}
+
+		if err := users.SoftDelete(user.ID, actor.UserID); err != nil {
+			return err
+		}
+		return s.record(tx, auditmodel.NewEntry(actor, auditmodel.EntityUser, user.UUID, auditmodel.ActionDelete, snapshot(user), nil))
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.revokeSessions(user.ID, actor.UserID)
+}
+
+// find reads a user that is not soft-deleted from users, which is bound to the transaction of a change
+// so the audit log records the state the change was made on.
+func (s *service) find(users repository.Repository, id string) (*model.User, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	user, err := users.FindByUUID(id)
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *service) revokeSessions(userID int64, by int64) error {
+	if s.sessions == nil {
+		return nil
+	}
+
+	return s.sessions.RevokeSessions(userID, by)
+}
+
+// record appends entry to the audit log in tx.
+func (s *service) record(tx postgres.Executor, entry *auditmodel.Entry) error {
+	return s.audits.WithTx(tx).Create(entry)
+}
+
+// snapshot returns the fields of a user recorded in the audit log; the password hash is never recorded.
+func snapshot(u *model.User) auditmodel.Snapshot {
+	return auditmodel.Snapshot{
+		"username":  u.Username,
+		"full_name": u.FullName,
+		"role_id":   u.RoleID,
+		"is_active": u.IsActive,
+	}
+}
+
+// salarySnapshot returns the fields of an initial salary recorded in the audit log, named like the salary
+// module names them.
+func salarySnapshot(us *model.UserSalary) auditmodel.Snapshot {
+	return auditmodel.Snapshot{
+		"user_id":        us.UserID,
+		"amount":         us.Amount.StringFixed(2),
+		"effective_from": us.EffectiveFrom,
+		"effective_to":   nil,
+		"note":           "",
+	}
+}
+
+func toDetail(u *model.User) dto.UserDetail {
+	return dto.UserDetail{
+		UUID:      u.UUID,
+		Username:  u.Username,
+		FullName:  u.FullName,
+		RoleID:    u.RoleID,
+		Role:      u.Role,
+		IsActive:  u.IsActive,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
+
+// checkRole returns ErrRoleNotFound unless the role exists, reading it in tx.
+func (s *service) checkRole(tx postgres.Executor, roleID int64) error {
+	_, err := s.roles.WithTx(tx).FindByID(roleID)
+	if apperror.IsNotFound(err) {
+		return ErrRoleNotFound
+	}
+	return err
+}
+
+// checkUnlocked returns ErrPeriodLocked if a salary taking effect on from, with no end, touches a closed
+// payroll period, like the salary module checks every new salary.
+func (s *service) checkUnlocked(from string) error {
+	if s.periods == nil {
+		return nil
+	}
+
+	day, _ := time.Parse(dateLayout, from)
+	locked, err := s.periods.IsLocked(day, nil)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return ErrPeriodLocked
+	}
+	return nil
+}
+
+// validateSalary checks the initial salary of a new user like the salary module checks every salary.
+func validateSalary(salary *dto.UserInitialSalaryRequest) error {
+	if !salary.Amount.IsPositive() || !salary.Amount.Equal(salary.Amount.Round(2)) {
+		return ErrInvalidSalary
+	}
+	if _, err := time.Parse(dateLayout, salary.EffectiveFrom); err != nil {
+		return ErrInvalidSalary
+	}
+	return nil
+}
@@ -1,24 +1,61 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/crypto/bcrypt"
 
+	mockaudit "github.com/dwilanang/psp/internal/audit/repository/mocks"
+	mockrolerepo "github.com/dwilanang/psp/internal/role/repository/mocks"
 	mockrepo "github.com/dwilanang/psp/internal/user/repository/mocks"
 
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
+	auditmodel "github.com/dwilanang/psp/internal/audit/model"
+	rolemodel "github.com/dwilanang/psp/internal/role/model"
 	"github.com/dwilanang/psp/internal/user/dto"
 	"github.com/dwilanang/psp/internal/user/model"
 )
 
+// fakeTransactor runs the unit of work without a database and records whether it would be committed.
+type fakeTransactor struct {
+	committed  int
+	rolledBack int
+}
+
+func (f *fakeTransactor) Within(fn func(tx postgres.Executor) error) error {
+	if err := fn(nil); err != nil {
+		f.rolledBack++
+		return err
+	}
+	f.committed++
+	return nil
+}
+
+var actor = auditmodel.Actor{UserID: 1, IPAddress: "10.0.0.1", RequestID: "req-1"}
+
+// newAudits returns an audit log repository bound to any transaction and accepting any entry.
+func newAudits(ctrl *gomock.Controller) *mockaudit.MockRepository {
+	audits := mockaudit.NewMockRepository(ctrl)
+	audits.EXPECT().WithTx(gomock.Any()).Return(audits).AnyTimes()
+	audits.EXPECT().Create(gomock.Any()).Return(nil).AnyTimes()
+	return audits
+}
+
 func TestService_Register(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mockrepo.NewMockRepository(ctrl)
-	svc := NewService(mockRepo)
+	mockRoles := mockrolerepo.NewMockRepository(ctrl)
+	mockAudits := mockaudit.NewMockRepository(ctrl)
+	tx := &fakeTransactor{}
+	svc := NewService(tx, mockRepo, mockRoles, mockAudits, nil, nil)
 
 	req := &dto.UserRequest{
 		Username: "testuser",
@@ -27,6 +64,9 @@ func TestService_Register(t *testing.T) {
 		RoleID:   1,
 	}
 
+	mockRoles.EXPECT().WithTx(gomock.Any()).Return(mockRoles)
+	mockRoles.EXPECT().FindByID(int64(1)).Return(&rolemodel.Role{ID: 1}, nil)
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo)
 	// Match what is expected to be created
 	mockRepo.
 		EXPECT().
@@ -37,11 +77,282 @@ func TestService_Register(t *testing.T) {
 			assert.Equal(t, req.RoleID, user.RoleID)
 			assert.NotEmpty(t, user.PasswordHash)
 			assert.Nil(t, bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)))
+			user.IsActive = true
 			return nil
 		})
+	mockAudits.EXPECT().WithTx(gomock.Any()).Return(mockAudits)
+	mockAudits.EXPECT().Create(gomock.Any()).DoAndReturn(func(entry *auditmodel.Entry) error {
+		// The password hash is never recorded.
+		assert.Equal(t, auditmodel.EntityUser, entry.Entity)
+		assert.Equal(t, auditmodel.ActionCreate, entry.Action)
+		assert.Len(t, entry.EntityID, 36)
+		assert.Nil(t, entry.Before)
+		assert.Equal(t, auditmodel.Snapshot{"username": "testuser", "full_name": "Test User", "role_id": int64(1), "is_active": true}, entry.After)
+		return nil
+	})
 
-	resp, err := svc.Register(req)
+	resp, err := svc.Register(req, actor)
 	assert.NoError(t, err)
 	assert.Equal(t, req.Username, resp.Data.Username)
 	assert.Equal(t, req.FullName, resp.Data.FullName)
+	assert.Equal(t, 1, tx.committed)
+}
+
+func TestService_Register_WithSalary_RollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	mockRoles := mockrolerepo.NewMockRepository(ctrl)
+	tx := &fakeTransactor{}
+	svc := NewService(tx, mockRepo, mockRoles, newAudits(ctrl), nil, nil)
+
+	req := &dto.UserRequest{
+		Username: "testuser",
+		Password: "secret123",
+		FullName: "Test User",
+		RoleID:   1,
+		Salary:   &dto.UserInitialSalaryRequest{Amount: decimal.RequireFromString("5000000.00"), EffectiveFrom: "2025-01-01"},
+		By:       2,
+	}
+
+	mockRoles.EXPECT().WithTx(gomock.Any()).Return(mockRoles)
+	mockRoles.EXPECT().FindByID(int64(1)).Return(&rolemodel.Role{ID: 1}, nil)
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo)
+	mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(user *model.User) error {
+		user.ID = 9
+		return nil
+	})
+	mockRepo.
+		EXPECT().
+		CreateSalary(&model.UserSalary{UserID: 9, Amount: req.Salary.Amount, EffectiveFrom: "2025-01-01", CreatedBy: 2}).
+		Return(errors.New("conflicting key value violates exclusion constraint"))
+
+	_, err := svc.Register(req, actor)
+
+	// The user is not kept without the salary it was registered with.
+	assert.Error(t, err)
+	assert.Equal(t, 0, tx.committed)
+	assert.Equal(t, 1, tx.rolledBack)
+}
+
+type fakePeriodLocker struct {
+	locked bool
+}
+
+func (f *fakePeriodLocker) IsLocked(from time.Time, to *time.Time) (bool, error) {
+	return f.locked, nil
+}
+
+func TestService_Register_WithSalary_PeriodLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoles := mockrolerepo.NewMockRepository(ctrl)
+	tx := &fakeTransactor{}
+	svc := NewService(tx, mockrepo.NewMockRepository(ctrl), mockRoles, newAudits(ctrl), nil, &fakePeriodLocker{locked: true})
+
+	req := &dto.UserRequest{
+		Username: "testuser",
+		Password: "secret123",
+		FullName: "Test User",
+		RoleID:   1,
+		Salary:   &dto.UserInitialSalaryRequest{Amount: decimal.RequireFromString("5000000.00"), EffectiveFrom: "2025-01-01"},
+		By:       2,
+	}
+
+	mockRoles.EXPECT().WithTx(gomock.Any()).Return(mockRoles)
+	mockRoles.EXPECT().FindByID(int64(1)).Return(&rolemodel.Role{ID: 1}, nil)
+
+	_, err := svc.Register(req, actor)
+
+	// Neither the user nor the salary is created.
+	assert.ErrorIs(t, err, ErrPeriodLocked)
+	assert.Equal(t, 1, tx.rolledBack)
+}
+
+func TestService_Register_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoles := mockrolerepo.NewMockRepository(ctrl)
+	svc := NewService(&fakeTransactor{}, mockrepo.NewMockRepository(ctrl), mockRoles, newAudits(ctrl), nil, nil)
+
+	req := &dto.UserRequest{Username: "testuser", Password: "secret123", FullName: "Test User", RoleID: 1}
+	req.Salary = &dto.UserInitialSalaryRequest{Amount: decimal.RequireFromString("10.005"), EffectiveFrom: "2025-01-01"}
+	_, err := svc.Register(req, actor)
+	assert.ErrorIs(t, err, ErrInvalidSalary)
+
+	req.Salary = &dto.UserInitialSalaryRequest{Amount: decimal.NewFromInt(100), EffectiveFrom: "01/01/2025"}
+	_, err = svc.Register(req, actor)
+	assert.ErrorIs(t, err, ErrInvalidSalary)
+
+	req.Salary = nil
+	mockRoles.EXPECT().WithTx(gomock.Any()).Return(mockRoles)
+	mockRoles.EXPECT().FindByID(int64(1)).Return(nil, postgres.WrapError(sql.ErrNoRows, "role"))
+	_, err = svc.Register(req, actor)
+	assert.ErrorIs(t, err, ErrRoleNotFound)
+}
+
+type fakeSessionRevoker struct {
+	revoked []int64
+}
+
+func (f *fakeSessionRevoker) RevokeSessions(userID int64, by int64) error {
+	f.revoked = append(f.revoked, userID)
+	return nil
+}
+
+const testUUID = "6f1d3c9e-7b7a-4f43-9f5b-0a3c2b1d4e5f"
+
+func TestService_List_Defaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(nil, mockRepo, nil, nil, nil, nil)
+
+	mockRepo.
+		EXPECT().
+		Fetch(&model.UserFilter{Role: "ADMIN", Limit: 100, Offset: 100}).
+		Return([]*model.User{{ID: 1, UUID: testUUID, Username: "testuser", Role: "ADMIN"}}, int64(101), nil)
+
+	resp, err := svc.List(&dto.UserFilterRequest{Page: 2, PerPage: 500, Role: "ADMIN"})
+	assert.NoError(t, err)
+	assert.Equal(t, 100, resp.PerPage)
+	assert.Equal(t, int64(101), resp.Total)
+	assert.Len(t, resp.Data, 1)
+}
+
+func TestService_Get_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	svc := NewService(nil, mockRepo, nil, nil, nil, nil)
+
+	mockRepo.EXPECT().FindByUUID(testUUID).Return(nil, postgres.WrapError(sql.ErrNoRows, "user"))
+
+	_, err := svc.Get(testUUID)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	_, err = svc.Get("not-a-uuid")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	mockAudits := mockaudit.NewMockRepository(ctrl)
+	tx := &fakeTransactor{}
+	svc := NewService(tx, mockRepo, nil, mockAudits, nil, nil)
+
+	// The user is read in the transaction, so the audited state is the one the update was made on.
+	gomock.InOrder(
+		mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo),
+		mockRepo.EXPECT().FindByUUID(testUUID).Return(&model.User{ID: 3, UUID: testUUID, Username: "old", FullName: "New Name"}, nil),
+	)
+	mockRepo.
+		EXPECT().
+		Update(gomock.AssignableToTypeOf(&model.User{})).
+		DoAndReturn(func(user *model.User) error {
+			assert.Equal(t, int64(3), user.ID)
+			assert.Equal(t, "new", user.Username)
+			assert.Equal(t, int64(1), user.UpdatedBy)
+			return nil
+		})
+	mockAudits.EXPECT().WithTx(gomock.Any()).Return(mockAudits)
+	mockAudits.EXPECT().Create(gomock.Any()).DoAndReturn(func(entry *auditmodel.Entry) error {
+		assert.Equal(t, testUUID, entry.EntityID)
+		assert.Equal(t, auditmodel.ActionUpdate, entry.Action)
+		assert.Equal(t, auditmodel.Snapshot{"username": "old"}, entry.Before)
+		assert.Equal(t, auditmodel.Snapshot{"username": "new"}, entry.After)
+		return nil
+	})
+
+	resp, err := svc.Update(testUUID, &dto.UserUpdateRequest{Username: "new", FullName: "New Name", By: 1}, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, "New Name", resp.Data.FullName)
+	assert.Equal(t, 1, tx.committed)
+}
+
+func TestService_ChangeRole_RevokesSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	sessions := &fakeSessionRevoker{}
+	mockRoles := mockrolerepo.NewMockRepository(ctrl)
+	svc := NewService(&fakeTransactor{}, mockRepo, mockRoles, newAudits(ctrl), sessions, nil)
+
+	mockRepo.EXPECT().FindByUUID(testUUID).Return(&model.User{ID: 3, UUID: testUUID}, nil)
+	mockRoles.EXPECT().WithTx(gomock.Any()).Return(mockRoles)
+	mockRoles.EXPECT().FindByID(int64(2)).Return(&rolemodel.Role{ID: 2}, nil)
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo)
+	mockRepo.EXPECT().UpdateRole(int64(3), int64(2), int64(1)).Return(nil)
+
+	err := svc.ChangeRole(testUUID, &dto.UserRoleRequest{RoleID: 2, By: 1}, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3}, sessions.revoked)
+}
+
+func TestService_SetActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	sessions := &fakeSessionRevoker{}
+	svc := NewService(&fakeTransactor{}, mockRepo, nil, newAudits(ctrl), sessions, nil)
+
+	mockRepo.EXPECT().FindByUUID(testUUID).Return(&model.User{ID: 3, UUID: testUUID}, nil).Times(2)
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).Times(2)
+	mockRepo.EXPECT().SetActive(int64(3), false, int64(1)).Return(nil)
+	mockRepo.EXPECT().SetActive(int64(3), true, int64(1)).Return(nil)
+
+	assert.NoError(t, svc.SetActive(testUUID, false, actor))
+	assert.NoError(t, svc.SetActive(testUUID, true, actor))
+	assert.Equal(t, []int64{3}, sessions.revoked)
+}
+
+func TestService_Delete_Self(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	tx := &fakeTransactor{}
+	svc := NewService(tx, mockRepo, nil, nil, nil, nil)
+
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo)
+	mockRepo.EXPECT().FindByUUID(testUUID).Return(&model.User{ID: 1, UUID: testUUID}, nil)
+
+	err := svc.Delete(testUUID, actor)
+	assert.ErrorIs(t, err, ErrSelfAction)
+	assert.Equal(t, 1, tx.rolledBack)
+}
+
+func TestService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockRepository(ctrl)
+	sessions := &fakeSessionRevoker{}
+	mockAudits := mockaudit.NewMockRepository(ctrl)
+	svc := NewService(&fakeTransactor{}, mockRepo, nil, mockAudits, sessions, nil)
+
+	mockRepo.EXPECT().FindByUUID(testUUID).Return(&model.User{ID: 3, UUID: testUUID, Username: "testuser", IsActive: true}, nil)
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo)
+	mockRepo.EXPECT().SoftDelete(int64(3), int64(1)).Return(nil)
+	mockAudits.EXPECT().WithTx(gomock.Any()).Return(mockAudits)
+	mockAudits.EXPECT().Create(gomock.Any()).DoAndReturn(func(entry *auditmodel.Entry) error {
+		assert.Equal(t, auditmodel.ActionDelete, entry.Action)
+		assert.Equal(t, "testuser", entry.Before["username"])
+		assert.Nil(t, entry.After)
+		return nil
+	})
+
+	err := svc.Delete(testUUID, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3}, sessions.revoked)
 }
@@ -1,11 +1,11 @@
 package user
 
 import (
+	"github.com/dwilanang/psp/infrastructure/db/postgres"
 	"github.com/dwilanang/psp/internal/user/service"
-	"github.com/jmoiron/sqlx"
 )
 
 type Dependencies struct {
-	DBPostgres *sqlx.DB
+	DBPostgres *postgres.DB
 	Service    service.Service
 }
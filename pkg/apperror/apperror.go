@@ -0,0 +1,144 @@
+// Package apperror defines the typed errors shared by every layer. Repositories and services return them,
+// and the error middleware turns their Kind into the HTTP status of the response.
+package apperror
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Kind classifies an error by how the caller should react to it.
+type Kind int
+
+const (
+	KindInternal      Kind = iota // something failed on our side; the message is not shown to clients
+	KindNotFound                  // the resource does not exist or is hidden from the caller
+	KindConflict                  // the request clashes with the current state, e.g. a duplicate name
+	KindValidation                // the request itself is invalid
+	KindUnprocessable             // the request is valid but cannot be carried out with the data at hand
+	KindUnauthorized              // the caller is not authenticated
+	KindForbidden                 // the caller is authenticated but not allowed
+)
+
+// Error is an error with a Kind and a message safe to return to clients.
+// Err keeps the underlying cause, if any, for errors.Is, errors.As and logs.
+// Fields holds the message of every invalid request field, keyed by field name, for validation errors.
+type Error struct {
+	Kind    Kind
+	Message string
+	Err     error
+	Fields  map[string]string
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New creates an error of kind with message.
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// Wrap creates an error of kind with message, caused by err.
+func Wrap(kind Kind, message string, err error) *Error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+// NotFound creates a KindNotFound error.
+func NotFound(message string) *Error {
+	return New(KindNotFound, message)
+}
+
+// Conflict creates a KindConflict error.
+func Conflict(message string) *Error {
+	return New(KindConflict, message)
+}
+
+// Validation creates a KindValidation error.
+func Validation(message string) *Error {
+	return New(KindValidation, message)
+}
+
+// InvalidFields creates a KindValidation error listing the message of every invalid field.
+func InvalidFields(message string, fields map[string]string) *Error {
+	return &Error{Kind: KindValidation, Message: message, Fields: fields}
+}
+
+// Unprocessable creates a KindUnprocessable error.
+func Unprocessable(message string) *Error {
+	return New(KindUnprocessable, message)
+}
+
+// Unauthorized creates a KindUnauthorized error.
+func Unauthorized(message string) *Error {
+	return New(KindUnauthorized, message)
+}
+
+// Forbidden creates a KindForbidden error.
+func Forbidden(message string) *Error {
+	return New(KindForbidden, message)
+}
+
+// KindOf returns the Kind of the outermost *Error in err's chain, or KindInternal when there is none.
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+	return KindInternal
+}
+
+// IsNotFound reports whether err is a KindNotFound error.
+func IsNotFound(err error) bool {
+	return KindOf(err) == KindNotFound
+}
+
+// FieldsOf returns the invalid fields of the outermost *Error in err's chain, or nil when there are none.
+func FieldsOf(err error) map[string]string {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Fields
+	}
+	return nil
+}
+
+// Status returns the HTTP status code of kind.
+func Status(kind Kind) int {
+	switch kind {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindUnprocessable:
+		return http.StatusUnprocessableEntity
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Message returns the text of err safe to return to clients, or fallback for internal errors.
+// The details services add by wrapping an *Error are kept, while its cause, e.g. a database error, is left out.
+func Message(err error, fallback string) string {
+	var appErr *Error
+	if !errors.As(err, &appErr) || appErr.Kind == KindInternal {
+		return fallback
+	}
+	if appErr.Err != nil {
+		return strings.TrimSuffix(err.Error(), ": "+appErr.Err.Error())
+	}
+	return err.Error()
+}
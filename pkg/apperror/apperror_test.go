@@ -0,0 +1,45 @@
+package apperror
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindOf(t *testing.T) {
+	notFound := NotFound("role not found")
+
+	assert.Equal(t, KindNotFound, KindOf(notFound))
+	assert.Equal(t, KindNotFound, KindOf(fmt.Errorf("update: %w", notFound)))
+	assert.Equal(t, KindInternal, KindOf(errors.New("connection reset")))
+	assert.True(t, IsNotFound(Wrap(KindNotFound, "role not found", sql.ErrNoRows)))
+}
+
+func TestWrap_KeepsCause(t *testing.T) {
+	err := Wrap(KindNotFound, "role not found", sql.ErrNoRows)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Equal(t, "role not found: sql: no rows in result set", err.Error())
+}
+
+func TestStatus(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, Status(KindNotFound))
+	assert.Equal(t, http.StatusConflict, Status(KindConflict))
+	assert.Equal(t, http.StatusBadRequest, Status(KindValidation))
+	assert.Equal(t, http.StatusUnprocessableEntity, Status(KindUnprocessable))
+	assert.Equal(t, http.StatusUnauthorized, Status(KindUnauthorized))
+	assert.Equal(t, http.StatusForbidden, Status(KindForbidden))
+	assert.Equal(t, http.StatusInternalServerError, Status(KindInternal))
+}
+
+func TestMessage(t *testing.T) {
+	invalid := Validation("invalid privilege")
+
+	assert.Equal(t, "invalid privilege: unknown permission", Message(fmt.Errorf("%w: unknown permission", invalid), "could not create role"))
+	assert.Equal(t, "role not found", Message(Wrap(KindNotFound, "role not found", sql.ErrNoRows), "could not update role"))
+	assert.Equal(t, "could not update role", Message(errors.New("connection reset"), "could not update role"))
+}
@@ -0,0 +1,206 @@
+// Package query parses the pagination, sorting and filtering parameters of list endpoints and turns them
+// into parameterized SQL fragments for sqlx.
+//
+// Clients page with ?page and ?per_page, or with the cursor of the next page in ?after, sort with
+// ?sort=-created_at,name (a leading "-" sorts descending) and filter with ?filter[name]=value.
+// Which fields may be sorted and filtered on is whitelisted per resource by a Schema, and column names never
+// come from the request, so the fragments are safe to concatenate into a query.
+package query
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/dwilanang/psp/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultPerPage is the page size when ?per_page is not given.
+	DefaultPerPage = 20
+	// MaxPerPage is the largest page size a client may ask for.
+	MaxPerPage = 100
+)
+
+// Sort orders a listing by a field.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Filter restricts a listing to the rows whose field matches Value.
+type Filter struct {
+	Field string
+	Value string
+}
+
+// Params are the pagination, sorting and filtering parameters of a list request.
+// They are only checked for syntax by Parse; Schema.SQL checks them against the resource's whitelist.
+type Params struct {
+	Page    int
+	PerPage int
+	Sort    []Sort
+	Filters []Filter
+
+	// After holds the sort values of the last row of the previous page when paging with a cursor.
+	After []any
+}
+
+// Parse reads the list parameters of the request in c.
+// It returns a validation error listing every malformed parameter.
+func Parse(c *gin.Context) (Params, error) {
+	values := c.Request.URL.Query()
+	p := Params{Page: 1, PerPage: DefaultPerPage}
+	invalid := map[string]string{}
+
+	if v := values.Get("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			invalid["page"] = "page must be a positive number"
+		}
+		p.Page = page
+	}
+	if v := values.Get("per_page"); v != "" {
+		perPage, err := strconv.Atoi(v)
+		if err != nil || perPage < 1 || perPage > MaxPerPage {
+			invalid["per_page"] = fmt.Sprintf("per_page must be a number between 1 and %d", MaxPerPage)
+		}
+		p.PerPage = perPage
+	}
+
+	if v := values.Get("sort"); v != "" {
+		seen := map[string]bool{}
+		for _, field := range strings.Split(v, ",") {
+			field = strings.TrimSpace(field)
+			desc := strings.HasPrefix(field, "-")
+			field = strings.TrimLeft(field, "+-")
+			if field == "" || seen[field] {
+				invalid["sort"] = "sort must be a comma separated list of distinct fields, each optionally prefixed with -"
+				break
+			}
+			seen[field] = true
+			p.Sort = append(p.Sort, Sort{Field: field, Desc: desc})
+		}
+	}
+
+	for key, v := range values {
+		field, ok := filterField(key)
+		if !ok {
+			continue
+		}
+		if field == "" {
+			invalid[key] = "filter must name a field, e.g. filter[name]"
+			continue
+		}
+		p.Filters = append(p.Filters, Filter{Field: field, Value: v[0]})
+	}
+	// Order filters by field, so the SQL built from them does not depend on map iteration.
+	sort.Slice(p.Filters, func(i, j int) bool { return p.Filters[i].Field < p.Filters[j].Field })
+
+	if v := values.Get("after"); v != "" {
+		after, err := decodeCursor(v)
+		if err != nil {
+			invalid["after"] = "after must be the cursor of a previous page"
+		}
+		if values.Has("page") {
+			invalid["page"] = "page cannot be combined with after"
+		}
+		p.After = after
+	}
+
+	if len(invalid) > 0 {
+		return Params{}, apperror.InvalidFields("invalid list parameters", invalid)
+	}
+	return p, nil
+}
+
+// Offset returns the number of rows before the requested page; it is 0 when paging with a cursor.
+func (p Params) Offset() int {
+	if p.After != nil {
+		return 0
+	}
+	return (p.Page - 1) * p.PerPage
+}
+
+// Meta returns the pagination metadata of a page of result, with links to the previous and next pages built
+// from the request in c. A listing paged with a cursor only links forward.
+func Meta[T any](c *gin.Context, p Params, result Result[T]) *response.Meta {
+	if p.After != nil {
+		meta := response.NewMeta(0, p.PerPage, result.Total)
+		if result.Next != "" {
+			meta.Next = link(c, "after", result.Next)
+			meta.NextCursor = result.Next
+		}
+		return meta
+	}
+
+	// Numbered pages link to the next page number, and expose the cursor so clients can switch to cursors.
+	meta := response.NewMeta(p.Page, p.PerPage, result.Total)
+	meta.NextCursor = result.Next
+	if p.Page < meta.TotalPages {
+		meta.Next = link(c, "page", strconv.Itoa(p.Page+1))
+	}
+	if p.Page > 1 {
+		meta.Prev = link(c, "page", strconv.Itoa(min(p.Page-1, max(meta.TotalPages, 1))))
+	}
+	return meta
+}
+
+// link returns the URL of the request in c with key set to value, leaving out the other way of paging.
+func link(c *gin.Context, key string, value string) string {
+	values := c.Request.URL.Query()
+	values.Del("page")
+	values.Del("after")
+	values.Set(key, value)
+
+	u := url.URL{Path: c.Request.URL.Path, RawQuery: values.Encode()}
+	return u.String()
+}
+
+// filterField returns the field of a filter[field] query key, and whether key is one.
+func filterField(key string) (string, bool) {
+	if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(key, "filter["), "]"), true
+}
+
+// encodeCursor encodes the sort values of a row as an opaque cursor.
+func encodeCursor(values []any) (string, error) {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(cursor string) ([]any, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []any
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&values); err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("empty cursor")
+	}
+	for i, v := range values {
+		// Numbers are passed on as their text so large IDs keep their precision.
+		if n, ok := v.(json.Number); ok {
+			values[i] = n.String()
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,166 @@
+package query
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	ID   int64
+	Name string
+}
+
+var items = Schema[item]{
+	Key: "id",
+	Fields: map[string]Field[item]{
+		"id":     {Column: "i.id", Sortable: true, Filter: Equal, Value: func(i item) any { return i.ID }},
+		"name":   {Column: "i.name", Sortable: true, Filter: Contains, Value: func(i item) any { return i.Name }},
+		"status": {Column: "i.status", Filter: Equal},
+	},
+}
+
+func newContext(target string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	return c
+}
+
+func TestParse(t *testing.T) {
+	p, err := Parse(newContext("/items?page=3&per_page=10&sort=-name,id&filter[status]=active&filter[name]=a"))
+
+	require.NoError(t, err)
+	assert.Equal(t, Params{
+		Page:    3,
+		PerPage: 10,
+		Sort:    []Sort{{Field: "name", Desc: true}, {Field: "id"}},
+		Filters: []Filter{{Field: "name", Value: "a"}, {Field: "status", Value: "active"}},
+	}, p)
+	assert.Equal(t, 20, p.Offset())
+}
+
+func TestParse_Defaults(t *testing.T) {
+	p, err := Parse(newContext("/items"))
+
+	require.NoError(t, err)
+	assert.Equal(t, Params{Page: 1, PerPage: DefaultPerPage}, p)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse(newContext("/items?page=0&per_page=500&sort=name,name&filter[]=x&after=%21"))
+
+	assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+	assert.ElementsMatch(t, []string{"page", "per_page", "sort", "filter[]", "after"}, keys(apperror.FieldsOf(err)))
+}
+
+func TestSchema_SQL(t *testing.T) {
+	q, err := items.SQL(Params{
+		Page:    2,
+		PerPage: 10,
+		Sort:    []Sort{{Field: "name", Desc: true}},
+		Filters: []Filter{{Field: "name", Value: "50%"}, {Field: "status", Value: "active"}},
+	}, int64(7))
+
+	require.NoError(t, err)
+	assert.Equal(t, "i.name ILIKE $2 AND i.status = $3", q.Filter)
+	assert.Equal(t, []any{int64(7), `%50\%%`, "active"}, q.FilterArgs)
+	assert.Equal(t, q.Filter, q.Where)
+	assert.Equal(t, "ORDER BY i.name DESC, i.id", q.OrderBy)
+	assert.Equal(t, "LIMIT $4 OFFSET $5", q.Limit)
+	assert.Equal(t, []any{int64(7), `%50\%%`, "active", 11, 10}, q.Args)
+}
+
+func TestSchema_SQL_Cursor(t *testing.T) {
+	q, err := items.SQL(Params{
+		Page:    1,
+		PerPage: 10,
+		Sort:    []Sort{{Field: "name", Desc: true}},
+		After:   []any{"Widget", "42"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "TRUE", q.Filter)
+	assert.Equal(t, "((i.name < $1) OR (i.name = $2 AND i.id > $3))", q.Where)
+	assert.Equal(t, "LIMIT $4 OFFSET $5", q.Limit)
+	assert.Equal(t, []any{"Widget", "Widget", "42", 11, 0}, q.Args)
+}
+
+func TestSchema_SQL_NotWhitelisted(t *testing.T) {
+	_, err := items.SQL(Params{Page: 1, PerPage: 10, Sort: []Sort{{Field: "status"}}})
+	assert.Equal(t, map[string]string{"sort": "status cannot be sorted on"}, apperror.FieldsOf(err))
+
+	_, err = items.SQL(Params{Page: 1, PerPage: 10, Filters: []Filter{{Field: "secret", Value: "x"}}})
+	assert.Equal(t, map[string]string{"filter[secret]": "secret cannot be filtered on"}, apperror.FieldsOf(err))
+
+	_, err = items.SQL(Params{Page: 1, PerPage: 10, After: []any{"1"}, Sort: []Sort{{Field: "name"}}})
+	assert.Contains(t, apperror.FieldsOf(err), "after")
+}
+
+func TestSchema_Result(t *testing.T) {
+	p := Params{Page: 1, PerPage: 2, Sort: []Sort{{Field: "name"}}}
+
+	result, err := items.Result(p, []item{{1, "a"}, {2, "b"}, {3, "c"}}, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []item{{1, "a"}, {2, "b"}}, result.Rows)
+	assert.Equal(t, int64(5), result.Total)
+
+	after, err := decodeCursor(result.Next)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"b", "2"}, after)
+
+	result, err = items.Result(p, []item{{1, "a"}}, 5)
+	require.NoError(t, err)
+	assert.Empty(t, result.Next)
+}
+
+func TestMeta(t *testing.T) {
+	c := newContext("/api/v1/items?page=2&per_page=2&sort=-name")
+	meta := Meta(c, Params{Page: 2, PerPage: 2}, Result[item]{Total: 5, Next: "cursor"})
+
+	assert.Equal(t, 3, meta.TotalPages)
+	assert.Equal(t, "/api/v1/items?page=3&per_page=2&sort=-name", meta.Next)
+	assert.Equal(t, "/api/v1/items?page=1&per_page=2&sort=-name", meta.Prev)
+
+	c = newContext("/api/v1/items?after=abc&per_page=2")
+	meta = Meta(c, Params{Page: 1, PerPage: 2, After: []any{"1"}}, Result[item]{Total: 5, Next: "def"})
+
+	assert.Equal(t, 0, meta.Page)
+	assert.Equal(t, "/api/v1/items?after=def&per_page=2", meta.Next)
+	assert.Equal(t, "def", meta.NextCursor)
+	assert.Empty(t, meta.Prev)
+}
+
+func TestMeta_FromPageOntoCursor(t *testing.T) {
+	c := newContext("/api/v1/items?page=1&per_page=2&sort=name")
+	p, err := Parse(c)
+	require.NoError(t, err)
+	result, err := items.Result(p, []item{{1, "a"}, {2, "b"}, {3, "c"}}, 5)
+	require.NoError(t, err)
+
+	meta := Meta(c, p, result)
+	require.NotEmpty(t, meta.NextCursor)
+	assert.Equal(t, "/api/v1/items?page=2&per_page=2&sort=name", meta.Next)
+
+	// The cursor of page 1 continues right after its last row.
+	c = newContext("/api/v1/items?per_page=2&sort=name&after=" + meta.NextCursor)
+	p, err = Parse(c)
+	require.NoError(t, err)
+	q, err := items.SQL(p)
+	require.NoError(t, err)
+	assert.Equal(t, "((i.name > $1) OR (i.name = $2 AND i.id > $3))", q.Where)
+	assert.Equal(t, []any{"b", "b", "2", 3, 0}, q.Args)
+}
+
+func keys(m map[string]string) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
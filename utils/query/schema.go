@@ -0,0 +1,209 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dwilanang/psp/pkg/apperror"
+)
+
+// Operator is how a filter compares a field with the value given by the client.
+type Operator int
+
+const (
+	NoFilter Operator = iota // the field cannot be filtered on
+	Equal                    // the field equals the value
+	Contains                 // the field contains the value, ignoring case
+)
+
+// Field is a field of resource T that clients may sort or filter on.
+type Field[T any] struct {
+	// Column is the SQL expression the field is read from. It must not be NULL for sortable fields, and must
+	// be as precise as Value, e.g. truncated to the second when Value is, for cursors to work.
+	Column string
+
+	// Sortable allows the field in ?sort.
+	Sortable bool
+
+	// Filter is how ?filter[field] applies to the field.
+	Filter Operator
+
+	// Value returns the field of a row. It is required for sortable fields, whose values make up cursors.
+	Value func(row T) any
+}
+
+// Schema whitelists the fields of resource T that list requests may sort and filter on.
+type Schema[T any] struct {
+	Fields map[string]Field[T]
+
+	// Key names a sortable field unique to every row, e.g. the ID. It ends every sort, so rows sorted on
+	// equal values keep a stable order and cursors point at exactly one row.
+	Key string
+
+	// DefaultSort is used when the request does not sort; the listing is then sorted by Key alone when empty.
+	DefaultSort []Sort
+}
+
+// SQL holds the fragments of a list query built by Schema.SQL, numbered to follow the arguments the caller
+// passed to it.
+type SQL struct {
+	// Filter is the filters' conditions joined with AND, or TRUE when there are none. Count queries use it
+	// with FilterArgs, so the total covers every page.
+	Filter     string
+	FilterArgs []any
+
+	// Where adds the cursor's condition to Filter.
+	Where string
+
+	// OrderBy is the ORDER BY clause, and Limit the LIMIT and OFFSET clause.
+	OrderBy string
+	Limit   string
+
+	// Args are the arguments of Where and Limit, following those passed to Schema.SQL.
+	Args []any
+}
+
+// Result is a page of a listing, as returned by Schema.Result.
+type Result[T any] struct {
+	Rows  []T
+	Total int64
+
+	// Next is the cursor of the next page, or empty on the last page.
+	Next string
+}
+
+// SQL checks p against the schema and builds the fragments of its query. args are arguments of conditions
+// the caller adds to the query itself; the fragments' placeholders are numbered after them.
+// It returns a validation error when p sorts or filters on a field the schema does not allow.
+//
+// The query selects one row more than the page holds, so Result can tell whether there is a next page.
+func (s Schema[T]) SQL(p Params, args ...any) (SQL, error) {
+	sorts, err := s.sorts(p)
+	if err != nil {
+		return SQL{}, err
+	}
+
+	args = append([]any{}, args...)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	var q SQL
+	conditions := []string{}
+	invalid := map[string]string{}
+	for _, f := range p.Filters {
+		field, ok := s.Fields[f.Field]
+		switch {
+		case !ok || field.Filter == NoFilter:
+			invalid["filter["+f.Field+"]"] = fmt.Sprintf("%s cannot be filtered on", f.Field)
+		case field.Filter == Equal:
+			conditions = append(conditions, field.Column+" = "+arg(f.Value))
+		case field.Filter == Contains:
+			conditions = append(conditions, field.Column+" ILIKE "+arg("%"+EscapeLike(f.Value)+"%"))
+		}
+	}
+	if len(invalid) > 0 {
+		return SQL{}, apperror.InvalidFields("invalid list parameters", invalid)
+	}
+	q.Filter = and(conditions)
+	q.FilterArgs = append([]any{}, args...)
+
+	if p.After != nil {
+		if len(p.After) != len(sorts) {
+			return SQL{}, apperror.InvalidFields("invalid list parameters", map[string]string{
+				"after": "after must be the cursor of a previous page with the same sort",
+			})
+		}
+
+		// Rows after the cursor: (a > x) OR (a = x AND b > y) OR ..., with < for descending fields.
+		var alternatives []string
+		for i, sort := range sorts {
+			var terms []string
+			for j := 0; j < i; j++ {
+				terms = append(terms, s.Fields[sorts[j].Field].Column+" = "+arg(p.After[j]))
+			}
+			op := " > "
+			if sort.Desc {
+				op = " < "
+			}
+			terms = append(terms, s.Fields[sort.Field].Column+op+arg(p.After[i]))
+			alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+		}
+		conditions = append(conditions, "("+strings.Join(alternatives, " OR ")+")")
+	}
+	q.Where = and(conditions)
+
+	var order []string
+	for _, sort := range sorts {
+		column := s.Fields[sort.Field].Column
+		if sort.Desc {
+			column += " DESC"
+		}
+		order = append(order, column)
+	}
+	q.OrderBy = "ORDER BY " + strings.Join(order, ", ")
+
+	q.Limit = "LIMIT " + arg(p.PerPage+1) + " OFFSET " + arg(p.Offset())
+	q.Args = args
+	return q, nil
+}
+
+// Result returns the page of rows selected by a query built with SQL for p, of total rows in all.
+func (s Schema[T]) Result(p Params, rows []T, total int64) (Result[T], error) {
+	result := Result[T]{Rows: rows, Total: total}
+	if len(rows) <= p.PerPage {
+		return result, nil
+	}
+	result.Rows = rows[:p.PerPage]
+
+	sorts, err := s.sorts(p)
+	if err != nil {
+		return Result[T]{}, err
+	}
+	last := result.Rows[len(result.Rows)-1]
+	values := make([]any, len(sorts))
+	for i, sort := range sorts {
+		values[i] = s.Fields[sort.Field].Value(last)
+	}
+	result.Next, err = encodeCursor(values)
+	if err != nil {
+		return Result[T]{}, err
+	}
+	return result, nil
+}
+
+// sorts returns the sort of p, or the default one, ending with Key.
+func (s Schema[T]) sorts(p Params) ([]Sort, error) {
+	sorts := p.Sort
+	if len(sorts) == 0 {
+		sorts = s.DefaultSort
+	}
+
+	var out []Sort
+	for _, sort := range sorts {
+		if field, ok := s.Fields[sort.Field]; !ok || !field.Sortable {
+			return nil, apperror.InvalidFields("invalid list parameters", map[string]string{
+				"sort": fmt.Sprintf("%s cannot be sorted on", sort.Field),
+			})
+		}
+		if sort.Field == s.Key {
+			return append(out, sort), nil
+		}
+		out = append(out, sort)
+	}
+	return append(out, Sort{Field: s.Key}), nil
+}
+
+func and(conditions []string) string {
+	if len(conditions) == 0 {
+		return "TRUE"
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// EscapeLike escapes the wildcards of a LIKE pattern, so the value given by the client matches literally
+// with Postgres' default escape character.
+func EscapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -2,20 +2,40 @@ package request
 
 import (
 	"errors"
+	"strings"
 
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
 
-func ValidateRequest(err error) any {
+// ValidateRequest turns an error from binding a request into a validation error, in the language the
+// request's Accept-Language header asks for; English and Indonesian are supported.
+// Failed validation rules are listed per field, keyed by the field's JSON path such as "salary.amount" or
+// "items[0].name"; anything else, such as malformed JSON, is reported as an invalid payload.
+func ValidateRequest(c *gin.Context, err error) error {
+	trans := translator(c.GetHeader("Accept-Language"))
+
 	var ve validator.ValidationErrors
 	if errors.As(err, &ve) {
 		out := make(map[string]string)
 		for _, fe := range ve {
-			out[fe.Field()] = "This field is required"
+			out[fieldPath(fe)] = fe.Translate(trans)
 		}
 
-		return out
+		message, _ := trans.T("invalid_fields")
+		return apperror.InvalidFields(message, out)
 	} else {
-		return "invalid payload"
+		message, _ := trans.T("invalid_payload")
+		return apperror.Wrap(apperror.KindValidation, message, err)
+	}
+}
+
+// fieldPath returns the path of fe's field from the bound struct, leaving out the struct's own name.
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
 	}
+	return path
 }
@@ -0,0 +1,96 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dwilanang/psp/pkg/apperror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type testRequest struct {
+	Username string     `json:"username" binding:"required,username"`
+	Password string     `json:"password" binding:"required,strong_password"`
+	Role     string     `json:"role" binding:"oneof=admin employee"`
+	Salary   testSalary `json:"salary"`
+	Items    []testItem `json:"items" binding:"dive"`
+}
+
+type testSalary struct {
+	EffectiveFrom string `json:"effective_from" binding:"required,iso_date"`
+}
+
+func bind(t *testing.T, body string, acceptLanguage string) error {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("Accept-Language", acceptLanguage)
+
+	var req testRequest
+	err := c.ShouldBindJSON(&req)
+	require.Error(t, err)
+	return ValidateRequest(c, err)
+}
+
+func TestValidateRequest(t *testing.T) {
+	err := bind(t, `{
+		"username": "-alice",
+		"password": "secret",
+		"role": "owner",
+		"salary": {"effective_from": "2025-02-30"},
+		"items": [{"name": "a"}, {}]
+	}`, "")
+
+	assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+	assert.EqualError(t, err, "one or more fields are invalid")
+	assert.Equal(t, map[string]string{
+		"username":              "username may only contain letters, digits, dots, underscores and hyphens, and must start with a letter or digit",
+		"password":              "password must be at least 8 characters long and contain an upper case letter, a lower case letter, a digit and a symbol",
+		"role":                  "role must be one of [admin employee]",
+		"salary.effective_from": "effective_from must be a valid date formatted as YYYY-MM-DD",
+		"items[1].name":         "name is a required field",
+	}, apperror.FieldsOf(err))
+}
+
+func TestValidateRequest_Indonesian(t *testing.T) {
+	err := bind(t, `{"username": "alice", "password": "S3cret!pass", "role": "admin", "salary": {}}`, "id-ID,id;q=0.9,en;q=0.8")
+
+	assert.EqualError(t, err, "satu atau lebih field tidak valid")
+	assert.Equal(t, map[string]string{
+		"salary.effective_from": "effective_from wajib diisi",
+	}, apperror.FieldsOf(err))
+}
+
+func TestValidateRequest_InvalidPayload(t *testing.T) {
+	err := bind(t, `{"username":`, "fr-FR")
+
+	assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+	assert.Equal(t, "invalid payload", apperror.Message(err, ""))
+	assert.Nil(t, apperror.FieldsOf(err))
+}
+
+func TestIsStrongPassword(t *testing.T) {
+	assert.True(t, IsStrongPassword("S3cret!pass"))
+	assert.False(t, IsStrongPassword("S3c!t"))
+	assert.False(t, IsStrongPassword("s3cret!pass"))
+	assert.False(t, IsStrongPassword("Secret!pass"))
+	assert.False(t, IsStrongPassword("S3cretpass1"))
+}
+
+func TestIsValidUsername(t *testing.T) {
+	assert.True(t, IsValidUsername("jane.doe_01"))
+	assert.True(t, IsValidUsername("9-lives"))
+	assert.False(t, IsValidUsername(""))
+	assert.False(t, IsValidUsername(".jane"))
+	assert.False(t, IsValidUsername("jane doe"))
+	assert.False(t, IsValidUsername("jane@doe"))
+}
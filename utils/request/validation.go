@@ -0,0 +1,177 @@
+package request
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+	idtranslations "github.com/go-playground/validator/v10/translations/id"
+)
+
+// DefaultLocale is used when the request asks for no supported language.
+const DefaultLocale = "en"
+
+// minPasswordLength is the shortest password accepted by strong_password.
+const minPasswordLength = 8
+
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// customValidators are the validation tags added to the ones built into the validator.
+var customValidators = map[string]validator.Func{
+	// strong_password: at least 8 characters with an upper case letter, a lower case letter, a digit and a symbol.
+	"strong_password": func(fl validator.FieldLevel) bool {
+		return IsStrongPassword(fl.Field().String())
+	},
+	// username: letters, digits, dots, underscores and hyphens, starting with a letter or digit.
+	"username": func(fl validator.FieldLevel) bool {
+		return IsValidUsername(fl.Field().String())
+	},
+	// iso_date: a calendar date formatted as YYYY-MM-DD.
+	"iso_date": func(fl validator.FieldLevel) bool {
+		_, err := time.Parse(time.DateOnly, fl.Field().String())
+		return err == nil
+	},
+}
+
+// messages holds, per locale, the translations of the custom tags and of the summaries ValidateRequest returns.
+// {0} is replaced by the field name.
+var messages = map[string]map[string]string{
+	"en": {
+		"strong_password": "{0} must be at least 8 characters long and contain an upper case letter, a lower case letter, a digit and a symbol",
+		"username":        "{0} may only contain letters, digits, dots, underscores and hyphens, and must start with a letter or digit",
+		"iso_date":        "{0} must be a valid date formatted as YYYY-MM-DD",
+		"invalid_fields":  "one or more fields are invalid",
+		"invalid_payload": "invalid payload",
+	},
+	"id": {
+		"strong_password": "{0} harus terdiri dari minimal 8 karakter dan mengandung huruf besar, huruf kecil, angka, dan simbol",
+		"username":        "{0} hanya boleh berisi huruf, angka, titik, garis bawah, dan tanda hubung, serta harus diawali huruf atau angka",
+		"iso_date":        "{0} harus berupa tanggal yang valid dengan format YYYY-MM-DD",
+		"invalid_fields":  "satu atau lebih field tidak valid",
+		"invalid_payload": "payload tidak valid",
+	},
+}
+
+var translators = ut.New(en.New(), en.New(), id.New())
+
+// init registers the custom validators and the English and Indonesian translations with the validator Gin
+// binds requests with, so they apply before any handler runs.
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	if err := setup(v); err != nil {
+		panic(err)
+	}
+}
+
+func setup(v *validator.Validate) error {
+	v.RegisterTagNameFunc(fieldName)
+
+	for tag, fn := range customValidators {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			return err
+		}
+	}
+
+	registerDefaults := map[string]func(*validator.Validate, ut.Translator) error{
+		"en": entranslations.RegisterDefaultTranslations,
+		"id": idtranslations.RegisterDefaultTranslations,
+	}
+	for locale, register := range registerDefaults {
+		trans, _ := translators.GetTranslator(locale)
+		if err := register(v, trans); err != nil {
+			return err
+		}
+
+		for key, text := range messages[locale] {
+			if err := trans.Add(key, text, true); err != nil {
+				return err
+			}
+		}
+		for tag := range customValidators {
+			err := v.RegisterTranslation(tag, trans, func(ut.Translator) error { return nil }, translateCustom)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func translateCustom(trans ut.Translator, fe validator.FieldError) string {
+	message, err := trans.T(fe.Tag(), fe.Field())
+	if err != nil {
+		return fe.Error()
+	}
+	return message
+}
+
+// fieldName names fields after their JSON key, or their query/form key for structs bound from a query string,
+// so validation errors refer to what the client sent.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// translator returns the translator of the first supported language in an Accept-Language header,
+// or the DefaultLocale one.
+func translator(acceptLanguage string) ut.Translator {
+	var locales []string
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		language, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if language != "" {
+			locales = append(locales, language)
+		}
+	}
+
+	trans, _ := translators.FindTranslator(append(locales, DefaultLocale)...)
+	return trans
+}
+
+// IsStrongPassword reports whether a password satisfies strong_password, for passwords that are not bound
+// from a request, e.g. those given on the command line.
+func IsStrongPassword(password string) bool {
+	if len([]rune(password)) < minPasswordLength {
+		return false
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	return upper && lower && digit && symbol
+}
+
+// IsValidUsername reports whether a username satisfies username, for usernames that are not bound from a request,
+// e.g. those given on the command line.
+func IsValidUsername(username string) bool {
+	return usernamePattern.MatchString(username)
+}
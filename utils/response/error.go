@@ -0,0 +1,10 @@
+package response
+
+import "github.com/gin-gonic/gin"
+
+// Error stops the request and hands err to the error middleware, which writes the response with the status
+// of err's apperror.Kind. message is shown instead of err when err is not a typed error, e.g. a database failure.
+func Error(c *gin.Context, err error, message string) {
+	c.Abort()
+	_ = c.Error(err).SetMeta(message)
+}
@@ -0,0 +1,60 @@
+package response
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/dwilanang/psp/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType is the media type of error responses, defined by RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// Problem is the body of every error response, following RFC 7807.
+// Type identifies the kind of problem and is derived from the status, e.g. /problems/not-found, so clients
+// can switch on it; Detail explains this occurrence and RequestID ties it to the server logs.
+type Problem struct {
+	Type      string       `json:"type" example:"/problems/not-found"`
+	Title     string       `json:"title" example:"Not Found"`
+	Status    int          `json:"status" example:"404"`
+	Detail    string       `json:"detail" example:"role not found"`
+	Instance  string       `json:"instance" example:"/api/v1/roles/update/7"`
+	RequestID string       `json:"request_id" example:"0b5f9a4e-3c1d-4d8e-9a43-5f2c7b1e6d90"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError is the problem with one field of the request.
+type FieldError struct {
+	Field   string `json:"field" example:"name"`
+	Message string `json:"message" example:"This field is required"`
+}
+
+// NewProblem creates the Problem of the current request with status and detail.
+// fields, when given, become the per-field Errors, sorted by field name.
+func NewProblem(c *gin.Context, status int, detail string, fields map[string]string) Problem {
+	title := http.StatusText(status)
+	problem := Problem{
+		Type:      "/problems/" + strings.ToLower(strings.ReplaceAll(title, " ", "-")),
+		Title:     title,
+		Status:    status,
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		RequestID: logger.GetRequestID(c),
+	}
+
+	for field, message := range fields {
+		problem.Errors = append(problem.Errors, FieldError{Field: field, Message: message})
+	}
+	sort.Slice(problem.Errors, func(i, j int) bool {
+		return problem.Errors[i].Field < problem.Errors[j].Field
+	})
+	return problem
+}
+
+// WriteProblem writes problem as application/problem+json with its status.
+func WriteProblem(c *gin.Context, problem Problem) {
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(problem.Status, problem)
+}
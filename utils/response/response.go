@@ -1,7 +1,63 @@
 package response
 
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ApiResponse is the envelope of every successful JSON response.
+// Meta is only set on paginated listings.
 type ApiResponse struct {
 	Status  bool   `json:"status"`
 	Message string `json:"message"`
 	Data    any    `json:"data"`
+	Meta    *Meta  `json:"meta,omitempty"`
+}
+
+// Meta describes the page of a paginated listing.
+// Page is left out for pages requested with a cursor; Next and Prev link to the neighbouring pages, if any.
+type Meta struct {
+	Page       int    `json:"page,omitempty" example:"1"`
+	PerPage    int    `json:"per_page" example:"20"`
+	Total      int64  `json:"total" example:"42"`
+	TotalPages int    `json:"total_pages" example:"3"`
+	Next       string `json:"next,omitempty" example:"/api/v1/roles/all?page=2&per_page=20"`
+	Prev       string `json:"prev,omitempty"`
+	// NextCursor is the cursor to pass as after to continue from this page without offsets.
+	NextCursor string `json:"next_cursor,omitempty" example:"WyJiIiwiMiJd"`
+}
+
+// NewMeta creates the Meta of page, holding at most perPage of total items.
+func NewMeta(page int, perPage int, total int64) *Meta {
+	meta := &Meta{Page: page, PerPage: perPage, Total: total}
+	if perPage > 0 {
+		meta.TotalPages = int((total + int64(perPage) - 1) / int64(perPage))
+	}
+	return meta
+}
+
+// JSON writes data in the success envelope with status and message.
+func JSON(c *gin.Context, status int, message string, data any) {
+	c.JSON(status, ApiResponse{Status: true, Message: message, Data: data})
+}
+
+// OK writes data in the success envelope with status 200.
+func OK(c *gin.Context, data any) {
+	JSON(c, http.StatusOK, "", data)
+}
+
+// Created writes data in the success envelope with status 201.
+func Created(c *gin.Context, data any) {
+	JSON(c, http.StatusCreated, "", data)
+}
+
+// Message writes a success envelope holding only message, for actions that return no data.
+func Message(c *gin.Context, message string) {
+	JSON(c, http.StatusOK, message, nil)
+}
+
+// Page writes one page of a listing in the success envelope, with its pagination metadata.
+func Page(c *gin.Context, data any, meta *Meta) {
+	c.JSON(http.StatusOK, ApiResponse{Status: true, Data: data, Meta: meta})
 }
@@ -0,0 +1,31 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMeta(t *testing.T) {
+	assert.Equal(t, &Meta{Page: 2, PerPage: 20, Total: 41, TotalPages: 3}, NewMeta(2, 20, 41))
+	assert.Equal(t, &Meta{Page: 1, PerPage: 20, Total: 0, TotalPages: 0}, NewMeta(1, 20, 0))
+}
+
+func TestPage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	Page(c, []string{"alice"}, NewMeta(1, 20, 1))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"status": true,
+		"message": "",
+		"data": ["alice"],
+		"meta": {"page": 1, "per_page": 20, "total": 1, "total_pages": 1}
+	}`, w.Body.String())
+}